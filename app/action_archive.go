@@ -0,0 +1,58 @@
+package app
+
+import (
+	"fmt"
+
+	"gopkg.in/urfave/cli.v1"
+
+	"github.com/zenon-network/go-zenon/node"
+)
+
+var (
+	exportFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Height of the first exported momentum",
+		Value: 1,
+	}
+	exportToFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Height of the last exported momentum (0 for the frontier momentum)",
+	}
+
+	exportCommand = cli.Command{
+		Action:    exportAction,
+		Name:      "export",
+		Usage:     "Export momentums and account-blocks to an archive file",
+		ArgsUsage: "<file>",
+		Flags:     []cli.Flag{exportFromFlag, exportToFlag},
+		Category:  "BLOCKCHAIN COMMANDS",
+	}
+	importCommand = cli.Command{
+		Action:    importAction,
+		Name:      "import",
+		Usage:     "Import momentums and account-blocks from an archive file",
+		ArgsUsage: "<file>",
+		Category:  "BLOCKCHAIN COMMANDS",
+	}
+)
+
+func exportAction(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("export requires exactly one file argument")
+	}
+	cfg, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	return node.ExportChain(cfg, ctx.Uint64(exportFromFlag.Name), ctx.Uint64(exportToFlag.Name), ctx.Args().First())
+}
+func importAction(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		return fmt.Errorf("import requires exactly one file argument")
+	}
+	cfg, err := MakeConfig(ctx)
+	if err != nil {
+		return err
+	}
+	return node.ImportChain(cfg, ctx.Args().First())
+}
@@ -52,6 +52,8 @@ func init() {
 	app.Commands = []cli.Command{
 		versionCommand,
 		licenseCommand,
+		exportCommand,
+		importCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -57,6 +57,13 @@ func applyFlagsToConfig(ctx *cli.Context, cfg *node.Config) {
 		cfg.GenesisFile = genesisFile
 	}
 
+	if ctx.GlobalIsSet(IndexerEnabledFlag.Name) {
+		cfg.EnableIndexer = ctx.GlobalBool(IndexerEnabledFlag.Name)
+	}
+	if ctx.GlobalIsSet(PruneFlag.Name) {
+		cfg.Prune = ctx.GlobalUint64(PruneFlag.Name)
+	}
+
 	// Network Config
 	if identity := ctx.GlobalString(IdentityFlag.Name); ctx.GlobalIsSet(IdentityFlag.Name) && len(identity) > 0 {
 		cfg.Name = identity
@@ -104,6 +111,19 @@ func applyFlagsToConfig(ctx *cli.Context, cfg *node.Config) {
 		cfg.RPC.WSPort = ctx.GlobalInt(WSPortFlag.Name)
 	}
 
+	// Metrics Config
+	if ctx.GlobalIsSet(MetricsEnabledFlag.Name) {
+		cfg.RPC.EnableMetrics = ctx.GlobalBool(MetricsEnabledFlag.Name)
+	}
+
+	if metricsHost := ctx.GlobalString(MetricsListenAddrFlag.Name); ctx.GlobalIsSet(MetricsListenAddrFlag.Name) && len(metricsHost) > 0 {
+		cfg.RPC.MetricsHost = metricsHost
+	}
+
+	if ctx.GlobalIsSet(MetricsPortFlag.Name) {
+		cfg.RPC.MetricsPort = ctx.GlobalInt(MetricsPortFlag.Name)
+	}
+
 	// Log Level Config
 	if logLevel := ctx.GlobalString(LogLvlFlag.Name); ctx.GlobalIsSet(LogLvlFlag.Name) && len(logLevel) > 0 {
 		cfg.LogLevel = logLevel
@@ -38,6 +38,15 @@ var (
 		Usage: "Node's name. Visible in the network.",
 	}
 
+	IndexerEnabledFlag = cli.BoolFlag{
+		Name:  "indexer",
+		Usage: "Enable the address-indexed account-block history",
+	}
+	PruneFlag = cli.Uint64Flag{
+		Name:  "prune",
+		Usage: "Number of epochs of momentums and account-blocks to keep, older ones are deleted (disabled if set to 0)",
+	}
+
 	// network
 
 	ListenHostFlag = cli.StringFlag{
@@ -90,6 +99,22 @@ var (
 		Value: p2p.DefaultWSPort,
 	}
 
+	// metrics
+
+	MetricsEnabledFlag = cli.BoolFlag{
+		Name:  "metrics",
+		Usage: "Enable the prometheus metrics endpoint",
+	}
+	MetricsListenAddrFlag = cli.StringFlag{
+		Name:  "metrics-addr",
+		Usage: "Metrics server listening interface",
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:  "metrics-port",
+		Usage: "Metrics server listening port",
+		Value: p2p.DefaultMetricsPort,
+	}
+
 	// log
 
 	LogLvlFlag = cli.StringFlag{
@@ -105,6 +130,8 @@ var (
 		WalletDirFlag,
 		GenesisFileFlag,
 		IdentityFlag,
+		IndexerEnabledFlag,
+		PruneFlag,
 
 		// network
 		ListenHostFlag,
@@ -122,6 +149,11 @@ var (
 		WSListenAddrFlag,
 		WSPortFlag,
 
+		// metrics
+		MetricsEnabledFlag,
+		MetricsListenAddrFlag,
+		MetricsPortFlag,
+
 		//Log
 		LogLvlFlag,
 	}
@@ -107,6 +107,22 @@ func (ap *accountPool) ForceAddAccountBlockTransaction(insertLocker sync.Locker,
 	defer ap.changes.Unlock()
 	return ap.addAccountBlockTransaction(transaction, true)
 }
+func (ap *accountPool) RollbackAccountBlockTransaction(insertLocker sync.Locker, address types.Address, identifier types.HashHeight) error {
+	if insertLocker == nil {
+		return errors.Errorf("insertLocker can't be nil")
+	}
+	ap.changes.Lock()
+	defer ap.changes.Unlock()
+
+	manager := ap.getAccountManager(address)
+	frontierIdentifier := db.GetFrontierIdentifier(manager.Frontier())
+	if frontierIdentifier != identifier {
+		return errors.Errorf("can't rollback account-block-transaction %v since it's not the frontier %v", identifier, frontierIdentifier)
+	}
+
+	ap.log.Info("rolling back account-block-transaction", "address", address, "identifier", identifier)
+	return manager.Pop()
+}
 func (ap *accountPool) addAccountBlockTransaction(transaction *nom.AccountBlockTransaction, forceAdd bool) error {
 	block := transaction.Block
 	address := block.Address
@@ -0,0 +1,232 @@
+// Package archive implements a portable, checksummed file format for exporting and importing momentums.
+//
+// An archive starts with a header, followed by one record for each nom.DetailedMomentum and ends with a trailer:
+//
+//	header:  magic | version (uint64) | chain identifier (uint64)
+//	record:  length (uint32) | payload | sha256(payload)
+//	payload: momentum length (uint32) | momentum | number of account-blocks (uint32) | { length (uint32) | account-block }
+//	trailer: length 0 (uint32) | number of records (uint64)
+//
+// Momentums and account-blocks use their protobuf serialization.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+)
+
+const (
+	Version = uint64(1)
+
+	// maxRecordSize limits the memory used while reading a corrupted archive
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+var (
+	magic = []byte("ZNNARCHIVE")
+
+	ErrInvalidMagic       = errors.New("invalid archive magic")
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrInvalidChecksum    = errors.New("invalid archive record checksum")
+	ErrInvalidRecord      = errors.New("invalid archive record")
+	ErrTruncated          = errors.New("archive is truncated")
+)
+
+// Writer writes momentums into an archive. Close needs to be called to write the trailer.
+type Writer struct {
+	w     *bufio.Writer
+	count uint64
+}
+
+func NewWriter(w io.Writer, chainIdentifier uint64) (*Writer, error) {
+	writer := &Writer{
+		w: bufio.NewWriter(w),
+	}
+	if _, err := writer.w.Write(common.JoinBytes(magic, common.Uint64ToBytes(Version), common.Uint64ToBytes(chainIdentifier))); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *Writer) Write(detailed *nom.DetailedMomentum) error {
+	momentum, err := detailed.Momentum.Serialize()
+	if err != nil {
+		return err
+	}
+	payload := new(bytes.Buffer)
+	writeBytes(payload, momentum)
+	writeUint32(payload, uint32(len(detailed.AccountBlocks)))
+	for _, block := range detailed.AccountBlocks {
+		data, err := block.Serialize()
+		if err != nil {
+			return err
+		}
+		writeBytes(payload, data)
+	}
+
+	checksum := sha256.Sum256(payload.Bytes())
+	record := new(bytes.Buffer)
+	writeBytes(record, payload.Bytes())
+	record.Write(checksum[:])
+	if _, err := w.w.Write(record.Bytes()); err != nil {
+		return err
+	}
+	w.count += 1
+	return nil
+}
+
+// Count returns the number of momentums written so far
+func (w *Writer) Count() uint64 {
+	return w.count
+}
+
+func (w *Writer) Close() error {
+	trailer := new(bytes.Buffer)
+	writeUint32(trailer, 0)
+	trailer.Write(common.Uint64ToBytes(w.count))
+	if _, err := w.w.Write(trailer.Bytes()); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// Reader reads momentums from an archive, verifying the checksum of each record.
+type Reader struct {
+	r               *bufio.Reader
+	chainIdentifier uint64
+	count           uint64
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{
+		r: bufio.NewReader(r),
+	}
+	header := make([]byte, len(magic)+16)
+	if _, err := io.ReadFull(reader.r, header); err != nil {
+		return nil, ErrInvalidMagic
+	}
+	if !bytes.Equal(header[:len(magic)], magic) {
+		return nil, ErrInvalidMagic
+	}
+	if version := common.BytesToUint64(header[len(magic) : len(magic)+8]); version != Version {
+		return nil, ErrUnsupportedVersion
+	}
+	reader.chainIdentifier = common.BytesToUint64(header[len(magic)+8:])
+	return reader, nil
+}
+
+// ChainIdentifier returns the chain identifier of the exported chain
+func (r *Reader) ChainIdentifier() uint64 {
+	return r.chainIdentifier
+}
+
+// Read returns the next momentum in the archive or io.EOF once the trailer was read and verified
+func (r *Reader) Read() (*nom.DetailedMomentum, error) {
+	length, err := readUint32(r.r)
+	if err != nil {
+		return nil, ErrTruncated
+	}
+	if length == 0 {
+		count := make([]byte, 8)
+		if _, err := io.ReadFull(r.r, count); err != nil {
+			return nil, ErrTruncated
+		}
+		if common.BytesToUint64(count) != r.count {
+			return nil, ErrTruncated
+		}
+		return nil, io.EOF
+	}
+	if length > maxRecordSize {
+		return nil, ErrInvalidRecord
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, ErrTruncated
+	}
+	checksum := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(r.r, checksum); err != nil {
+		return nil, ErrTruncated
+	}
+	if expected := sha256.Sum256(payload); !bytes.Equal(expected[:], checksum) {
+		return nil, ErrInvalidChecksum
+	}
+
+	detailed, err := parsePayload(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	r.count += 1
+	return detailed, nil
+}
+
+func parsePayload(payload *bytes.Reader) (*nom.DetailedMomentum, error) {
+	data, err := readBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	momentum, err := nom.DeserializeMomentum(data)
+	if err != nil {
+		return nil, err
+	}
+	num, err := readUint32(payload)
+	if err != nil {
+		return nil, ErrInvalidRecord
+	}
+	if int(num) != len(momentum.Content) {
+		return nil, ErrInvalidRecord
+	}
+	accountBlocks := make([]*nom.AccountBlock, num)
+	for i := range accountBlocks {
+		data, err := readBytes(payload)
+		if err != nil {
+			return nil, err
+		}
+		if accountBlocks[i], err = nom.DeserializeAccountBlock(data); err != nil {
+			return nil, err
+		}
+	}
+	if payload.Len() != 0 {
+		return nil, ErrInvalidRecord
+	}
+	return &nom.DetailedMomentum{
+		Momentum:      momentum,
+		AccountBlocks: accountBlocks,
+	}, nil
+}
+
+func writeUint32(w *bytes.Buffer, value uint32) {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, value)
+	w.Write(data)
+}
+func writeBytes(w *bytes.Buffer, data []byte) {
+	writeUint32(w, uint32(len(data)))
+	w.Write(data)
+}
+func readUint32(r io.Reader) (uint32, error) {
+	data := make([]byte, 4)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(data), nil
+}
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	length, err := readUint32(r)
+	if err != nil || int(length) > r.Len() {
+		return nil, ErrInvalidRecord
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidRecord
+	}
+	return data, nil
+}
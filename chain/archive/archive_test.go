@@ -0,0 +1,98 @@
+package archive
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func exportMockChain(t *testing.T) (*bytes.Buffer, types.HashHeight) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+
+	for i := 0; i < 5; i += 1 {
+		z.InsertSendBlock(&nom.AccountBlock{
+			Address:       g.User1.Address,
+			ToAddress:     g.User2.Address,
+			TokenStandard: types.ZnnTokenStandard,
+			Amount:        big.NewInt(10 * g.Zexp),
+		}, nil, mock.SkipVmChanges)
+		z.InsertNewMomentum()
+	}
+
+	store := z.Chain().GetFrontierMomentumStore()
+	buffer := new(bytes.Buffer)
+	writer, err := NewWriter(buffer, z.Chain().ChainIdentifier())
+	common.FailIfErr(t, err)
+	for height := uint64(1); height <= store.Identifier().Height; height += 1 {
+		momentum, err := store.GetMomentumByHeight(height)
+		common.FailIfErr(t, err)
+		detailed, err := store.PrefetchMomentum(momentum)
+		common.FailIfErr(t, err)
+		common.FailIfErr(t, writer.Write(detailed))
+	}
+	common.FailIfErr(t, writer.Close())
+	return buffer, store.Identifier()
+}
+
+func readAll(data []byte) ([]*nom.DetailedMomentum, error) {
+	reader, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*nom.DetailedMomentum, 0)
+	for {
+		detailed, err := reader.Read()
+		if err == io.EOF {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, detailed)
+	}
+}
+
+func TestArchive_ExportImport(t *testing.T) {
+	buffer, frontier := exportMockChain(t)
+
+	momentums, err := readAll(buffer.Bytes())
+	common.FailIfErr(t, err)
+	common.ExpectUint64(t, uint64(len(momentums)), frontier.Height)
+
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	bridge := protocol.NewChainBridge(z.Chain(), z.Consensus(), z.Verifier(), vm.NewSupervisor(z.Chain(), z.Consensus()))
+	_, err = bridge.InsertChain(momentums)
+	common.FailIfErr(t, err)
+	imported := z.Chain().GetFrontierMomentumStore().Identifier()
+	common.ExpectUint64(t, imported.Height, frontier.Height)
+	common.ExpectString(t, imported.Hash.String(), frontier.Hash.String())
+}
+
+func TestArchive_Corrupted(t *testing.T) {
+	buffer, _ := exportMockChain(t)
+	data := buffer.Bytes()
+
+	// flip a byte inside the first record
+	corrupted := append([]byte{}, data...)
+	corrupted[len(magic)+16+10] ^= 0xFF
+	_, err := readAll(corrupted)
+	common.ExpectError(t, err, ErrInvalidChecksum)
+
+	// missing trailer
+	_, err = readAll(data[:len(data)-12])
+	common.ExpectError(t, err, ErrTruncated)
+
+	_, err = readAll([]byte("not an archive"))
+	common.ExpectError(t, err, ErrInvalidMagic)
+}
@@ -27,6 +27,9 @@ type chain struct {
 
 	chainManager db.Manager
 	insert       sync.Mutex
+
+	stopped chan struct{}
+	wg      sync.WaitGroup
 }
 
 func NewChain(chainManager db.Manager, genesis store.Genesis) *chain {
@@ -86,17 +89,47 @@ func (c *chain) Start() error {
 	c.log.Info("starting ...")
 	defer c.log.Info("started")
 
+	c.stopped = make(chan struct{})
+	c.wg.Add(1)
+	go c.pruneAll()
 	return nil
 }
 func (c *chain) Stop() error {
 	c.log.Info("stopping ...")
 	defer c.log.Info("stopped")
 
+	close(c.stopped)
+	c.wg.Wait()
+
 	c.UnRegister(c.accountPool)
 
 	return c.chainManager.Stop()
 }
 
+// pruneAll prunes the momentums which are no longer retained, for example after pruning was enabled on an existing database.
+// It runs in the background and takes the insert lock for each batch, so that the node keeps inserting momentums meanwhile.
+func (c *chain) pruneAll() {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.stopped:
+			return
+		default:
+		}
+
+		insert := c.AcquireInsert("prune momentums")
+		more, err := c.PruneBatch(insert)
+		insert.Unlock()
+		if err != nil {
+			c.log.Error("failed to prune momentums", "reason", err)
+			return
+		}
+		if !more {
+			return
+		}
+	}
+}
+
 func (c *chain) checkGenesisCompatibility() error {
 	frontierStore := c.GetFrontierMomentumStore()
 	if frontierStore.Identifier().IsZero() {
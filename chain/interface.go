@@ -38,6 +38,9 @@ type MomentumEventManager interface {
 type MomentumPool interface {
 	AddMomentumTransaction(insertLocker sync.Locker, transaction *nom.MomentumTransaction) error
 	RollbackTo(insertLocker sync.Locker, identifier types.HashHeight) error
+	// SetPruning keeps only the last retained momentums and the account-blocks they confirmed.
+	// Account frontiers and send-blocks which are not received yet are always kept. A value of 0 disables pruning.
+	SetPruning(retained uint64)
 
 	GetFrontierMomentumStore() store.Momentum
 	GetMomentumStore(identifier types.HashHeight) store.Momentum
@@ -54,6 +57,9 @@ type AccountPool interface {
 	//  - the account-block with the smallest hash
 	AddAccountBlockTransaction(insertLocker sync.Locker, transaction *nom.AccountBlockTransaction) error
 	ForceAddAccountBlockTransaction(insertLocker sync.Locker, transaction *nom.AccountBlockTransaction) error
+	// RollbackAccountBlockTransaction removes the unconfirmed account-block identified by identifier,
+	// which needs to be the frontier of the account-chain of address.
+	RollbackAccountBlockTransaction(insertLocker sync.Locker, address types.Address, identifier types.HashHeight) error
 
 	GetPatch(address types.Address, identifier types.HashHeight) db.Patch
 	GetAccountStore(address types.Address, identifier types.HashHeight) store.Account
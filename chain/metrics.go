@@ -0,0 +1,20 @@
+package chain
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	momentumInsertTimer metrics.Timer = metrics.NilTimer{}
+
+	registerMetrics sync.Once
+)
+
+// RegisterMetrics creates the timer of momentum insertions.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		momentumInsertTimer = metrics.GetOrRegisterTimer("chain/momentums/insert", nil)
+	})
+}
@@ -16,13 +16,28 @@ func (ms *momentumStore) GetFrontierAccountBlock(address types.Address) (*nom.Ac
 	return ms.GetAccountStore(address).Frontier()
 }
 func (ms *momentumStore) GetAccountBlock(header types.AccountHeader) (*nom.AccountBlock, error) {
-	return ms.GetAccountStore(header.Address).ByHeight(header.Height)
+	return ms.GetAccountBlockByHeight(header.Address, header.Height)
 }
 func (ms *momentumStore) GetAccountBlockByHeight(address types.Address, height uint64) (*nom.AccountBlock, error) {
-	return ms.GetAccountStore(address).ByHeight(height)
+	block, err := ms.GetAccountStore(address).ByHeight(height)
+	if err != nil || block != nil {
+		return block, err
+	}
+	return nil, ms.isAccountBlockPruned(address, height)
 }
 func (ms *momentumStore) GetAccountBlocksByHeight(address types.Address, height, count uint64) ([]*nom.AccountBlock, error) {
-	return ms.GetAccountStore(address).MoreByHeight(height, count)
+	blocks, err := ms.GetAccountStore(address).MoreByHeight(height, count)
+	if err != nil {
+		return nil, err
+	}
+	for index, block := range blocks {
+		if block == nil {
+			if err := ms.isAccountBlockPruned(address, height+uint64(index)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return blocks, nil
 }
 
 func (ms *momentumStore) addAccountBlockHeader(header types.AccountHeader) error {
@@ -45,6 +60,6 @@ func (ms *momentumStore) GetAccountBlockByHash(hash types.Hash) (*nom.AccountBlo
 	if header, err := types.DeserializeAccountHeader(data); err != nil {
 		return nil, err
 	} else {
-		return ms.GetAccountStore(header.Address).ByHeight(header.Height)
+		return ms.GetAccountBlockByHeight(header.Address, header.Height)
 	}
 }
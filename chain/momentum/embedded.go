@@ -131,27 +131,35 @@ func (ms *momentumStore) GetAllDefinedSporks() ([]*definition.Spork, error) {
 
 	return definition.GetAllSporks(sd.Storage()), nil
 }
-func (ms *momentumStore) IsSporkActive(implemented *types.ImplementedSpork) (bool, error) {
+func (ms *momentumStore) GetEnforcedSporks() (map[types.Hash]bool, error) {
 	frontier, err := ms.GetFrontierMomentum()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
+	enforced := make(map[types.Hash]bool)
 	if frontier.Height == 1 {
-		return false, nil
+		return enforced, nil
 	}
 
 	sporks, err := ms.GetAllDefinedSporks()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	for _, spork := range sporks {
-		if spork.Activated && spork.EnforcementHeight <= frontier.Height && spork.Id == implemented.SporkId {
-			return true, nil
+		if spork.Activated && spork.EnforcementHeight <= frontier.Height {
+			enforced[spork.Id] = true
 		}
 	}
 
-	return false, nil
+	return enforced, nil
+}
+func (ms *momentumStore) IsSporkActive(implemented *types.ImplementedSpork) (bool, error) {
+	enforced, err := ms.GetEnforcedSporks()
+	if err != nil {
+		return false, err
+	}
+	return enforced[implemented.SporkId], nil
 }
 
 func (ms *momentumStore) getEmbeddedStore(address types.Address) (store.Account, error) {
@@ -0,0 +1,167 @@
+package momentum
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/abi"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+)
+
+// successStatus is the status stored by the vm in the data of the embedded receive-blocks which executed successfully
+const successStatus uint64 = 1
+
+type eventContract struct {
+	abi abi.ABIContract
+	// names maps the methods which emit events to the name of the event.
+	// Other methods, such as the epoch updates, don't emit events.
+	names map[string]string
+}
+
+var (
+	eventContracts = map[types.Address]*eventContract{
+		types.TokenContract: {definition.ABIToken, map[string]string{
+			definition.IssueMethodName:       "TokenIssued",
+			definition.MintMethodName:        "TokenMinted",
+			definition.BurnMethodName:        "TokenBurned",
+			definition.UpdateTokenMethodName: "TokenUpdated",
+		}},
+		types.StakeContract: {definition.ABIStake, map[string]string{
+			definition.StakeMethodName:              "StakeCreated",
+			definition.CancelStakeMethodName:        "StakeCancelled",
+			definition.CollectRewardMethodName:      "RewardCollected",
+			definition.ExtendStakeMethodName:        "StakeExtended",
+			definition.SplitStakeMethodName:         "StakeSplit",
+			definition.CancelPartialStakeMethodName: "StakePartiallyCancelled",
+			definition.SetAutoCompoundMethodName:    "StakeAutoCompoundSet",
+		}},
+		types.PillarContract: {definition.ABIPillars, map[string]string{
+			definition.RegisterMethodName:        "PillarRegistered",
+			definition.LegacyRegisterMethodName:  "PillarRegistered",
+			definition.UpdatePillarMethodName:    "PillarUpdated",
+			definition.RevokeMethodName:          "PillarRevoked",
+			definition.DelegateMethodName:        "PillarDelegated",
+			definition.UndelegateMethodName:      "PillarUndelegated",
+			definition.CollectRewardMethodName:   "RewardCollected",
+			definition.SetPayoutConfigMethodName: "PillarPayoutConfigSet",
+		}},
+		types.SentinelContract: {definition.ABISentinel, map[string]string{
+			definition.RegisterSentinelMethodName: "SentinelRegistered",
+			definition.RevokeSentinelMethodName:   "SentinelRevoked",
+			definition.CollectRewardMethodName:    "RewardCollected",
+		}},
+		types.LiquidityContract: {definition.ABILiquidity, map[string]string{
+			definition.CollectRewardMethodName: "RewardCollected",
+		}},
+		types.PlasmaContract: {definition.ABIPlasma, map[string]string{
+			definition.FuseMethodName:       "PlasmaFused",
+			definition.CancelFuseMethodName: "PlasmaFuseCancelled",
+			definition.FuseRewardMethodName: "PlasmaFused",
+		}},
+		types.AcceleratorContract: {definition.ABIAccelerator, map[string]string{
+			definition.CreateProjectMethodName:     "ProjectCreated",
+			definition.AddPhaseMethodName:          "PhaseAdded",
+			definition.UpdatePhaseMethodName:       "PhaseUpdated",
+			definition.VoteByNameMethodName:        "ProjectVoted",
+			definition.VoteByProdAddressMethodName: "ProjectVoted",
+		}},
+	}
+)
+
+func getEventsKey(hash types.Hash) []byte {
+	return common.JoinBytes(eventsPrefix, hash.Bytes())
+}
+
+func (ms *momentumStore) GetEvents(hash types.Hash) ([]*nom.Event, error) {
+	data, err := ms.DB.Get(getEventsKey(hash))
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nom.DeserializeEvents(data)
+}
+
+// AddEvents stores the events emitted by the embedded receive-blocks confirmed by momentum.
+// The blocks need to be in the store already.
+func (ms *momentumStore) AddEvents(momentum *nom.Momentum) error {
+	for _, header := range momentum.Content {
+		if _, ok := eventContracts[header.Address]; !ok {
+			continue
+		}
+		block, err := ms.GetAccountBlock(*header)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return errors.Errorf("can't find block for header %v", header)
+		}
+		event, err := ms.computeEvent(block)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			continue
+		}
+		data, err := nom.SerializeEvents([]*nom.Event{event})
+		if err != nil {
+			return err
+		}
+		if err := ms.DB.Put(getEventsKey(block.Hash), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// computeEvent returns the event emitted by block, or nil if the block doesn't emit one
+func (ms *momentumStore) computeEvent(block *nom.AccountBlock) (*nom.Event, error) {
+	if block.BlockType != nom.BlockTypeContractReceive || len(block.Data) != 8 || common.BytesToUint64(block.Data) != successStatus {
+		return nil, nil
+	}
+	sendBlock, err := ms.GetAccountBlockByHash(block.FromBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if sendBlock == nil {
+		return nil, errors.Errorf("can't find from-block %v", block.FromBlockHash)
+	}
+	contract := eventContracts[block.Address]
+	method, params, err := contract.abi.UnpackMethodValues(sendBlock.Data)
+	if err != nil {
+		// calls which don't decode don't emit events
+		return nil, nil
+	}
+	name, ok := contract.names[method.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	event := &nom.Event{
+		Name:          name,
+		Contract:      block.Address,
+		MethodName:    method.Name,
+		Params:        params,
+		Caller:        sendBlock.Address,
+		TokenStandard: sendBlock.TokenStandard,
+		Amount:        sendBlock.Amount,
+		Transfers:     make([]*nom.EventTransfer, 0, len(block.DescendantBlocks)),
+		SendBlockHash: sendBlock.Hash,
+		BlockHash:     block.Hash,
+	}
+	for _, dBlock := range block.DescendantBlocks {
+		if dBlock.Amount == nil || dBlock.Amount.Sign() == 0 {
+			continue
+		}
+		event.Transfers = append(event.Transfers, &nom.EventTransfer{
+			ToAddress:     dBlock.ToAddress,
+			TokenStandard: dBlock.TokenStandard,
+			Amount:        dBlock.Amount,
+		})
+	}
+	return event, nil
+}
@@ -8,4 +8,6 @@ var (
 	blockConfirmationHeightPrefix = []byte{5}
 	accountZNNBalancePrefix       = []byte{8}
 	accountHeaderByHashPrefix     = []byte{9}
+	prunedHeightKey               = []byte{10}
+	eventsPrefix                  = []byte{11}
 )
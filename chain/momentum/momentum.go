@@ -33,7 +33,14 @@ func (ms *momentumStore) GetFrontierMomentum() (*nom.Momentum, error) {
 	return parseMomentum(db.GetEntryByHeight(ms.DB, db.GetFrontierIdentifier(ms.DB).Height))
 }
 func (ms *momentumStore) GetMomentumByHash(hash types.Hash) (*nom.Momentum, error) {
-	return parseMomentum(db.GetEntryByHash(ms.DB, hash))
+	identifier, err := db.GetIdentifierByHash(ms.DB, hash)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ms.GetMomentumByHeight(identifier.Height)
 }
 func (ms *momentumStore) GetMomentumsByHash(blockHash types.Hash, higher bool, count uint64) ([]*nom.Momentum, error) {
 	momentum, err := ms.GetMomentumByHash(blockHash)
@@ -43,7 +50,11 @@ func (ms *momentumStore) GetMomentumsByHash(blockHash types.Hash, higher bool, c
 	return ms.GetMomentumsByHeight(momentum.Height, higher, count)
 }
 func (ms *momentumStore) GetMomentumByHeight(height uint64) (*nom.Momentum, error) {
-	return parseMomentum(db.GetEntryByHeight(ms.DB, height))
+	momentum, err := parseMomentum(db.GetEntryByHeight(ms.DB, height))
+	if err != nil || momentum != nil {
+		return momentum, err
+	}
+	return nil, ms.isPruned(height)
 }
 func (ms *momentumStore) GetMomentumsByHeight(height uint64, higher bool, count uint64) ([]*nom.Momentum, error) {
 	var to, from uint64
@@ -0,0 +1,145 @@
+package momentum
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+func (ms *momentumStore) PrunedHeight() (uint64, error) {
+	data, err := ms.DB.Get(prunedHeightKey)
+	if err == leveldb.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return common.BytesToUint64(data), nil
+}
+
+// PruneMomentum deletes the momentum and the account-blocks it confirmed, leaving only the indexes behind.
+// Account frontiers and send-blocks which are not received yet are kept, since they are required to insert new blocks.
+// A kept account-block is deleted once the momentum which confirmed the next block of its account, or its
+// receive-block, is pruned. Frontiers of accounts which don't have new blocks are kept forever.
+// Momentums need to be pruned in order and the genesis momentum is never pruned.
+func (ms *momentumStore) PruneMomentum(height uint64) error {
+	prunedHeight, err := ms.PrunedHeight()
+	if err != nil {
+		return err
+	}
+	if height < 2 || (prunedHeight != 0 && height != prunedHeight+1) {
+		return errors.Errorf("can't prune momentum %v after momentum %v", height, prunedHeight)
+	}
+
+	momentum, err := ms.GetMomentumByHeight(height)
+	if err != nil {
+		return err
+	}
+	if momentum == nil {
+		return errors.Errorf("can't find momentum %v", height)
+	}
+
+	for _, header := range momentum.Content {
+		block, err := ms.GetAccountBlock(*header)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return errors.Errorf("can't find block for header %v", header)
+		}
+		blocks := append([]*nom.AccountBlock{block}, block.DescendantBlocks...)
+		for _, block := range blocks {
+			if err := ms.pruneAccountBlock(block); err != nil {
+				return err
+			}
+			if err := ms.pruneKeptAccountBlocks(block); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := db.DeleteEntryByHeight(ms.DB, height); err != nil {
+		return err
+	}
+	return ms.DB.Put(prunedHeightKey, common.Uint64ToBytes(height))
+}
+func (ms *momentumStore) pruneAccountBlock(block *nom.AccountBlock) error {
+	accountDB := ms.DB.Subset(getAccountStorePrefix(block.Address))
+	if db.GetFrontierIdentifier(accountDB).Height == block.Height {
+		return nil
+	}
+	if block.IsSendBlock() && ms.getAccountMailbox(block.Address).GetBlockWhichReceives(block.Hash) == nil {
+		return nil
+	}
+	return db.DeleteEntryByHeight(accountDB, block.Height)
+}
+
+// pruneKeptAccountBlocks deletes the previous account-block and the send-block received by block,
+// if they were kept when their momentum was pruned. Both were confirmed at most by the momentum of block.
+func (ms *momentumStore) pruneKeptAccountBlocks(block *nom.AccountBlock) error {
+	if err := ms.pruneKeptAccountBlock(block.Address, block.Height-1); err != nil {
+		return err
+	}
+	if !block.IsReceiveBlock() {
+		return nil
+	}
+	data, err := ms.DB.Get(getAccountHeaderByHashKey(block.FromBlockHash))
+	if err == leveldb.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	header, err := types.DeserializeAccountHeader(data)
+	if err != nil {
+		return err
+	}
+	return ms.pruneKeptAccountBlock(header.Address, header.Height)
+}
+func (ms *momentumStore) pruneKeptAccountBlock(address types.Address, height uint64) error {
+	if height == 0 {
+		return nil
+	}
+	block, err := ms.GetAccountStore(address).ByHeight(height)
+	if err != nil || block == nil {
+		return err
+	}
+	// account-blocks confirmed by the genesis momentum are never pruned
+	confirmationHeight, err := ms.GetBlockConfirmationHeight(block.Hash)
+	if err != nil || confirmationHeight < 2 {
+		return err
+	}
+	return ms.pruneAccountBlock(block)
+}
+
+// isPruned returns ErrPruned if the momentum at height was pruned
+func (ms *momentumStore) isPruned(height uint64) error {
+	prunedHeight, err := ms.PrunedHeight()
+	if err != nil {
+		return err
+	}
+	if height > 1 && height <= prunedHeight {
+		return store.ErrPruned
+	}
+	return nil
+}
+
+// isAccountBlockPruned returns ErrPruned if the account-block at height exists according to the indexes
+func (ms *momentumStore) isAccountBlockPruned(address types.Address, height uint64) error {
+	if height == 0 || db.GetFrontierIdentifier(ms.GetAccountDB(address)).Height < height {
+		return nil
+	}
+	prunedHeight, err := ms.PrunedHeight()
+	if err != nil {
+		return err
+	}
+	if prunedHeight != 0 {
+		return store.ErrPruned
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 )
 
 func (ms *momentumStore) GetMomentumBeforeTime(timestamp *time.Time) (*nom.Momentum, error) {
@@ -25,6 +26,12 @@ func (ms *momentumStore) GetMomentumBeforeTime(timestamp *time.Time) (*nom.Momen
 		return frontierMomentum, nil
 	}
 
+	// momentums up to prunedHeight can't be used while searching, except for genesis
+	prunedHeight, err := ms.PrunedHeight()
+	if err != nil {
+		return nil, err
+	}
+
 	endSec := frontierMomentum.Timestamp.Unix()
 	timeSec := timestamp.Unix()
 
@@ -37,6 +44,9 @@ func (ms *momentumStore) GetMomentumBeforeTime(timestamp *time.Time) (*nom.Momen
 	var highBoundary, lowBoundary *nom.Momentum
 
 	for highBoundary == nil || lowBoundary == nil {
+		if estimateHeight <= prunedHeight {
+			estimateHeight = prunedHeight + 1
+		}
 		block, err := ms.GetMomentumByHeight(estimateHeight)
 		if err != nil {
 			return nil, errors.Errorf("GetMomentumByHeight failed; reason: %v; height: %v", err, estimateHeight)
@@ -53,7 +63,13 @@ func (ms *momentumStore) GetMomentumBeforeTime(timestamp *time.Time) (*nom.Momen
 				gap = 1
 			}
 
-			if block.Height <= gap {
+			if prunedHeight != 0 && (block.Height <= gap || block.Height-gap <= prunedHeight) {
+				// all momentums before block were pruned
+				if block.Height == prunedHeight+1 {
+					return nil, store.ErrPruned
+				}
+				estimateHeight = prunedHeight + 1
+			} else if block.Height <= gap {
 				lowBoundary = genesis
 				break
 			} else {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -17,12 +18,20 @@ import (
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 )
 
+const (
+	// maxPrunedMomentums limits the number of momentums pruned by one batch, to keep the insert lock short
+	maxPrunedMomentums = 1000
+)
+
 type momentumPool struct {
 	*momentumEventManager
 	chainManager db.Manager
 	genesis      store.Genesis
 	log          log15.Logger
 	changes      sync.Mutex
+
+	// number of momentums kept when pruning, 0 if pruning is disabled
+	retained uint64
 }
 
 func (c *momentumPool) AddMomentumTransaction(insertLocker sync.Locker, transaction *nom.MomentumTransaction) error {
@@ -35,9 +44,14 @@ func (c *momentumPool) AddMomentumTransaction(insertLocker sync.Locker, transact
 
 	momentum := transaction.Momentum
 
+	if err := c.addEvents(transaction); err != nil {
+		return err
+	}
+	start := time.Now()
 	if err := c.chainManager.Add(transaction); err != nil {
 		return err
 	}
+	momentumInsertTimer.UpdateSince(start)
 
 	store := c.getFrontierStore()
 	detailed, err := store.PrefetchMomentum(momentum)
@@ -73,8 +87,34 @@ func (c *momentumPool) AddMomentumTransaction(insertLocker sync.Locker, transact
 		fmt.Printf("\n")
 	}
 
+	// the momentum which is no longer retained is pruned right away, older ones are left to the background pruning
+	if _, err := c.prune(1); err != nil {
+		c.log.Error("failed to prune momentums", "reason", err)
+	}
+
 	return nil
 }
+
+// addEvents adds the events emitted by the momentum to its changes. The changes-hash of the momentum was already
+// verified, so the events are not part of consensus but they are still rollbacked together with the momentum.
+func (c *momentumPool) addEvents(transaction *nom.MomentumTransaction) error {
+	frontierDB := c.chainManager.Frontier()
+	if frontierDB == nil {
+		return errors.Errorf("can't find frontier")
+	}
+	if err := frontierDB.Apply(transaction.Changes); err != nil {
+		return err
+	}
+	store := momentum.NewStore(c.genesis, frontierDB.Snapshot())
+	if err := store.AddEvents(transaction.Momentum); err != nil {
+		return err
+	}
+	events, err := store.Changes()
+	if err != nil {
+		return err
+	}
+	return events.Replay(transaction.Changes)
+}
 func (c *momentumPool) RollbackTo(insertLocker sync.Locker, identifier types.HashHeight) error {
 	c.log.Info("rollbacking momentums", "to-identifier", identifier)
 	if insertLocker == nil {
@@ -119,6 +159,71 @@ func (c *momentumPool) RollbackTo(insertLocker sync.Locker, identifier types.Has
 	return nil
 }
 
+// SetPruning keeps only the last retained momentums and the account-blocks they confirmed.
+// A value of 0 disables pruning.
+func (c *momentumPool) SetPruning(retained uint64) {
+	c.changes.Lock()
+	defer c.changes.Unlock()
+	c.retained = retained
+}
+
+// PruneBatch prunes at most maxPrunedMomentums momentums which are no longer retained.
+// Returns true if there are more momentums to prune.
+func (c *momentumPool) PruneBatch(insertLocker sync.Locker) (bool, error) {
+	if insertLocker == nil {
+		return false, errors.Errorf("insertLocker can't be nil")
+	}
+	c.changes.Lock()
+	defer c.changes.Unlock()
+	return c.prune(maxPrunedMomentums)
+}
+
+// prune deletes at most limit momentums which are no longer retained, in order.
+// Returns true if there are more momentums to prune.
+func (c *momentumPool) prune(limit uint64) (bool, error) {
+	if c.retained == 0 {
+		return false, nil
+	}
+	store := c.getFrontierStore()
+	frontier := store.Identifier()
+	if frontier.Height <= c.retained {
+		return false, nil
+	}
+	target := frontier.Height - c.retained
+	prunedHeight, err := store.PrunedHeight()
+	if err != nil {
+		return false, err
+	}
+
+	// genesis is never pruned
+	from := prunedHeight + 1
+	if from < 2 {
+		from = 2
+	}
+	if from > target {
+		return false, nil
+	}
+	to := target
+	if to-from >= limit {
+		to = from + limit - 1
+	}
+
+	for height := from; height <= to; height += 1 {
+		if err := store.PruneMomentum(height); err != nil {
+			return false, err
+		}
+	}
+	changes, err := store.Changes()
+	if err != nil {
+		return false, err
+	}
+	if err := c.chainManager.Prune(to, changes); err != nil {
+		return false, err
+	}
+	c.log.Info("pruned momentums", "from", from, "to", to)
+	return to < target, nil
+}
+
 // Checks whatever or not all active sporks are implemented
 func GotAllActiveSporksImplemented(store store.Momentum) (justNow *definition.Spork, unimplemented []*definition.Spork, err error) {
 	momentum, err := store.GetFrontierMomentum()
@@ -0,0 +1,48 @@
+package nom
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+// Event is the structured record of a successful call of an embedded contract
+type Event struct {
+	Name       string                 `json:"name"`
+	Contract   types.Address          `json:"contract"`
+	MethodName string                 `json:"methodName"`
+	Params     map[string]interface{} `json:"params"`
+	// Caller, TokenStandard and Amount are taken from the send-block which called the contract
+	Caller        types.Address            `json:"caller"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount        *big.Int                 `json:"amount"`
+	// Transfers are the funds sent by the contract while executing the call
+	Transfers     []*EventTransfer `json:"transfers"`
+	SendBlockHash types.Hash       `json:"sendBlockHash"`
+	BlockHash     types.Hash       `json:"blockHash"`
+}
+
+type EventTransfer struct {
+	ToAddress     types.Address            `json:"toAddress"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount        *big.Int                 `json:"amount"`
+}
+
+// SerializeEvents encodes events as JSON, since the params of the calls don't have a fixed type
+func SerializeEvents(events []*Event) ([]byte, error) {
+	return json.Marshal(events)
+}
+
+// DeserializeEvents decodes the events encoded by SerializeEvents.
+// Numeric params are decoded as json.Number, so big values keep their precision.
+func DeserializeEvents(data []byte) ([]*Event, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	events := make([]*Event, 0)
+	if err := decoder.Decode(&events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -5,11 +5,17 @@ import (
 	"time"
 
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 )
 
+var (
+	// ErrPruned is returned when querying momentums or account-blocks which were deleted by a pruned node
+	ErrPruned = common.NewErrorWCode(-32000, "requested data was pruned by this node")
+)
+
 type Momentum interface {
 	Genesis
 
@@ -39,11 +45,28 @@ type Momentum interface {
 
 	GetBlockConfirmationHeight(hash types.Hash) (uint64, error)
 
+	// Events
+
+	// GetEvents returns the events emitted by the embedded receive-block with hash
+	GetEvents(hash types.Hash) ([]*nom.Event, error)
+	// AddEvents stores the events emitted by the account-blocks confirmed by momentum.
+	// Events are not part of the changes of the momentum, they are added only after the momentum was verified.
+	AddEvents(momentum *nom.Momentum) error
+
+	// Pruning
+
+	// PrunedHeight returns the height of the last pruned momentum or 0 if no momentum was pruned
+	PrunedHeight() (uint64, error)
+	// PruneMomentum deletes the momentum at height, together with the account-blocks it confirmed
+	PruneMomentum(height uint64) error
+
 	// Embedded
 
 	GetAllDefinedSporks() ([]*definition.Spork, error)
 	GetActivePillars() ([]*definition.PillarInfo, error)
 	IsSporkActive(*types.ImplementedSpork) (bool, error)
+	// GetEnforcedSporks returns the ids of the sporks enforced at the frontier momentum
+	GetEnforcedSporks() (map[types.Hash]bool, error)
 	GetStakeBeneficialAmount(addr types.Address) (*big.Int, error)
 	GetTokenInfoByTs(ts types.ZenonTokenStandard) (*definition.TokenInfo, error)
 	ComputePillarDelegations() ([]*types.PillarDelegationDetail, error)
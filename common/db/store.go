@@ -62,3 +62,6 @@ func GetEntryByHash(db DB, hash types.Hash) ([]byte, error) {
 func GetEntryByHeight(db DB, height uint64) ([]byte, error) {
 	return db.Get(getEntryByHeightKey(height))
 }
+func DeleteEntryByHeight(db DB, height uint64) error {
+	return db.Delete(getEntryByHeightKey(height))
+}
@@ -23,6 +23,7 @@ var (
 	frontierByte = []byte{85}
 	patchByte    = []byte{102}
 	rollbackByte = []byte{119}
+	prunedByte   = []byte{80}
 )
 
 func absDiff(x, y uint64) uint64 {
@@ -50,6 +51,9 @@ type Manager interface {
 
 	Add(Transaction) error
 	Pop() error
+	// Prune applies patch directly on the frontier, without keeping a rollback for it, and discards the patches and
+	// rollbacks of all versions up to height. Such versions can't be retrieved or rollbacked to anymore.
+	Prune(height uint64, patch Patch) error
 
 	Stop() error
 	Location() string
@@ -169,6 +173,24 @@ func (m *memdbManager) Pop() error {
 	m.frontierIdentifier = previous
 	return nil
 }
+func (m *memdbManager) Prune(height uint64, patch Patch) error {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	if height >= m.frontierIdentifier.Height {
+		return errors.Errorf("can't prune frontier %v", m.frontierIdentifier)
+	}
+	if err := m.versions[m.frontierIdentifier].Apply(patch); err != nil {
+		return err
+	}
+	for identifier := range m.versions {
+		if identifier.Height <= height {
+			delete(m.previous, identifier)
+			delete(m.versions, identifier)
+			delete(m.patches, identifier)
+		}
+	}
+	return nil
+}
 func (m *memdbManager) Stop() error {
 	m.frontierIdentifier = types.ZeroHashHeight
 	m.versions = nil
@@ -235,6 +257,9 @@ func (m *ldbManager) Get(identifier types.HashHeight) DB {
 	if identifier == frontierIdentifier {
 		return frontier
 	}
+	if identifier.Height <= m.getPrunedHeight(snapshot) {
+		return nil
+	}
 
 	trueIdentifier, err := GetIdentifierByHash(frontier, identifier.Hash)
 	if err == leveldb.ErrNotFound {
@@ -389,6 +414,45 @@ func (m *ldbManager) Pop() error {
 
 	return nil
 }
+func (m *ldbManager) Prune(height uint64, patch Patch) error {
+	m.changes.Lock()
+	defer m.changes.Unlock()
+	if m.stopped {
+		return errors.Errorf("can't prune stopped db")
+	}
+	snapshot, err := m.ldb.GetSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+	frontierIdentifier := GetFrontierIdentifier(NewLevelDBSnapshotWrapper(snapshot).Subset(frontierByte))
+	if height >= frontierIdentifier.Height {
+		return errors.Errorf("can't prune frontier %v", frontierIdentifier)
+	}
+
+	// keys deleted by the patch are removed from leveldb, instead of being marked as deleted,
+	// since no rollback will ever need them
+	batch := &pruneBatch{
+		batch: new(leveldb.Batch),
+	}
+	if err := patch.Replay(batch); err != nil {
+		return err
+	}
+	for i := m.getPrunedHeight(snapshot) + 1; i <= height; i += 1 {
+		batch.batch.Delete(common.JoinBytes(patchByte, common.Uint64ToBytes(i)))
+		batch.batch.Delete(common.JoinBytes(rollbackByte, common.Uint64ToBytes(i)))
+	}
+	batch.batch.Put(prunedByte, common.Uint64ToBytes(height))
+	return m.ldb.Write(batch.batch, nil)
+}
+func (m *ldbManager) getPrunedHeight(snapshot *leveldb.Snapshot) uint64 {
+	value, err := snapshot.Get(prunedByte, nil)
+	if err == leveldb.ErrNotFound {
+		return 0
+	}
+	common.DealWithErr(err)
+	return common.BytesToUint64(value)
+}
 func (m *ldbManager) Stop() error {
 	m.changes.Lock()
 	defer m.changes.Unlock()
@@ -404,3 +468,14 @@ func (m *ldbManager) Stop() error {
 func (m *ldbManager) Location() string {
 	return m.location
 }
+
+type pruneBatch struct {
+	batch *leveldb.Batch
+}
+
+func (b *pruneBatch) Put(key []byte, value []byte) {
+	b.batch.Put(common.JoinBytes(frontierByte, key), common.JoinBytes(existsByte, value))
+}
+func (b *pruneBatch) Delete(key []byte) {
+	b.batch.Delete(common.JoinBytes(frontierByte, key))
+}
@@ -260,3 +260,36 @@ d5104dc76695721d - b80704bb7b4d7c03
 dc2864602be7fb85 - d38967f931a50490
 f25f4b21eef64b43 - 9c0a8a2bfc0914df`)
 }
+
+func TestVersionedDBPrune(t *testing.T) {
+	m := NewLevelDBManager(t.TempDir())
+
+	identifiers := make([]types.HashHeight, 0, 3)
+	for i := int64(1); i <= 3; i += 1 {
+		common.DealWithErr(m.Add(newMockTransaction(i, m.Frontier())))
+		identifiers = append(identifiers, GetFrontierIdentifier(m.Frontier()))
+	}
+
+	db := m.Frontier()
+	common.FailIfErr(t, db.Delete(getEntryByHeightKey(1)))
+	common.FailIfErr(t, db.Delete(getEntryByHeightKey(2)))
+	patch, err := db.Changes()
+	common.FailIfErr(t, err)
+
+	common.ExpectString(t, fmt.Sprintf("%v", m.Prune(3, patch)), `can't prune frontier {d8ba48392cd7843812028c9fc3d7c92e232b8a725db741d69c930772e8551a85 3}`)
+	common.FailIfErr(t, m.Prune(2, patch))
+
+	common.ExpectTrue(t, m.Get(identifiers[0]) == nil)
+	common.ExpectTrue(t, m.Get(identifiers[1]) == nil)
+	common.ExpectTrue(t, m.GetPatch(identifiers[1]) == nil)
+	common.ExpectTrue(t, m.GetPatch(identifiers[2]) != nil)
+
+	_, err = GetEntryByHeight(m.Frontier(), 1)
+	common.ExpectString(t, fmt.Sprintf("%v", err), `leveldb: not found`)
+	_, err = GetEntryByHeight(m.Frontier(), 3)
+	common.FailIfErr(t, err)
+
+	// the frontier can still be rollbacked
+	common.FailIfErr(t, m.Pop())
+	common.ExpectString(t, fmt.Sprintf("%v", GetFrontierIdentifier(m.Frontier())), fmt.Sprintf("%v", identifiers[1]))
+}
@@ -28,6 +28,7 @@ var (
 	SupervisorLogger = log15.New("module", "supervisor")
 	EmbeddedLogger   = log15.New("module", "embedded")
 	WalletLogger     = log15.New("module", "wallet")
+	IndexerLogger    = log15.New("module", "indexer")
 )
 
 func InitLogging(dataPath, logLevelStr string) {
@@ -1,12 +1,36 @@
 package types
 
 var (
-	AcceleratorSpork     = NewImplementedSpork("6d2b1e6cb4025f2f45533f0fe22e9b7ce2014d91cc960471045fa64eee5a6ba3")
-	ImplementedSporksMap = map[Hash]bool{
-		AcceleratorSpork.SporkId: true,
+	AcceleratorSpork  = NewImplementedSpork("6d2b1e6cb4025f2f45533f0fe22e9b7ce2014d91cc960471045fa64eee5a6ba3")
+	MultisigSpork     = NewImplementedSpork("a3b1f4f1c6a2dc06b6cc4e9a8ae4bd33bb5d1fe4d3eb1b6d0a35e0ab6f7c1d52")
+	VestingSpork      = NewImplementedSpork("f92515c40a45f6a1aa036f911b6d91aec9d880794471bdab2f4d33c31fd47a9e")
+	HtlcSpork         = NewImplementedSpork("cb46dbf635b878108ef08ff8bf22c8b059bd6a1ae15b65c40ac37fcbd8808834")
+	TokenPolicySpork  = NewImplementedSpork("a3272ab711cdac970355c44124b77965fae218a216417e3efe4057b4e5394ca7")
+	StakeSpork        = NewImplementedSpork("7c07e7d4fa409eba53b7a1d19e24b80f0a1e43126b0d6f4fb1cea4b53569a459")
+	PillarPayoutSpork = NewImplementedSpork("09fdf52aa51601031dd2dfa0f9c483b7feb6b0812be96f334b4a8f6869b35caa")
+
+	// ImplementedSporks lists all the sporks implemented by this node.
+	// The protocol changes gated by a spork are registered, keyed by the spork, by the packages implementing them:
+	// embedded methods in vm/embedded, plasma tables in vm/constants and verifier rules in verifier.
+	ImplementedSporks = []*ImplementedSpork{
+		AcceleratorSpork,
+		MultisigSpork,
+		VestingSpork,
+		HtlcSpork,
+		TokenPolicySpork,
+		StakeSpork,
+		PillarPayoutSpork,
 	}
+	// ImplementedSporksMap indexes ImplementedSporks by id
+	ImplementedSporksMap = make(map[Hash]bool)
 )
 
+func init() {
+	for _, spork := range ImplementedSporks {
+		ImplementedSporksMap[spork.SporkId] = true
+	}
+}
+
 type ImplementedSpork struct {
 	SporkId Hash
 }
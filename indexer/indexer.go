@@ -0,0 +1,421 @@
+package indexer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/embedded"
+)
+
+// Indexer keeps secondary indexes of the account-blocks, ordered by address, in a separate database.
+// The indexes are updated as momentums are inserted and deleted from the chain.
+type Indexer interface {
+	chain.MomentumEventListener
+
+	Init() error
+	Start() error
+	Stop() error
+
+	// Frontier returns the identifier of the last indexed momentum
+	Frontier() (*types.HashHeight, error)
+	// GetEntries returns the entries of address which match filter, newest first, and whether more entries match
+	// after the requested page
+	GetEntries(address types.Address, filter *Filter, pageIndex, pageSize uint32) ([]*Entry, bool, error)
+}
+
+const (
+	// catchUpBatch is the number of momentums indexed for each acquisition of the insert lock while catching up
+	catchUpBatch = 1000
+	// a failed catch-up is retried after catchUpRetryDelay, doubled after each consecutive failure up to catchUpMaxRetryDelay
+	catchUpRetryDelay    = time.Second
+	catchUpMaxRetryDelay = time.Minute
+)
+
+type indexer struct {
+	log   common.Logger
+	chain chain.Chain
+	db    *leveldb.DB
+
+	changes sync.RWMutex
+	// catchingUp is set while the indexer is not registered for momentum events and catchUp runs in the background
+	catchingUp bool
+
+	stopped chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewIndexer(chain chain.Chain, db *leveldb.DB) Indexer {
+	return &indexer{
+		log:   common.IndexerLogger,
+		chain: chain,
+		db:    db,
+	}
+}
+
+func (idx *indexer) Init() error {
+	return nil
+}
+func (idx *indexer) Start() error {
+	idx.stopped = make(chan struct{})
+	idx.catchingUp = true
+	idx.wg.Add(1)
+	go idx.catchUp()
+	return nil
+}
+func (idx *indexer) Stop() error {
+	// closed under the changes lock, so InsertMomentum doesn't start a new catch-up once stopped
+	idx.changes.Lock()
+	close(idx.stopped)
+	idx.changes.Unlock()
+	idx.wg.Wait()
+	idx.chain.UnRegister(idx)
+	return nil
+}
+
+// catchUp indexes the momentums missing from the indexes in batches, releasing the insert lock between batches
+// so the node keeps inserting momentums while the indexer catches up.
+// The indexer registers for momentum events with the insert lock held after the last batch, so no event is lost.
+// Failed batches are retried with backoff until the indexer is stopped.
+func (idx *indexer) catchUp() {
+	defer idx.wg.Done()
+	retryDelay := catchUpRetryDelay
+	for {
+		select {
+		case <-idx.stopped:
+			return
+		default:
+		}
+
+		insert := idx.chain.AcquireInsert("indexer catch-up")
+		done, err := idx.syncBatch(catchUpBatch)
+		if err == nil && done {
+			idx.chain.Register(idx)
+		}
+		insert.Unlock()
+
+		if err != nil {
+			idx.log.Error("failed to catch up with the chain", "reason", err, "retry-in", retryDelay)
+			select {
+			case <-idx.stopped:
+				return
+			case <-time.After(retryDelay):
+			}
+			retryDelay *= 2
+			if retryDelay > catchUpMaxRetryDelay {
+				retryDelay = catchUpMaxRetryDelay
+			}
+			continue
+		}
+		if done {
+			return
+		}
+		retryDelay = catchUpRetryDelay
+	}
+}
+
+// InsertMomentum indexes the momentum if it follows the indexer frontier.
+// Otherwise the indexer stops following the chain and catches up in the background, since the missing momentums
+// can be arbitrarily many and the insert lock is held by the caller.
+func (idx *indexer) InsertMomentum(detailed *nom.DetailedMomentum) {
+	idx.changes.Lock()
+	defer idx.changes.Unlock()
+	if idx.catchingUp {
+		return
+	}
+
+	frontier, err := idx.getFrontier()
+	if err != nil {
+		idx.log.Error("failed to get frontier", "reason", err)
+		return
+	}
+
+	if (frontier == nil && detailed.Momentum.Height == 1) || (frontier != nil && frontier.Height+1 == detailed.Momentum.Height && frontier.Hash == detailed.Momentum.PreviousHash) {
+		err = idx.indexMomentum(idx.chain.GetFrontierMomentumStore(), detailed)
+	} else {
+		idx.log.Warn("indexer out of sync with the chain", "frontier", frontier, "momentum-identifier", detailed.Momentum.Identifier())
+		idx.restartCatchUp()
+		return
+	}
+	if err != nil {
+		idx.log.Error("failed to index momentum", "identifier", detailed.Momentum.Identifier(), "reason", err)
+		idx.restartCatchUp()
+	}
+}
+func (idx *indexer) DeleteMomentum(detailed *nom.DetailedMomentum) {
+	idx.changes.Lock()
+	defer idx.changes.Unlock()
+	if idx.catchingUp {
+		return
+	}
+
+	frontier, err := idx.getFrontier()
+	if err != nil {
+		idx.log.Error("failed to get frontier", "reason", err)
+		return
+	}
+	if frontier == nil || frontier.Hash != detailed.Momentum.Hash {
+		idx.log.Warn("deleted momentum is not the indexer frontier", "frontier", frontier, "momentum-identifier", detailed.Momentum.Identifier())
+		return
+	}
+	if err := idx.unindexMomentum(frontier.Height); err != nil {
+		idx.log.Error("failed to unindex momentum", "identifier", detailed.Momentum.Identifier(), "reason", err)
+		idx.restartCatchUp()
+	}
+}
+
+// restartCatchUp unregisters the indexer from the momentum events and hands the indexing back to catchUp.
+// It is called with the changes lock held, from the momentum events, so the listener is unregistered from another goroutine.
+func (idx *indexer) restartCatchUp() {
+	select {
+	case <-idx.stopped:
+		return
+	default:
+	}
+	idx.catchingUp = true
+	idx.wg.Add(1)
+	go func() {
+		idx.chain.UnRegister(idx)
+		idx.catchUp()
+	}()
+}
+
+func (idx *indexer) Frontier() (*types.HashHeight, error) {
+	idx.changes.RLock()
+	defer idx.changes.RUnlock()
+	return idx.getFrontier()
+}
+func (idx *indexer) GetEntries(address types.Address, filter *Filter, pageIndex, pageSize uint32) ([]*Entry, bool, error) {
+	idx.changes.RLock()
+	defer idx.changes.RUnlock()
+
+	// the counterparty is more selective than the token standard, the other fields are checked on each entry
+	prefix := getEntryPrefix(address)
+	if filter != nil && filter.Counterparty != nil {
+		prefix = getEntryByCounterpartyPrefix(address, *filter.Counterparty)
+	} else if filter != nil && filter.TokenStandard != nil {
+		prefix = getEntryByTokenPrefix(address, *filter.TokenStandard)
+	}
+	iterator := idx.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iterator.Release()
+
+	start := pageIndex * pageSize
+	end := start + pageSize
+	list := make([]*Entry, 0, pageSize)
+	more := false
+	count := uint32(0)
+	for ok := iterator.Last(); ok; ok = iterator.Prev() {
+		entry, err := DeserializeEntry(iterator.Value())
+		if err != nil {
+			return nil, false, err
+		}
+		// entries are ordered by momentum height, so no older entry can match
+		if filter != nil && filter.FromTimestamp != 0 && entry.Timestamp < filter.FromTimestamp {
+			break
+		}
+		if !filter.Matches(address, entry) {
+			continue
+		}
+		if count >= end {
+			more = true
+			break
+		}
+		if count >= start {
+			list = append(list, entry)
+		}
+		count += 1
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, false, err
+	}
+	return list, more, nil
+}
+
+func (idx *indexer) getFrontier() (*types.HashHeight, error) {
+	data, err := idx.db.Get(frontierKey, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return types.DeserializeHashHeight(data)
+}
+
+// syncBatch brings at most limit momentums of the indexes in line with the chain and returns true once the indexes
+// are in line with the chain
+func (idx *indexer) syncBatch(limit uint64) (bool, error) {
+	idx.changes.Lock()
+	defer idx.changes.Unlock()
+	done, err := idx.syncLockedBatch(limit)
+	if err == nil && done {
+		idx.catchingUp = false
+	}
+	return done, err
+}
+
+// syncLockedBatch is syncBatch with the changes lock held.
+// Momentums which are no longer part of the chain (for example rollbacks which happened while the indexer was disabled)
+// are removed first, after which the missing momentums are indexed.
+func (idx *indexer) syncLockedBatch(limit uint64) (bool, error) {
+	momentumStore := idx.chain.GetFrontierMomentumStore()
+
+	for {
+		frontier, err := idx.getFrontier()
+		if err != nil {
+			return false, err
+		}
+		if frontier == nil {
+			break
+		}
+		momentum, err := momentumStore.GetMomentumByHeight(frontier.Height)
+		if err != nil {
+			return false, err
+		}
+		if momentum != nil && momentum.Hash == frontier.Hash {
+			break
+		}
+		idx.log.Info("removing momentum which is no longer part of the chain", "identifier", frontier)
+		if err := idx.unindexMomentum(frontier.Height); err != nil {
+			return false, err
+		}
+	}
+
+	frontier, err := idx.getFrontier()
+	if err != nil {
+		return false, err
+	}
+	height := uint64(1)
+	if frontier != nil {
+		height = frontier.Height + 1
+	}
+	target := momentumStore.Identifier().Height
+	if height+limit <= target {
+		target = height + limit - 1
+	}
+	if height <= target {
+		idx.log.Info("indexing momentums", "from", height, "to", target)
+	}
+	for ; height <= target; height += 1 {
+		momentum, err := momentumStore.GetMomentumByHeight(height)
+		if err != nil {
+			return false, err
+		}
+		detailed, err := momentumStore.PrefetchMomentum(momentum)
+		if err != nil {
+			return false, err
+		}
+		if err := idx.indexMomentum(momentumStore, detailed); err != nil {
+			return false, err
+		}
+	}
+	return target == momentumStore.Identifier().Height, nil
+}
+
+func (idx *indexer) indexMomentum(momentumStore store.Momentum, detailed *nom.DetailedMomentum) error {
+	momentum := detailed.Momentum
+	batch := new(leveldb.Batch)
+	keys := make([][]byte, 0, len(detailed.AccountBlocks))
+
+	index := uint32(0)
+	for _, accountBlock := range detailed.AccountBlocks {
+		blocks := append([]*nom.AccountBlock{accountBlock}, accountBlock.DescendantBlocks...)
+		for _, block := range blocks {
+			entry, err := newEntry(momentumStore, momentum, block)
+			if err != nil {
+				return err
+			}
+			data := entry.Serialize()
+
+			owners := []types.Address{entry.Address}
+			if !entry.Counterparty.IsZero() && entry.Counterparty != entry.Address {
+				owners = append(owners, entry.Counterparty)
+			}
+			for _, owner := range owners {
+				for _, key := range getEntryKeys(owner, entry, index) {
+					batch.Put(key, data)
+					keys = append(keys, key)
+				}
+			}
+			index += 1
+		}
+	}
+
+	// keep the list of written keys, so the momentum can be unindexed without having access to it
+	batch.Put(getMomentumKey(momentum.Height), common.JoinBytes(momentum.Hash.Bytes(), common.JoinBytes(keys...)))
+	identifier := momentum.Identifier()
+	batch.Put(frontierKey, identifier.Serialize())
+	return idx.db.Write(batch, nil)
+}
+func (idx *indexer) unindexMomentum(height uint64) error {
+	data, err := idx.db.Get(getMomentumKey(height), nil)
+	if err != nil {
+		return err
+	}
+
+	batch := new(leveldb.Batch)
+	for offset := types.HashSize; offset < len(data); {
+		size, err := getEntryKeySize(data[offset])
+		if err != nil {
+			return err
+		}
+		if offset+size > len(data) {
+			return errors.Errorf("invalid indexed momentum %v", height)
+		}
+		batch.Delete(data[offset : offset+size])
+		offset += size
+	}
+	batch.Delete(getMomentumKey(height))
+
+	if height == 1 {
+		batch.Delete(frontierKey)
+	} else {
+		previous, err := idx.db.Get(getMomentumKey(height-1), nil)
+		if err != nil {
+			return err
+		}
+		identifier := types.HashHeight{
+			Hash:   types.BytesToHashPanic(previous[:types.HashSize]),
+			Height: height - 1,
+		}
+		batch.Put(frontierKey, identifier.Serialize())
+	}
+	return idx.db.Write(batch, nil)
+}
+
+// newEntry computes the indexed information of block.
+// For receive-blocks, the counterparty, token standard and method name are taken from the paired send-block.
+func newEntry(momentumStore store.Momentum, momentum *nom.Momentum, block *nom.AccountBlock) (*Entry, error) {
+	entry := &Entry{
+		Hash:           block.Hash,
+		Address:        block.Address,
+		TokenStandard:  block.TokenStandard,
+		BlockType:      block.BlockType,
+		MomentumHeight: momentum.Height,
+		Timestamp:      momentum.Timestamp.Unix(),
+	}
+
+	if block.IsSendBlock() {
+		entry.Counterparty = block.ToAddress
+		entry.MethodName, _ = embedded.GetEmbeddedMethodName(block.ToAddress, block.Data)
+	} else if !block.FromBlockHash.IsZero() {
+		fromBlock, err := momentumStore.GetAccountBlockByHash(block.FromBlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if fromBlock != nil {
+			entry.Counterparty = fromBlock.Address
+			entry.TokenStandard = fromBlock.TokenStandard
+			entry.MethodName, _ = embedded.GetEmbeddedMethodName(fromBlock.ToAddress, fromBlock.Data)
+		}
+	}
+	return entry, nil
+}
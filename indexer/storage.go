@@ -0,0 +1,187 @@
+package indexer
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+var (
+	frontierKey               = []byte{0}
+	momentumPrefix            = []byte{1}
+	entryPrefix               = []byte{2}
+	entryByTokenPrefix        = []byte{3}
+	entryByCounterpartyPrefix = []byte{4}
+
+	entryKeySize               = len(entryPrefix) + types.AddressSize + 8 + 4
+	entryByTokenKeySize        = len(entryByTokenPrefix) + types.AddressSize + types.ZenonTokenStandardSize + 8 + 4
+	entryByCounterpartyKeySize = len(entryByCounterpartyPrefix) + 2*types.AddressSize + 8 + 4
+	entryMinimumSize           = types.HashSize + 2*types.AddressSize + types.ZenonTokenStandardSize + 8 + 8
+)
+
+func getMomentumKey(height uint64) []byte {
+	return common.JoinBytes(momentumPrefix, common.Uint64ToBytes(height))
+}
+
+// getEntryPosition orders the entries by momentum height and by the position of the block in the momentum
+func getEntryPosition(height uint64, index uint32) []byte {
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	return common.JoinBytes(common.Uint64ToBytes(height), indexBytes)
+}
+func getEntryKey(address types.Address, height uint64, index uint32) []byte {
+	return common.JoinBytes(getEntryPrefix(address), getEntryPosition(height, index))
+}
+func getEntryPrefix(address types.Address) []byte {
+	return common.JoinBytes(entryPrefix, address.Bytes())
+}
+func getEntryByTokenKey(address types.Address, zts types.ZenonTokenStandard, height uint64, index uint32) []byte {
+	return common.JoinBytes(getEntryByTokenPrefix(address, zts), getEntryPosition(height, index))
+}
+func getEntryByTokenPrefix(address types.Address, zts types.ZenonTokenStandard) []byte {
+	return common.JoinBytes(entryByTokenPrefix, address.Bytes(), zts.Bytes())
+}
+func getEntryByCounterpartyKey(address, counterparty types.Address, height uint64, index uint32) []byte {
+	return common.JoinBytes(getEntryByCounterpartyPrefix(address, counterparty), getEntryPosition(height, index))
+}
+func getEntryByCounterpartyPrefix(address, counterparty types.Address) []byte {
+	return common.JoinBytes(entryByCounterpartyPrefix, address.Bytes(), counterparty.Bytes())
+}
+
+// getEntryKeySize returns the size of the entry key which starts with prefix
+func getEntryKeySize(prefix byte) (int, error) {
+	switch prefix {
+	case entryPrefix[0]:
+		return entryKeySize, nil
+	case entryByTokenPrefix[0]:
+		return entryByTokenKeySize, nil
+	case entryByCounterpartyPrefix[0]:
+		return entryByCounterpartyKeySize, nil
+	default:
+		return 0, errors.Errorf("invalid indexer entry key prefix %v", prefix)
+	}
+}
+
+// getEntryKeys returns the keys of entry from the point of view of address.
+// Besides the address index, entries are indexed by address and token standard, and by address and counterparty,
+// so the filters on these fields don't scan all the entries of address.
+func getEntryKeys(address types.Address, entry *Entry, index uint32) [][]byte {
+	keys := [][]byte{
+		getEntryKey(address, entry.MomentumHeight, index),
+		getEntryByTokenKey(address, entry.TokenStandard, entry.MomentumHeight, index),
+	}
+	if other := entry.Other(address); !other.IsZero() {
+		keys = append(keys, getEntryByCounterpartyKey(address, other, entry.MomentumHeight, index))
+	}
+	return keys
+}
+
+// Entry is the indexed information of an account-block, stored for both the owner and the counterparty of the block
+type Entry struct {
+	Hash           types.Hash
+	Address        types.Address
+	Counterparty   types.Address
+	TokenStandard  types.ZenonTokenStandard
+	BlockType      uint64
+	MomentumHeight uint64
+	Timestamp      int64
+	MethodName     string
+}
+
+func (e *Entry) Serialize() []byte {
+	return common.JoinBytes(
+		e.Hash.Bytes(),
+		e.Address.Bytes(),
+		e.Counterparty.Bytes(),
+		e.TokenStandard.Bytes(),
+		common.Uint64ToBytes(e.BlockType),
+		common.Uint64ToBytes(e.MomentumHeight),
+		common.Uint64ToBytes(uint64(e.Timestamp)),
+		[]byte(e.MethodName),
+	)
+}
+func DeserializeEntry(data []byte) (*Entry, error) {
+	if len(data) < entryMinimumSize {
+		return nil, errors.Errorf("invalid indexer entry size %v", len(data))
+	}
+	e := &Entry{}
+	offset := 0
+	next := func(size int) []byte {
+		current := data[offset : offset+size]
+		offset += size
+		return current
+	}
+	if err := e.Hash.SetBytes(next(types.HashSize)); err != nil {
+		return nil, err
+	}
+	if err := e.Address.SetBytes(next(types.AddressSize)); err != nil {
+		return nil, err
+	}
+	if err := e.Counterparty.SetBytes(next(types.AddressSize)); err != nil {
+		return nil, err
+	}
+	if err := e.TokenStandard.SetBytes(next(types.ZenonTokenStandardSize)); err != nil {
+		return nil, err
+	}
+	e.BlockType = common.BytesToUint64(next(8))
+	e.MomentumHeight = common.BytesToUint64(next(8))
+	e.Timestamp = int64(common.BytesToUint64(next(8)))
+	e.MethodName = string(data[offset:])
+	return e, nil
+}
+
+// Other returns the other party of the entry, from the point of view of address
+func (e *Entry) Other(address types.Address) types.Address {
+	if e.Address == address {
+		return e.Counterparty
+	}
+	return e.Address
+}
+
+// Filter restricts the entries returned for an address. All non-empty fields need to match.
+type Filter struct {
+	Counterparty  *types.Address            `json:"counterparty"`
+	TokenStandard *types.ZenonTokenStandard `json:"tokenStandard"`
+	BlockTypes    []uint64                  `json:"blockTypes"`
+	MethodName    string                    `json:"methodName"`
+	// FromTimestamp and ToTimestamp are inclusive unix timestamps of the momentum which confirmed the block
+	FromTimestamp int64 `json:"fromTimestamp"`
+	ToTimestamp   int64 `json:"toTimestamp"`
+}
+
+func (f *Filter) Matches(address types.Address, e *Entry) bool {
+	if f == nil {
+		return true
+	}
+	if f.Counterparty != nil && e.Other(address) != *f.Counterparty {
+		return false
+	}
+	if f.TokenStandard != nil && e.TokenStandard != *f.TokenStandard {
+		return false
+	}
+	if len(f.BlockTypes) != 0 {
+		found := false
+		for _, blockType := range f.BlockTypes {
+			if blockType == e.BlockType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.MethodName != "" && e.MethodName != f.MethodName {
+		return false
+	}
+	if f.FromTimestamp != 0 && e.Timestamp < f.FromTimestamp {
+		return false
+	}
+	if f.ToTimestamp != 0 && e.Timestamp > f.ToTimestamp {
+		return false
+	}
+	return true
+}
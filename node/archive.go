@@ -0,0 +1,192 @@
+package node
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/tsdb/fileutil"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/archive"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/verifier"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+const (
+	// importBatchSize is the number of momentums inserted at once while importing an archive
+	importBatchSize = 100
+)
+
+// ExportChain writes the momentums in the range [from, to] from the database in DataPath to the archive file.
+// A to of 0 exports everything up to the frontier momentum.
+func ExportChain(c *Config, from, to uint64, file string) error {
+	lock, err := lockDataDir(c.DataPath)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	zenonConfig := &zenon.Config{
+		DataDir:       c.DataPath,
+		GenesisConfig: c.makeGenesisConfig(),
+	}
+	ch := chain.NewChain(zenonConfig.NewDBManager("nom"), zenonConfig.GenesisConfig)
+	if err := ch.Init(); err != nil {
+		return err
+	}
+	if err := ch.Start(); err != nil {
+		return err
+	}
+	defer ch.Stop()
+
+	store := ch.GetFrontierMomentumStore()
+	frontier := store.Identifier()
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = frontier.Height
+	}
+	if from > to || to > frontier.Height {
+		return errors.Errorf("invalid export range [%v, %v]. Frontier momentum height is %v", from, to, frontier.Height)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer, err := archive.NewWriter(f, ch.ChainIdentifier())
+	if err != nil {
+		return err
+	}
+	for height := from; height <= to; height += 1 {
+		momentum, err := store.GetMomentumByHeight(height)
+		if err != nil {
+			return err
+		}
+		detailed, err := store.PrefetchMomentum(momentum)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(detailed); err != nil {
+			return err
+		}
+		if height%10000 == 0 {
+			fmt.Printf("Exported momentums up to height %v\n", height)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	log.Info("exported momentums", "from", from, "to", to, "file", file)
+	fmt.Printf("Exported %v momentums to %v\n", writer.Count(), file)
+	return f.Sync()
+}
+
+// ImportChain replays the momentums from the archive file on top of the database in DataPath.
+// Every momentum and account-block is fully verified, exactly like momentums received from the network.
+// Only the chain and the consensus run while importing, the node prunes and indexes the imported momentums
+// in the background the next time it starts.
+func ImportChain(c *Config, file string) error {
+	lock, err := lockDataDir(c.DataPath)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader, err := archive.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	zenonConfig := &zenon.Config{
+		DataDir:       c.DataPath,
+		GenesisConfig: c.makeGenesisConfig(),
+	}
+	ch := chain.NewChain(zenonConfig.NewDBManager("nom"), zenonConfig.GenesisConfig)
+	if ch.ChainIdentifier() != reader.ChainIdentifier() {
+		return errors.Errorf("archive has chain identifier %v but the node uses %v", reader.ChainIdentifier(), ch.ChainIdentifier())
+	}
+	if err := ch.Init(); err != nil {
+		return err
+	}
+	if err := ch.Start(); err != nil {
+		return err
+	}
+	defer ch.Stop()
+
+	consensusDB, consensusLevelDB := zenonConfig.NewLevelDB("consensus")
+	defer consensusLevelDB.Close()
+	cs := consensus.NewConsensus(consensusDB, ch, false)
+	if err := cs.Init(); err != nil {
+		return err
+	}
+	if err := cs.Start(); err != nil {
+		return err
+	}
+	defer cs.Stop()
+
+	bridge := protocol.NewChainBridge(ch, cs, verifier.NewVerifier(ch, cs), vm.NewSupervisor(ch, cs))
+	batch := make([]*nom.DetailedMomentum, 0, importBatchSize)
+	insertBatch := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if index, err := bridge.InsertChain(batch); err != nil {
+			return errors.Errorf("failed to import momentum %v. Reason: %v", batch[index].Momentum.Identifier(), err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		detailed, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		batch = append(batch, detailed)
+		if len(batch) == importBatchSize {
+			if err := insertBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := insertBatch(); err != nil {
+		return err
+	}
+
+	frontier := ch.GetFrontierMomentumStore().Identifier()
+	log.Info("imported momentums", "file", file, "frontier-identifier", frontier)
+	fmt.Printf("Imported momentums from %v. Height: %v, Hash: %v\n", file, frontier.Height, frontier.Hash)
+	return nil
+}
+
+func lockDataDir(dataPath string) (fileutil.Releaser, error) {
+	if err := os.MkdirAll(dataPath, 0700); err != nil {
+		return nil, err
+	}
+	lock, _, err := fileutil.Flock(filepath.Join(dataPath, ".lock"))
+	if err != nil {
+		return nil, convertFileLockError(err)
+	}
+	return lock, nil
+}
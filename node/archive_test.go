@@ -0,0 +1,126 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain/archive"
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// writeMockGenesis writes the genesis of the mock chain to a file, so that the node uses the same chain
+func writeMockGenesis(t *testing.T, dir string) string {
+	data, err := json.Marshal(g.EmbeddedGenesis)
+	common.FailIfErr(t, err)
+	file := filepath.Join(dir, "genesis.json")
+	common.FailIfErr(t, ioutil.WriteFile(file, data, 0600))
+	return file
+}
+
+// archiveMockChain writes all the momentums of a mock chain with a few account-blocks to an archive file
+func archiveMockChain(t *testing.T, file string) types.HashHeight {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+
+	for i := 0; i < 5; i += 1 {
+		z.InsertSendBlock(&nom.AccountBlock{
+			Address:       g.User1.Address,
+			ToAddress:     g.User2.Address,
+			TokenStandard: types.ZnnTokenStandard,
+			Amount:        big.NewInt(10 * g.Zexp),
+		}, nil, mock.SkipVmChanges)
+		z.InsertNewMomentum()
+	}
+
+	f, err := os.Create(file)
+	common.FailIfErr(t, err)
+	defer f.Close()
+	store := z.Chain().GetFrontierMomentumStore()
+	writer, err := archive.NewWriter(f, z.Chain().ChainIdentifier())
+	common.FailIfErr(t, err)
+	for height := uint64(1); height <= store.Identifier().Height; height += 1 {
+		momentum, err := store.GetMomentumByHeight(height)
+		common.FailIfErr(t, err)
+		detailed, err := store.PrefetchMomentum(momentum)
+		common.FailIfErr(t, err)
+		common.FailIfErr(t, writer.Write(detailed))
+	}
+	common.FailIfErr(t, writer.Close())
+	return store.Identifier()
+}
+
+func readArchive(t *testing.T, file string) []*nom.DetailedMomentum {
+	f, err := os.Open(file)
+	common.FailIfErr(t, err)
+	defer f.Close()
+	reader, err := archive.NewReader(f)
+	common.FailIfErr(t, err)
+	momentums := make([]*nom.DetailedMomentum, 0)
+	for {
+		detailed, err := reader.Read()
+		if err != nil {
+			if len(momentums) == 0 {
+				t.Fatalf("failed to read archive %v: %v", file, err)
+			}
+			return momentums
+		}
+		momentums = append(momentums, detailed)
+	}
+}
+
+// Test that the momentums imported from an archive are exported back unchanged
+func TestImportExportChain(t *testing.T) {
+	dir := t.TempDir()
+	genesisFile := writeMockGenesis(t, dir)
+	original := filepath.Join(dir, "original.archive")
+	frontier := archiveMockChain(t, original)
+
+	config := &Config{DataPath: filepath.Join(dir, "node"), GenesisFile: genesisFile}
+	common.FailIfErr(t, ImportChain(config, original))
+
+	exported := filepath.Join(dir, "exported.archive")
+	common.FailIfErr(t, ExportChain(config, 0, 0, exported))
+	originalData, err := ioutil.ReadFile(original)
+	common.FailIfErr(t, err)
+	exportedData, err := ioutil.ReadFile(exported)
+	common.FailIfErr(t, err)
+	if !bytes.Equal(originalData, exportedData) {
+		t.Fatalf("exported archive differs from the imported one")
+	}
+	momentums := readArchive(t, exported)
+	if last := momentums[len(momentums)-1].Momentum.Identifier(); last != frontier {
+		t.Fatalf("exported frontier mismatch: have %v, want %v", last, frontier)
+	}
+
+	// a partial export can be imported by a new node
+	partial := filepath.Join(dir, "partial.archive")
+	common.FailIfErr(t, ExportChain(config, 1, 3, partial))
+	momentums = readArchive(t, partial)
+	if len(momentums) != 3 || momentums[0].Momentum.Height != 1 || momentums[2].Momentum.Height != 3 {
+		t.Fatalf("partial export mismatch: have %v momentums", len(momentums))
+	}
+	other := &Config{DataPath: filepath.Join(dir, "other"), GenesisFile: genesisFile}
+	common.FailIfErr(t, ImportChain(other, partial))
+	common.FailIfErr(t, ExportChain(other, 0, 0, filepath.Join(dir, "other.archive")))
+	momentums = readArchive(t, filepath.Join(dir, "other.archive"))
+	if last := momentums[len(momentums)-1].Momentum.Height; last != 3 {
+		t.Fatalf("imported frontier height mismatch: have %v, want 3", last)
+	}
+
+	// invalid ranges
+	if err := ExportChain(config, 4, 2, filepath.Join(dir, "invalid.archive")); err == nil {
+		t.Fatalf("export of an inverted range succeeded")
+	}
+	if err := ExportChain(config, 1, frontier.Height+1, filepath.Join(dir, "invalid.archive")); err == nil {
+		t.Fatalf("export past the frontier succeeded")
+	}
+}
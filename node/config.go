@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/metadata"
 	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/pillar"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
 	"github.com/zenon-network/go-zenon/wallet"
 	"github.com/zenon-network/go-zenon/zenon"
 )
@@ -21,6 +24,16 @@ type ProducerConfig struct {
 	Index       uint32
 	KeyFilePath string
 	Password    string
+
+	// Standby enables the hot-standby mode. The nodes configured with the same producer and LeaseFilePath coordinate
+	// through the lease file and only the node which holds the lease produces momentums.
+	// It only coordinates nodes which share a filesystem, like nodes on the same host. The lease file is guarded
+	// with flock, which is unreliable on NFS and other network filesystems, so don't put the lease file on one.
+	Standby *StandbyConfig
+}
+type StandbyConfig struct {
+	LeaseFilePath string
+	LeaseDuration int64 // seconds, defaults to DefaultLeaseDuration
 }
 type RPCConfig struct {
 	EnableHTTP bool
@@ -36,6 +49,15 @@ type RPCConfig struct {
 	HTTPVirtualHosts []string
 	HTTPCors         []string
 	WSOrigins        []string
+
+	// AuthKeys enables authentication on the HTTP and WS RPC if not empty. Each key can only call its Endpoints,
+	// which use the same names as Endpoints and can end with a wildcard, for example `embedded.*`
+	AuthKeys []rpc.AuthKey
+
+	// EnableMetrics serves the metrics of the node in the prometheus text format on http://MetricsHost:MetricsPort/metrics
+	EnableMetrics bool
+	MetricsHost   string
+	MetricsPort   int
 }
 type NetConfig struct {
 	ListenHost string
@@ -58,6 +80,9 @@ type Config struct {
 
 	LogLevel string // "debug", "dbug" | "info" | "warn" | "error", "error" | "crit"
 
+	EnableIndexer bool   // keeps address-indexed account-block history in DataPath/indexer
+	Prune         uint64 // number of epochs of momentums and account-blocks to keep, 0 keeps everything
+
 	Producer *ProducerConfig
 	RPC      RPCConfig
 	Net      NetConfig
@@ -102,13 +127,21 @@ func (c *Config) makeZenonConfig(walletManager *wallet.Manager) (*zenon.Config,
 	if err != nil {
 		return nil, err
 	}
+	lease, leaseDuration, err := c.parseStandby()
+	if err != nil {
+		return nil, err
+	}
 
 	return &zenon.Config{
 		MinPeers:          c.Net.MinPeers,
 		MinConnectedPeers: c.Net.MinConnectedPeers,
 		ProducingKeyPair:  pillarCoinbase,
+		ProducerLease:     lease,
+		LeaseDuration:     leaseDuration,
 		GenesisConfig:     c.makeGenesisConfig(),
 		DataDir:           c.DataPath,
+		EnableIndexer:     c.EnableIndexer,
+		PruneEpochs:       c.Prune,
 	}, nil
 }
 func (c *Config) makeGenesisConfig() (genesisConfig store.Genesis) {
@@ -140,6 +173,34 @@ func (c *Config) makeGenesisConfig() (genesisConfig store.Genesis) {
 		return
 	}
 }
+func (c *Config) parseStandby() (pillar.Lease, time.Duration, error) {
+	if c.Producer == nil || c.Producer.Standby == nil {
+		return nil, 0, nil
+	}
+
+	if c.Producer.Standby.LeaseFilePath == "" {
+		return nil, 0, fmt.Errorf("unable to parse producer standby. Reason:missing lease file path")
+	}
+	path, err := filepath.Abs(ReplaceHomeVariable(c.Producer.Standby.LeaseFilePath))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	duration := time.Duration(c.Producer.Standby.LeaseDuration) * time.Second
+	if duration == 0 {
+		duration = DefaultLeaseDuration
+	}
+	// the lease must outlive a few producing slots, otherwise the renewals race the slots
+	if duration < MinLeaseDuration {
+		return nil, 0, errors.Errorf("producer lease duration must be at least %v", MinLeaseDuration)
+	}
+
+	lease, err := pillar.NewFileLease(path, duration)
+	if err != nil {
+		return nil, 0, err
+	}
+	return lease, duration, nil
+}
 func (c *Config) parseProducer(walletManager *wallet.Manager) (*wallet.KeyPair, error) {
 	if c.Producer == nil {
 		return nil, nil
@@ -5,12 +5,16 @@ import (
 	"os/user"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/zenon-network/go-zenon/p2p"
 )
 
 const (
 	DefaultWalletDir = "wallet"
+
+	DefaultLeaseDuration = 30 * time.Second // Duration of the producer lease in hot-standby mode
+	MinLeaseDuration     = 15 * time.Second // Minimum duration of the producer lease, a few producing slots
 )
 
 var DefaultNodeConfig = Config{
@@ -30,6 +34,9 @@ var DefaultNodeConfig = Config{
 
 		HTTPCors:  []string{"*"},
 		WSOrigins: []string{"*"},
+
+		MetricsHost: "127.0.0.1",
+		MetricsPort: p2p.DefaultMetricsPort,
 	},
 	Net: NetConfig{
 		ListenHost:        p2p.DefaultListenHost,
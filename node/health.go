@@ -0,0 +1,85 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+const (
+	// maxFrontierAge is the number of block times after which a frontier momentum is considered stale
+	maxFrontierAge = 6
+)
+
+// Readiness is the body of the /ready endpoint
+type Readiness struct {
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason,omitempty"`
+
+	SyncInfo       *protocol.SyncInfo `json:"syncInfo"`
+	FrontierHeight uint64             `json:"frontierHeight"`
+	FrontierAge    int64              `json:"frontierAge"` // in seconds
+	PeerCount      int                `json:"peerCount"`
+}
+
+// newHealthHandler returns the handler of the /health and /ready endpoints used by load balancers.
+// /health answers as long as the node is running, /ready only while readiness reports the node as ready.
+func newHealthHandler(readiness func() *Readiness) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		readiness := readiness()
+		w.Header().Set("Content-Type", "application/json")
+		if readiness.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(readiness)
+	})
+	return mux
+}
+
+// registerHealthHandlers mounts the health endpoints on the HTTP-RPC server,
+// they only expose the sync state of the node so they don't require authentication.
+func (node *Node) registerHealthHandlers() {
+	handler := newHealthHandler(node.Readiness)
+	node.RegisterHandler("health", "/health", handler)
+	node.RegisterHandler("readiness", "/ready", handler)
+}
+
+// Readiness reports if the node is in sync with the network and has a recent frontier momentum
+func (node *Node) Readiness() *Readiness {
+	momentum, err := node.z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	if err != nil {
+		return &Readiness{Reason: err.Error()}
+	}
+	return checkReadiness(momentum, node.z.Protocol().SyncInfo(), node.server.PeerCount(), node.config.Net.MinPeers)
+}
+
+func checkReadiness(momentum *nom.Momentum, syncInfo *protocol.SyncInfo, peerCount, minPeers int) *Readiness {
+	readiness := &Readiness{
+		SyncInfo:       syncInfo,
+		FrontierHeight: momentum.Height,
+		FrontierAge:    common.Clock.Now().Unix() - int64(momentum.TimestampUnix),
+		PeerCount:      peerCount,
+	}
+	switch {
+	case readiness.PeerCount < minPeers || readiness.SyncInfo.State == protocol.NotEnoughPeers:
+		readiness.Reason = "not enough peers"
+	case readiness.SyncInfo.State != protocol.SyncDone:
+		readiness.Reason = "sync is not done"
+	case readiness.FrontierAge > maxFrontierAge*constants.ConsensusConfig.BlockTime:
+		readiness.Reason = "frontier momentum is too old"
+	default:
+		readiness.Ready = true
+	}
+	return readiness
+}
@@ -0,0 +1,135 @@
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/protocol"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func TestCheckReadiness(t *testing.T) {
+	previous := common.Clock
+	now := time.Unix(1000000000, 0)
+	common.Clock = &testClock{now: now}
+	t.Cleanup(func() { common.Clock = previous })
+
+	recent := &nom.Momentum{Height: 10, TimestampUnix: uint64(now.Unix()) - 10}
+	stale := &nom.Momentum{Height: 10, TimestampUnix: uint64(now.Unix() - maxFrontierAge*constants.ConsensusConfig.BlockTime - 1)}
+
+	tests := []struct {
+		name      string
+		momentum  *nom.Momentum
+		state     protocol.SyncState
+		peerCount int
+		ready     bool
+		reason    string
+	}{
+		{"ready", recent, protocol.SyncDone, 5, true, ""},
+		{"too few peers", recent, protocol.SyncDone, 2, false, "not enough peers"},
+		{"not enough peers to sync", recent, protocol.NotEnoughPeers, 5, false, "not enough peers"},
+		{"syncing", recent, protocol.Syncing, 5, false, "sync is not done"},
+		{"stale frontier", stale, protocol.SyncDone, 5, false, "frontier momentum is too old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readiness := checkReadiness(tt.momentum, &protocol.SyncInfo{State: tt.state}, tt.peerCount, 3)
+			if readiness.Ready != tt.ready || readiness.Reason != tt.reason {
+				t.Fatalf("have %v %q, want %v %q", readiness.Ready, readiness.Reason, tt.ready, tt.reason)
+			}
+			if readiness.FrontierHeight != 10 || readiness.PeerCount != tt.peerCount {
+				t.Fatalf("readiness details mismatch: %+v", readiness)
+			}
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	readiness := &Readiness{Reason: "sync is not done", SyncInfo: &protocol.SyncInfo{State: protocol.Syncing}}
+	handler := newHealthHandler(func() *Readiness { return readiness })
+
+	get := func(path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code, recorder.Body.String()
+	}
+
+	if status, body := get("/health"); status != http.StatusOK || body != "OK" {
+		t.Fatalf("health mismatch: have %v %v, want %v OK", status, body, http.StatusOK)
+	}
+	if status, _ := get("/"); status != http.StatusNotFound {
+		t.Fatalf("status of other paths mismatch: have %v, want %v", status, http.StatusNotFound)
+	}
+
+	status, body := get("/ready")
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("not ready status mismatch: have %v, want %v", status, http.StatusServiceUnavailable)
+	}
+	decoded := new(Readiness)
+	if err := json.Unmarshal([]byte(body), decoded); err != nil {
+		t.Fatalf("failed to decode readiness: %v", err)
+	}
+	if decoded.Ready || decoded.Reason != readiness.Reason || decoded.SyncInfo.State != protocol.Syncing {
+		t.Fatalf("readiness mismatch: have %+v, want %+v", decoded, readiness)
+	}
+
+	readiness = &Readiness{Ready: true, SyncInfo: &protocol.SyncInfo{State: protocol.SyncDone}}
+	if status, _ := get("/ready"); status != http.StatusOK {
+		t.Fatalf("ready status mismatch: have %v, want %v", status, http.StatusOK)
+	}
+}
+
+// freePort returns a port which is free at the time of the call
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	common.FailIfErr(t, err)
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// Test that the health endpoints are served by the HTTP-RPC server, without its authentication
+func TestRegisterHealthHandlers(t *testing.T) {
+	port := freePort(t)
+	node := &Node{http: newHTTPServer(rpc.DefaultHTTPTimeouts)}
+	node.registerHealthHandlers()
+	common.FailIfErr(t, node.http.setListenAddr("127.0.0.1", port))
+	common.FailIfErr(t, node.http.enableRPC(nil, httpConfig{AuthKeys: []rpc.AuthKey{{APIKey: "secret"}}}))
+	common.FailIfErr(t, node.http.start())
+	defer node.http.stop()
+	url := "http://" + net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+
+	resp, err := http.Get(url + "/health")
+	common.FailIfErr(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	common.FailIfErr(t, err)
+	if resp.StatusCode != http.StatusOK || string(body) != "OK" {
+		t.Fatalf("health mismatch: have %v %s, want %v OK", resp.StatusCode, body, http.StatusOK)
+	}
+
+	// the JSON-RPC served by the same server still requires authentication
+	resp, err = http.Post(url, "application/json", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"stats.syncInfo","params":[]}`))
+	common.FailIfErr(t, err)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated JSON-RPC status mismatch: have %v, want %v", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,105 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/pillar"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+)
+
+// enableMetrics turns on metrics collection and registers the metrics updated by the packages of the node.
+// It's called before any component is created, so that none of them keeps updating no-op metrics.
+func enableMetrics() {
+	metrics.Enabled = true
+	chain.RegisterMetrics()
+	p2p.RegisterMetrics()
+	pillar.RegisterMetrics()
+	rpc.RegisterMetrics()
+}
+
+// downloaderStats is implemented by downloader.Downloader
+type downloaderStats interface {
+	Stats() (pending int, cached int, importing int, estimate time.Duration)
+}
+
+// registerMetrics registers the gauges which are computed on every scrape from the state of the node.
+// The gauges of a node which was previously created in the same process are replaced.
+func registerMetrics(chain chain.Chain, downloader downloaderStats, peerCount func() int) {
+	register := func(name string, value func() int64) {
+		metrics.Unregister(name)
+		metrics.NewRegisteredFunctionalGauge(name, nil, value)
+	}
+	register("chain/frontier/height", func() int64 {
+		return int64(chain.GetFrontierMomentumStore().Identifier().Height)
+	})
+	register("chain/accountpool/size", func() int64 {
+		return int64(len(chain.GetAllUncommittedAccountBlocks()))
+	})
+	register("p2p/peers", func() int64 {
+		return int64(peerCount())
+	})
+	register("downloader/queue/pending", func() int64 {
+		pending, _, _, _ := downloader.Stats()
+		return int64(pending)
+	})
+	register("downloader/queue/cached", func() int64 {
+		_, cached, _, _ := downloader.Stats()
+		return int64(cached)
+	})
+	register("downloader/queue/importing", func() int64 {
+		_, _, importing, _ := downloader.Stats()
+		return int64(importing)
+	})
+	register("downloader/estimate/seconds", func() int64 {
+		_, _, _, estimate := downloader.Stats()
+		return int64(estimate / time.Second)
+	})
+}
+
+// startMetrics serves all the registered metrics in the prometheus text format on /metrics.
+func (node *Node) startMetrics() error {
+	if !node.config.RPC.EnableMetrics {
+		return nil
+	}
+	registerMetrics(node.z.Chain(), node.z.Protocol().Downloader(), node.server.PeerCount)
+
+	endpoint := fmt.Sprintf("%s:%d", node.config.RPC.MetricsHost, node.config.RPC.MetricsPort)
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return err
+	}
+
+	node.metrics = &http.Server{
+		Handler:      newMetricsHandler(),
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	go node.metrics.Serve(listener)
+	log.Info("metrics enabled", "url", "http://"+listener.Addr().String()+"/metrics")
+	return nil
+}
+
+// newMetricsHandler serves all the registered metrics in the prometheus text format on /metrics
+func newMetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler(metrics.DefaultRegistry))
+	return mux
+}
+
+func (node *Node) stopMetrics() {
+	if node.metrics == nil {
+		return
+	}
+	if err := node.metrics.Close(); err != nil {
+		log.Error("failed to stop metrics server", "reason", err)
+	}
+	node.metrics = nil
+}
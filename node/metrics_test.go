@@ -0,0 +1,64 @@
+package node
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+type testDownloader struct{}
+
+func (testDownloader) Stats() (int, int, int, time.Duration) {
+	return 7, 3, 1, 90 * time.Second
+}
+
+// Test that /metrics exports the metrics of the chain, p2p and rpc packages and the ones computed from the node
+func TestMetricsHandler(t *testing.T) {
+	enabled := metrics.Enabled
+	t.Cleanup(func() { metrics.Enabled = enabled })
+	enableMetrics()
+
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	registerMetrics(z.Chain(), testDownloader{}, func() int { return 4 })
+	z.InsertMomentumsTo(10)
+
+	recorder := httptest.NewRecorder()
+	newMetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status mismatch: have %v, want %v", recorder.Code, http.StatusOK)
+	}
+	body := recorder.Body.String()
+
+	// the values of the gauges computed from the node
+	for _, series := range []string{
+		"chain_frontier_height 10",
+		"chain_accountpool_size 0",
+		"p2p_peers 4",
+		"downloader_queue_pending 7",
+		"downloader_estimate_seconds 90",
+	} {
+		if !strings.Contains(body, "\n"+series+"\n") {
+			t.Errorf("series %q missing from:\n%v", series, body)
+		}
+	}
+	// the metrics updated by the packages are shared by all the tests, only their presence is checked
+	for _, name := range []string{
+		"chain_momentums_insert_count",
+		"p2p_ingress_traffic",
+		"p2p_egress_connects",
+		"rpc_requests",
+		"rpc_duration_all_count",
+		"pillar_momentums_produced",
+	} {
+		if !strings.Contains(body, "\n"+name+" ") {
+			t.Errorf("series %q missing from:\n%v", name, body)
+		}
+	}
+}
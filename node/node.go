@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sync"
@@ -30,9 +31,10 @@ type Node struct {
 
 	z zenon.Zenon
 
-	rpcAPIs []rpc.API   // List of APIs currently provided by the node
-	http    *httpServer //
-	ws      *httpServer //
+	rpcAPIs []rpc.API    // List of APIs currently provided by the node
+	http    *httpServer  //
+	ws      *httpServer  //
+	metrics *http.Server // serves the prometheus metrics, nil if disabled
 
 	// Channel to wait for termination notifications
 	stop        chan struct{}
@@ -51,6 +53,11 @@ func NewNode(conf *Config) (*Node, error) {
 		ws:            newHTTPServer(rpc.DefaultHTTPTimeouts),
 	}
 
+	// metrics are collected only when they are exported, before any component is created
+	if conf.RPC.EnableMetrics {
+		enableMetrics()
+	}
+
 	// prepare node
 	log.Info("preparing node ... ")
 	if err = node.openDataDir(); err != nil {
@@ -108,11 +115,17 @@ func (node *Node) Start() error {
 	if err := node.server.Start(); err != nil {
 		return err
 	}
-	node.rpcAPIs = api.GetPublicApis(node.z, node.server)
+	node.z.Protocol().SetPeerBanner(node.server)
+	node.rpcAPIs = append(api.GetPublicApis(node.z, node.server), api.GetApis(node.z, node.server, "admin")...)
+	node.registerHealthHandlers()
 	if err := node.startRPC(); err != nil {
 		log.Error("failed to start rpc", "reason", err)
 		return err
 	}
+	if err := node.startMetrics(); err != nil {
+		log.Error("failed to start metrics", "reason", err)
+		return err
+	}
 
 	return nil
 }
@@ -133,6 +146,7 @@ func (node *Node) Stop() error {
 		return err
 	}
 	node.stopRPC()
+	node.stopMetrics()
 
 	// Release instance directory lock.
 	node.closeDataDir()
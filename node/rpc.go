@@ -1,5 +1,7 @@
 package node
 
+import "net/http"
+
 // configureRPC is a helper method to configure all the various RPC endpoints during node
 // startup. It's not meant to be called at any time afterwards as it makes certain
 // assumptions about the state of the node.
@@ -9,6 +11,7 @@ func (node *Node) startRPC() error {
 		config := httpConfig{
 			CorsAllowedOrigins: node.config.RPC.HTTPCors,
 			Vhosts:             node.config.RPC.HTTPVirtualHosts,
+			AuthKeys:           node.config.RPC.AuthKeys,
 			Modules:            node.config.RPC.Endpoints,
 			prefix:             "",
 		}
@@ -24,9 +27,10 @@ func (node *Node) startRPC() error {
 	if node.config.RPC.WSHost != "" {
 		server := node.wsServerForPort(node.config.RPC.WSPort)
 		config := wsConfig{
-			Modules: node.config.RPC.Endpoints,
-			Origins: node.config.RPC.WSOrigins,
-			prefix:  "",
+			Modules:  node.config.RPC.Endpoints,
+			Origins:  node.config.RPC.WSOrigins,
+			AuthKeys: node.config.RPC.AuthKeys,
+			prefix:   "",
 		}
 		if err := server.setListenAddr(node.config.RPC.WSHost, node.config.RPC.WSPort); err != nil {
 			return err
@@ -49,6 +53,13 @@ func (node *Node) wsServerForPort(port int) *httpServer {
 	return node.ws
 }
 
+// RegisterHandler mounts handler on path of the HTTP-RPC server, next to the JSON-RPC endpoint.
+// The handlers are served while the HTTP-RPC is enabled, without the authentication of the JSON-RPC.
+func (node *Node) RegisterHandler(name, path string, handler http.Handler) {
+	node.http.mux.Handle(path, handler)
+	node.http.handlerNames[path] = name
+}
+
 func (node *Node) stopRPC() {
 	node.http.stop()
 	node.ws.stop()
@@ -25,14 +25,16 @@ type httpConfig struct {
 	Modules            []string
 	CorsAllowedOrigins []string
 	Vhosts             []string
+	AuthKeys           []rpc.AuthKey
 	prefix             string // path prefix on which to mount http handler
 }
 
 // wsConfig is the JSON-RPC/Websocket configuration
 type wsConfig struct {
-	Origins []string
-	Modules []string
-	prefix  string // path prefix on which to mount ws handler
+	Origins  []string
+	Modules  []string
+	AuthKeys []rpc.AuthKey
+	prefix   string // path prefix on which to mount ws handler
 }
 
 type rpcHandler struct {
@@ -275,7 +277,7 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	}
 	h.httpConfig = config
 	h.httpHandler.Store(&rpcHandler{
-		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts),
+		Handler: NewHTTPHandlerStack(srv, config.CorsAllowedOrigins, config.Vhosts, config.AuthKeys),
 		server:  srv,
 	})
 	return nil
@@ -307,7 +309,7 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 	}
 	h.wsConfig = config
 	h.wsHandler.Store(&rpcHandler{
-		Handler: srv.WebsocketHandler(config.Origins),
+		Handler: rpc.NewAuthHandler(config.AuthKeys, srv.WebsocketHandler(config.Origins)),
 		server:  srv,
 	})
 	return nil
@@ -352,9 +354,11 @@ func isWebsocket(r *http.Request) bool {
 }
 
 // NewHTTPHandlerStack returns wrapped http-related handlers
-func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string) http.Handler {
+func NewHTTPHandlerStack(srv http.Handler, cors []string, vhosts []string, authKeys []rpc.AuthKey) http.Handler {
+	// Wrap the auth-handler within a CORS-handler, so preflight requests don't need to be authenticated
+	handler := rpc.NewAuthHandler(authKeys, srv)
 	// Wrap the CORS-handler within a host-handler
-	handler := newCorsHandler(srv, cors)
+	handler = newCorsHandler(handler, cors)
 	handler = newVHostHandler(vhosts, handler)
 	return newGzipHandler(handler)
 }
@@ -0,0 +1,135 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/p2p/discover"
+)
+
+// banStore persists the bans, implemented by the discovery table.
+type banStore interface {
+	Ban(id discover.NodeID, until time.Time) error
+	Unban(id discover.NodeID) error
+	Bans() map[discover.NodeID]time.Time
+}
+
+// BannedPeer is a node which is not allowed to connect until BannedUntil.
+type BannedPeer struct {
+	ID          discover.NodeID
+	BannedUntil time.Time
+}
+
+// banList keeps the banned nodes in memory and persists them in the node
+// database if discovery is enabled.
+type banList struct {
+	lock  sync.RWMutex
+	bans  map[discover.NodeID]time.Time
+	store banStore
+}
+
+func newBanList(store banStore) *banList {
+	list := &banList{
+		bans:  make(map[discover.NodeID]time.Time),
+		store: store,
+	}
+	if store != nil {
+		for id, until := range store.Bans() {
+			list.bans[id] = until
+		}
+	}
+	return list
+}
+
+func (l *banList) ban(id discover.NodeID, until time.Time) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.bans[id] = until
+	if l.store != nil {
+		return l.store.Ban(id, until)
+	}
+	return nil
+}
+
+func (l *banList) unban(id discover.NodeID) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	delete(l.bans, id)
+	if l.store != nil {
+		return l.store.Unban(id)
+	}
+	return nil
+}
+
+func (l *banList) isBanned(id discover.NodeID) bool {
+	l.lock.RLock()
+	until, ok := l.bans[id]
+	l.lock.RUnlock()
+
+	if !ok {
+		return false
+	}
+	if time.Now().Before(until) {
+		return true
+	}
+	// the ban expired, forget it
+	if err := l.unban(id); err != nil {
+		common.P2PLogger.Warn("failed to remove expired ban", "id", id, "reason", err)
+	}
+	return false
+}
+
+func (l *banList) list() []*BannedPeer {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	now := time.Now()
+	list := make([]*BannedPeer, 0, len(l.bans))
+	for id, until := range l.bans {
+		if now.Before(until) {
+			list = append(list, &BannedPeer{ID: id, BannedUntil: until})
+		}
+	}
+	return list
+}
+
+// BanPeer disconnects the node if it's connected and refuses its connections for the given duration.
+func (srv *Server) BanPeer(id discover.NodeID, duration time.Duration) error {
+	if srv.bans == nil {
+		return errServerStopped
+	}
+	common.P2PLogger.Info("banning peer", "id", id, "duration", duration)
+	if err := srv.bans.ban(id, time.Now().Add(duration)); err != nil {
+		return err
+	}
+	select {
+	case srv.peerOp <- func(peers map[discover.NodeID]*Peer) {
+		if p, ok := peers[id]; ok {
+			p.Disconnect(DiscUselessPeer)
+		}
+	}:
+		<-srv.peerOpDone
+	case <-srv.quit:
+	}
+	return nil
+}
+
+// UnbanPeer allows the node to connect again.
+func (srv *Server) UnbanPeer(id discover.NodeID) error {
+	if srv.bans == nil {
+		return errServerStopped
+	}
+	common.P2PLogger.Info("unbanning peer", "id", id)
+	return srv.bans.unban(id)
+}
+
+// BannedPeers returns the nodes which are currently banned.
+func (srv *Server) BannedPeers() []*BannedPeer {
+	if srv.bans == nil {
+		return []*BannedPeer{}
+	}
+	return srv.bans.list()
+}
@@ -0,0 +1,101 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/p2p/discover"
+)
+
+// memoryBanStore is a banStore which keeps the bans in a map
+type memoryBanStore map[discover.NodeID]time.Time
+
+func (s memoryBanStore) Ban(id discover.NodeID, until time.Time) error {
+	s[id] = until
+	return nil
+}
+func (s memoryBanStore) Unban(id discover.NodeID) error {
+	delete(s, id)
+	return nil
+}
+func (s memoryBanStore) Bans() map[discover.NodeID]time.Time {
+	bans := make(map[discover.NodeID]time.Time, len(s))
+	for id, until := range s {
+		bans[id] = until
+	}
+	return bans
+}
+
+func TestBanList(t *testing.T) {
+	a, b, c := discover.NodeID{1}, discover.NodeID{2}, discover.NodeID{3}
+	store := memoryBanStore{a: time.Now().Add(time.Hour)}
+
+	// bans are loaded from the store
+	list := newBanList(store)
+	if !list.isBanned(a) {
+		t.Fatalf("ban from the store was not loaded")
+	}
+
+	// new bans are persisted
+	if err := list.ban(b, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to ban: %v", err)
+	}
+	if _, ok := store[b]; !ok || !list.isBanned(b) {
+		t.Fatalf("ban was not persisted")
+	}
+	if list.isBanned(c) {
+		t.Fatalf("node which was never banned is banned")
+	}
+	if len(list.list()) != 2 {
+		t.Fatalf("unexpected banned peers: %v", list.list())
+	}
+
+	// unbans are persisted
+	if err := list.unban(a); err != nil {
+		t.Fatalf("failed to unban: %v", err)
+	}
+	if _, ok := store[a]; ok || list.isBanned(a) {
+		t.Fatalf("unban was not persisted")
+	}
+
+	// expired bans are lifted and removed from the store
+	if err := list.ban(c, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("failed to ban: %v", err)
+	}
+	if len(list.list()) != 1 {
+		t.Fatalf("expired ban is listed")
+	}
+	if list.isBanned(c) {
+		t.Fatalf("expired ban is enforced")
+	}
+	if _, ok := store[c]; ok {
+		t.Fatalf("expired ban was not removed from the store")
+	}
+}
+
+func TestBanListWithoutStore(t *testing.T) {
+	id := discover.NodeID{1}
+	list := newBanList(nil)
+	if err := list.ban(id, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to ban: %v", err)
+	}
+	if !list.isBanned(id) {
+		t.Fatalf("ban is not enforced")
+	}
+	if err := list.unban(id); err != nil || list.isBanned(id) {
+		t.Fatalf("failed to unban: %v", err)
+	}
+}
+
+func TestServerBansWhenStopped(t *testing.T) {
+	srv := &Server{}
+	if err := srv.BanPeer(discover.NodeID{1}, time.Hour); err != errServerStopped {
+		t.Fatalf("expected %v, got %v", errServerStopped, err)
+	}
+	if err := srv.UnbanPeer(discover.NodeID{1}); err != errServerStopped {
+		t.Fatalf("expected %v, got %v", errServerStopped, err)
+	}
+	if peers := srv.BannedPeers(); len(peers) != 0 {
+		t.Fatalf("unexpected banned peers: %v", peers)
+	}
+}
@@ -61,6 +61,7 @@ type nodeDB struct {
 var (
 	nodeDBVersionKey = []byte("version") // Version of the database to flush if changes
 	nodeDBItemPrefix = []byte("n:")      // Header to prefix node entries with
+	nodeDBBanPrefix  = []byte("b:")      // Header to prefix ban entries with, kept apart from the expiring node entries
 
 	nodeDBDiscoverRoot      = ":discover"
 	nodeDBDiscoverPing      = nodeDBDiscoverRoot + ":lastping"
@@ -304,6 +305,51 @@ func (db *nodeDB) updateFindFails(id NodeID, fails int) error {
 	return db.storeInt64(makeKey(id, nodeDBDiscoverFindFails), int64(fails))
 }
 
+// makeBanKey generates the leveldb key-blob of the ban entry of a node.
+func makeBanKey(id NodeID) []byte {
+	return append(append([]byte{}, nodeDBBanPrefix...), id[:]...)
+}
+
+// bannedUntil retrieves the time until which a node is banned, zero if the node
+// is not banned.
+func (db *nodeDB) bannedUntil(id NodeID) time.Time {
+	until := db.fetchInt64(makeBanKey(id))
+	if until == 0 {
+		return time.Time{}
+	}
+	return time.Unix(until, 0)
+}
+
+// updateBan bans a node until the given time instance.
+func (db *nodeDB) updateBan(id NodeID, until time.Time) error {
+	return db.storeInt64(makeBanKey(id), until.Unix())
+}
+
+// deleteBan lifts the ban of a node.
+func (db *nodeDB) deleteBan(id NodeID) error {
+	return db.lvl.Delete(makeBanKey(id), nil)
+}
+
+// bans retrieves all the banned nodes, dropping the bans which already expired.
+func (db *nodeDB) bans() map[NodeID]time.Time {
+	it := db.lvl.NewIterator(util.BytesPrefix(nodeDBBanPrefix), nil)
+	defer it.Release()
+
+	now := time.Now()
+	bans := make(map[NodeID]time.Time)
+	for it.Next() {
+		var id NodeID
+		copy(id[:], it.Key()[len(nodeDBBanPrefix):])
+		until := db.bannedUntil(id)
+		if !until.After(now) {
+			db.deleteBan(id)
+			continue
+		}
+		bans[id] = until
+	}
+	return bans
+}
+
 // querySeeds retrieves a batch of nodes to be used as potential seed servers
 // during bootstrapping the node into the network.
 //
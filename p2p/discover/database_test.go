@@ -0,0 +1,54 @@
+package discover
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNodeDBBans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nodes")
+	banned, expired := NodeID{1}, NodeID{2}
+
+	db, err := newNodeDB(path, 1, NodeID{})
+	if err != nil {
+		t.Fatalf("failed to open node database: %v", err)
+	}
+	until := time.Now().Add(time.Hour)
+	if err := db.updateBan(banned, until); err != nil {
+		t.Fatalf("failed to ban node: %v", err)
+	}
+	if err := db.updateBan(expired, time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("failed to ban node: %v", err)
+	}
+	db.close()
+
+	// the bans are persisted, the expired ones are dropped
+	db, err = newNodeDB(path, 1, NodeID{})
+	if err != nil {
+		t.Fatalf("failed to reopen node database: %v", err)
+	}
+	defer db.close()
+	bans := db.bans()
+	if len(bans) != 1 || bans[banned].Unix() != until.Unix() {
+		t.Fatalf("unexpected bans after reopen: %v", bans)
+	}
+	if !db.bannedUntil(expired).IsZero() {
+		t.Fatalf("expired ban was not deleted")
+	}
+
+	// bans don't interfere with the node entries
+	if err := db.deleteNode(banned); err != nil {
+		t.Fatalf("failed to delete node: %v", err)
+	}
+	if len(db.bans()) != 1 {
+		t.Fatalf("deleting the node removed its ban")
+	}
+
+	if err := db.deleteBan(banned); err != nil {
+		t.Fatalf("failed to unban node: %v", err)
+	}
+	if bans := db.bans(); len(bans) != 0 {
+		t.Fatalf("unexpected bans after unban: %v", bans)
+	}
+}
@@ -95,7 +95,7 @@ func newTable(t transport, ourID NodeID, ourAddr *net.UDPAddr, nodeDBPath string
 	// If no node database was given, use an in-memory one
 	db, err := newNodeDB(nodeDBPath, Version, ourID)
 	if err != nil {
-		common.P2PLogger.Warn(fmt.Sprintf("Failed to open node database:", err))
+		common.P2PLogger.Warn("Failed to open node database", "reason", err)
 		db, _ = newNodeDB("", Version, ourID)
 	}
 	tab := &Table{
@@ -168,6 +168,22 @@ func randUint(max uint32) uint32 {
 	return binary.BigEndian.Uint32(b[:]) % max
 }
 
+// Ban persists the ban of a node in the node database until the given time.
+func (tab *Table) Ban(id NodeID, until time.Time) error {
+	return tab.db.updateBan(id, until)
+}
+
+// Unban removes the ban of a node from the node database.
+func (tab *Table) Unban(id NodeID) error {
+	return tab.db.deleteBan(id)
+}
+
+// Bans returns the nodes banned in the node database, with the time until
+// which they are banned.
+func (tab *Table) Bans() map[NodeID]time.Time {
+	return tab.db.bans()
+}
+
 // Close terminates the network listener and flushes the node database.
 func (tab *Table) Close() {
 	tab.net.close()
@@ -20,17 +20,31 @@ package p2p
 
 import (
 	"net"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var (
-	ingressConnectMeter = metrics.NewMeter()
-	ingressTrafficMeter = metrics.NewMeter()
-	egressConnectMeter  = metrics.NewMeter()
-	egressTrafficMeter  = metrics.NewMeter()
+	ingressConnectMeter metrics.Meter = metrics.NilMeter{}
+	ingressTrafficMeter metrics.Meter = metrics.NilMeter{}
+	egressConnectMeter  metrics.Meter = metrics.NilMeter{}
+	egressTrafficMeter  metrics.Meter = metrics.NilMeter{}
+
+	registerMetrics sync.Once
 )
 
+// RegisterMetrics creates the connection and traffic meters, connections are not metered before.
+// The node calls it once metrics.Enabled is set, before starting the server.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		ingressConnectMeter = metrics.GetOrRegisterMeter("p2p/ingress/connects", nil)
+		ingressTrafficMeter = metrics.GetOrRegisterMeter("p2p/ingress/traffic", nil)
+		egressConnectMeter = metrics.GetOrRegisterMeter("p2p/egress/connects", nil)
+		egressTrafficMeter = metrics.GetOrRegisterMeter("p2p/egress/traffic", nil)
+	})
+}
+
 // meteredConn is a wrapper around a network TCP connection that meters both the
 // inbound and outbound network traffic.
 type meteredConn struct {
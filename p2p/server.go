@@ -130,6 +130,7 @@ type Server struct {
 	running bool
 
 	ntab         discoverTable
+	bans         *banList
 	listener     net.Listener
 	ourHandshake *protoHandshake
 	lastLookup   time.Time
@@ -337,6 +338,9 @@ func (srv *Server) Start() (err error) {
 		}
 		srv.ntab = ntab
 	}
+	// bans are persisted in the node database of the discovery table, if any
+	store, _ := srv.ntab.(banStore)
+	srv.bans = newBanList(store)
 
 	dynPeers := srv.MinConnectedPeers
 	if !srv.Discovery {
@@ -559,6 +563,8 @@ func (srv *Server) encHandshakeChecks(peers map[discover.NodeID]*Peer, c *conn)
 		return DiscAlreadyConnected
 	case c.id == srv.Self().ID:
 		return DiscSelf
+	case srv.bans.isBanned(c.id):
+		return DiscUselessPeer
 	default:
 		return nil
 	}
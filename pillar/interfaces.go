@@ -1,6 +1,8 @@
 package pillar
 
 import (
+	"time"
+
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
@@ -22,4 +24,8 @@ type Manager interface {
 
 	SetCoinBase(coinbase *wallet.KeyPair)
 	GetCoinBase() *types.Address
+
+	// SetLease enables the hot-standby mode, in which only the node holding lease produces momentums.
+	// Must be called before Start.
+	SetLease(lease Lease, duration time.Duration)
 }
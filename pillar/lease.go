@@ -0,0 +1,150 @@
+package pillar
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/tsdb/fileutil"
+
+	"github.com/zenon-network/go-zenon/common"
+)
+
+const (
+	leaseLockTimeout = time.Second           // Maximum time to wait for the other nodes to release the lease file
+	leaseLockRetry   = 10 * time.Millisecond // Time between two attempts to lock the lease file
+)
+
+// Lease coordinates the nodes configured with the same producer, so only one of them produces momentums.
+type Lease interface {
+	// Acquire acquires or renews the lease and returns the time until which the node holds it.
+	// The zero time means that another node holds the lease.
+	Acquire() (time.Time, error)
+	// Release gives up the lease if the node holds it, so a standby node can take over right away.
+	Release() error
+	// Claim records that the node is about to sign a momentum at height. It fails if the node doesn't hold
+	// the lease for at least margin or if a momentum at height was already signed by any of the nodes.
+	Claim(height uint64, margin time.Duration) error
+}
+
+// leaseRecord is the content of the lease file
+type leaseRecord struct {
+	Owner      string `json:"owner"`
+	Expires    int64  `json:"expires"`    // unix milliseconds
+	LastHeight uint64 `json:"lastHeight"` // height of the last momentum signed by any owner
+}
+
+// fileLease is a Lease stored in a file shared by the nodes, for example on the same host or on a shared volume.
+// Every access to the file is guarded by an exclusive lock on a sibling lock file.
+type fileLease struct {
+	path     string
+	owner    string
+	duration time.Duration
+}
+
+// NewFileLease creates a lease stored in the file at path, which is held for duration after every renewal.
+func NewFileLease(path string, duration time.Duration) (Lease, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return &fileLease{
+		path:     path,
+		owner:    fmt.Sprintf("%v-%v-%v", host, os.Getpid(), hex.EncodeToString(id)),
+		duration: duration,
+	}, nil
+}
+
+func (l *fileLease) Acquire() (time.Time, error) {
+	var until time.Time
+	err := l.update(func(record *leaseRecord, now time.Time) (bool, error) {
+		if record.Owner != l.owner && record.Expires > now.UnixMilli() {
+			return false, nil
+		}
+		until = now.Add(l.duration)
+		record.Owner = l.owner
+		record.Expires = until.UnixMilli()
+		return true, nil
+	})
+	return until, err
+}
+func (l *fileLease) Release() error {
+	return l.update(func(record *leaseRecord, now time.Time) (bool, error) {
+		if record.Owner != l.owner {
+			return false, nil
+		}
+		record.Expires = 0
+		return true, nil
+	})
+}
+func (l *fileLease) Claim(height uint64, margin time.Duration) error {
+	return l.update(func(record *leaseRecord, now time.Time) (bool, error) {
+		if record.Owner != l.owner || record.Expires <= now.Add(margin).UnixMilli() {
+			return false, ErrLeaseNotHeld
+		}
+		if height <= record.LastHeight {
+			return false, ErrHeightAlreadySigned
+		}
+		record.LastHeight = height
+		return true, nil
+	})
+}
+
+// update applies change to the lease record while holding the lock and writes the record if change returns true
+func (l *fileLease) update(change func(record *leaseRecord, now time.Time) (bool, error)) error {
+	lock, err := l.lock()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	record := new(leaseRecord)
+	data, err := ioutil.ReadFile(l.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) != 0 {
+		if err := json.Unmarshal(data, record); err != nil {
+			return errors.Errorf("malformed lease file %v. Reason: %v", l.path, err)
+		}
+	}
+
+	changed, err := change(record, common.Clock.Now())
+	if err != nil || !changed {
+		return err
+	}
+
+	// write to a temporary file and rename it, so a crash never leaves a partially written record
+	data, err = json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path)
+}
+
+func (l *fileLease) lock() (fileutil.Releaser, error) {
+	deadline := time.Now().Add(leaseLockTimeout)
+	for {
+		lock, _, err := fileutil.Flock(l.path + ".lock")
+		if err == nil {
+			return lock, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Errorf("unable to lock lease file %v. Reason: %v", l.path, err)
+		}
+		time.Sleep(leaseLockRetry)
+	}
+}
@@ -0,0 +1,257 @@
+package pillar
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/protocol"
+	"github.com/zenon-network/go-zenon/wallet"
+)
+
+const testLeaseDuration = 30 * time.Second
+
+type testClock struct {
+	lock sync.Mutex
+	now  time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+func (c *testClock) advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// useTestClock replaces common.Clock until the test finishes
+func useTestClock(t *testing.T) *testClock {
+	previous := common.Clock
+	clock := &testClock{now: time.Unix(1000000000, 0)}
+	common.Clock = clock
+	t.Cleanup(func() { common.Clock = previous })
+	return clock
+}
+
+func newTestLeases(t *testing.T, count int) []Lease {
+	path := filepath.Join(t.TempDir(), "producer.lease")
+	leases := make([]Lease, count)
+	for i := range leases {
+		lease, err := NewFileLease(path, testLeaseDuration)
+		common.FailIfErr(t, err)
+		leases[i] = lease
+	}
+	return leases
+}
+
+func TestFileLease_Competing(t *testing.T) {
+	useTestClock(t)
+	leases := newTestLeases(t, 2)
+
+	until, err := leases[0].Acquire()
+	common.FailIfErr(t, err)
+	if until.IsZero() {
+		t.Fatalf("first lease not acquired")
+	}
+	until, err = leases[1].Acquire()
+	common.FailIfErr(t, err)
+	if !until.IsZero() {
+		t.Fatalf("second lease acquired while the first one is held")
+	}
+
+	// the owner renews, the other node keeps waiting
+	until, err = leases[0].Acquire()
+	common.FailIfErr(t, err)
+	if until.IsZero() {
+		t.Fatalf("lease not renewed by its owner")
+	}
+	if err := leases[1].Claim(1, 0); err != ErrLeaseNotHeld {
+		t.Fatalf("claim without the lease: have %v, want %v", err, ErrLeaseNotHeld)
+	}
+	common.FailIfErr(t, leases[0].Claim(1, 0))
+
+	// releasing lets the other node take over right away
+	common.FailIfErr(t, leases[0].Release())
+	until, err = leases[1].Acquire()
+	common.FailIfErr(t, err)
+	if until.IsZero() {
+		t.Fatalf("released lease not taken over")
+	}
+}
+
+func TestFileLease_Concurrent(t *testing.T) {
+	leases := newTestLeases(t, 8)
+
+	var wg sync.WaitGroup
+	acquired := make([]bool, len(leases))
+	for i := range leases {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			until, err := leases[i].Acquire()
+			if err != nil {
+				t.Errorf("failed to acquire lease: %v", err)
+			}
+			acquired[i] = !until.IsZero()
+		}(i)
+	}
+	wg.Wait()
+
+	holders := 0
+	for _, ok := range acquired {
+		if ok {
+			holders += 1
+		}
+	}
+	if holders != 1 {
+		t.Fatalf("lease held by %v nodes, want 1", holders)
+	}
+}
+
+func TestFileLease_ExpiredTakeover(t *testing.T) {
+	clock := useTestClock(t)
+	leases := newTestLeases(t, 2)
+
+	_, err := leases[0].Acquire()
+	common.FailIfErr(t, err)
+
+	// the owner stops renewing, the lease is free once it expires
+	clock.advance(testLeaseDuration - time.Second)
+	until, err := leases[1].Acquire()
+	common.FailIfErr(t, err)
+	if !until.IsZero() {
+		t.Fatalf("lease taken over before expiring")
+	}
+	clock.advance(time.Second)
+	until, err = leases[1].Acquire()
+	common.FailIfErr(t, err)
+	if until.IsZero() {
+		t.Fatalf("expired lease not taken over")
+	}
+
+	// the previous owner lost the lease
+	until, err = leases[0].Acquire()
+	common.FailIfErr(t, err)
+	if !until.IsZero() {
+		t.Fatalf("previous owner acquired the lease back")
+	}
+	if err := leases[0].Claim(1, 0); err != ErrLeaseNotHeld {
+		t.Fatalf("claim of the previous owner: have %v, want %v", err, ErrLeaseNotHeld)
+	}
+}
+
+func TestFileLease_Claim(t *testing.T) {
+	clock := useTestClock(t)
+	leases := newTestLeases(t, 2)
+
+	_, err := leases[0].Acquire()
+	common.FailIfErr(t, err)
+	common.FailIfErr(t, leases[0].Claim(5, 0))
+
+	tests := []struct {
+		height uint64
+		margin time.Duration
+		err    error
+	}{
+		{5, 0, ErrHeightAlreadySigned},
+		{4, 0, ErrHeightAlreadySigned},
+		{6, testLeaseDuration, ErrLeaseNotHeld},
+		{6, testLeaseDuration / 3, nil},
+		{6, 0, ErrHeightAlreadySigned},
+	}
+	for _, tt := range tests {
+		if err := leases[0].Claim(tt.height, tt.margin); err != tt.err {
+			t.Fatalf("claim height %v with margin %v: have %v, want %v", tt.height, tt.margin, err, tt.err)
+		}
+	}
+
+	// the last signed height is shared with the node which takes over
+	clock.advance(testLeaseDuration)
+	_, err = leases[1].Acquire()
+	common.FailIfErr(t, err)
+	if err := leases[1].Claim(6, 0); err != ErrHeightAlreadySigned {
+		t.Fatalf("claim of a signed height after takeover: have %v, want %v", err, ErrHeightAlreadySigned)
+	}
+	common.FailIfErr(t, leases[1].Claim(7, 0))
+}
+
+func TestStandby(t *testing.T) {
+	clock := useTestClock(t)
+	leases := newTestLeases(t, 2)
+	active := newStandby(leases[0], testLeaseDuration)
+	passive := newStandby(leases[1], testLeaseDuration)
+
+	active.renew()
+	passive.renew()
+	if !active.isActive() {
+		t.Fatalf("standby holding the lease is not active")
+	}
+	if passive.isActive() {
+		t.Fatalf("standby without the lease is active")
+	}
+	if err := passive.claim(1); err != ErrLeaseNotHeld {
+		t.Fatalf("claim of the passive standby: have %v, want %v", err, ErrLeaseNotHeld)
+	}
+	common.FailIfErr(t, active.claim(1))
+
+	// without renewals a node stops producing before its lease expires, before another node can take over
+	clock.advance(testLeaseDuration - testLeaseDuration/3)
+	if active.isActive() {
+		t.Fatalf("standby still active within the safety margin")
+	}
+	passive.renew()
+	if passive.isActive() {
+		t.Fatalf("standby took over a lease which didn't expire")
+	}
+	clock.advance(testLeaseDuration / 3)
+	passive.renew()
+	if !passive.isActive() {
+		t.Fatalf("standby didn't take over the expired lease")
+	}
+	if err := passive.claim(1); err != ErrHeightAlreadySigned {
+		t.Fatalf("claim of a signed height after takeover: have %v, want %v", err, ErrHeightAlreadySigned)
+	}
+}
+
+type testBroadcaster struct{}
+
+func (testBroadcaster) SyncInfo() *protocol.SyncInfo {
+	return &protocol.SyncInfo{State: protocol.SyncDone}
+}
+func (testBroadcaster) CreateMomentum(*nom.MomentumTransaction)         {}
+func (testBroadcaster) CreateAccountBlock(*nom.AccountBlockTransaction) {}
+func (testBroadcaster) BroadcastAccountBlock(*nom.AccountBlock)         {}
+
+func TestManager_ShouldProcessStandby(t *testing.T) {
+	clock := useTestClock(t)
+	leases := newTestLeases(t, 2)
+	address := types.ParseAddressPanic("z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz")
+	newManager := func(lease Lease) *manager {
+		return &manager{
+			coinbase:    &wallet.KeyPair{Address: address},
+			standby:     newStandby(lease, testLeaseDuration),
+			broadcaster: testBroadcaster{},
+		}
+	}
+	active, passive := newManager(leases[0]), newManager(leases[1])
+	active.standby.renew()
+	passive.standby.renew()
+
+	event := consensus.ProducerEvent{
+		StartTime: clock.Now(),
+		EndTime:   clock.Now().Add(10 * time.Second),
+		Producer:  address,
+	}
+	common.FailIfErr(t, active.shouldProcess(event))
+	if err := passive.shouldProcess(event); err != ErrLeaseNotHeld {
+		t.Fatalf("standby node processes the event: have %v, want %v", err, ErrLeaseNotHeld)
+	}
+}
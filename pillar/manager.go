@@ -19,7 +19,8 @@ type manager struct {
 	log      log15.Logger
 	coinbase *wallet.KeyPair
 
-	worker *worker
+	worker  *worker
+	standby *standby
 
 	consensus   consensus.Consensus
 	broadcaster protocol.Broadcaster
@@ -49,6 +50,9 @@ func (m *manager) Start() error {
 	m.log.Info("starting ...")
 	defer m.log.Info("started")
 
+	if m.standby != nil {
+		m.standby.start()
+	}
 	m.consensus.Register(m)
 	if err := m.worker.Start(); err != nil {
 		m.log.Error("failed to produce contracts", "reason", err)
@@ -64,6 +68,9 @@ func (m *manager) Stop() error {
 	if err := m.worker.Stop(); err != nil {
 		return err
 	}
+	if m.standby != nil {
+		m.standby.stop()
+	}
 
 	return nil
 }
@@ -83,6 +90,9 @@ func (m *manager) shouldProcess(e consensus.ProducerEvent) error {
 	if m.coinbase.Address != e.Producer {
 		return ErrNotOurEvent
 	}
+	if m.standby != nil && !m.standby.isActive() {
+		return ErrLeaseNotHeld
+	}
 	if common.Clock.Now().Before(e.StartTime) {
 		return ErrEventHasNotStarted
 	}
@@ -94,6 +104,9 @@ func (m *manager) shouldProcess(e consensus.ProducerEvent) error {
 func (m *manager) processSupervised(e consensus.ProducerEvent) {
 	if err := m.shouldProcess(e); err != nil {
 		m.log.Info("do not process current event", "event", e, "reason", err)
+		if m.coinbase != nil && m.coinbase.Address == e.Producer && (err == ErrSyncNotDone || err == ErrEventEnded) {
+			missedMomentumsCounter.Inc(1)
+		}
 		return
 	}
 
@@ -133,6 +146,10 @@ func (m *manager) SetCoinBase(coinbase *wallet.KeyPair) {
 	m.coinbase = coinbase
 	m.worker.coinbase = coinbase
 }
+func (m *manager) SetLease(lease Lease, duration time.Duration) {
+	m.standby = newStandby(lease, duration)
+	m.worker.claim = m.standby.claim
+}
 func (m *manager) GetCoinBase() *types.Address {
 	if m.coinbase == nil {
 		return nil
@@ -0,0 +1,22 @@
+package pillar
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	producedMomentumsCounter metrics.Counter = metrics.NilCounter{}
+	missedMomentumsCounter   metrics.Counter = metrics.NilCounter{}
+
+	registerMetrics sync.Once
+)
+
+// RegisterMetrics creates the counters of produced and missed momentums.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		producedMomentumsCounter = metrics.GetOrRegisterCounter("pillar/momentums/produced", nil)
+		missedMomentumsCounter = metrics.GetOrRegisterCounter("pillar/momentums/missed", nil)
+	})
+}
@@ -0,0 +1,109 @@
+package pillar
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/zenon-network/go-zenon/common"
+)
+
+// standby runs the hot-standby mode, in which only the node which holds the lease produces momentums.
+// The lease is renewed every third of its duration and a node stops producing a third of the duration
+// before its lease expires, so the lease can be lost for a renewal before another node takes over.
+type standby struct {
+	log    common.Logger
+	lease  Lease
+	margin time.Duration
+
+	lock   sync.RWMutex
+	until  time.Time
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newStandby(lease Lease, duration time.Duration) *standby {
+	return &standby{
+		log:    common.PillarLogger.New("submodule", "standby"),
+		lease:  lease,
+		margin: duration / 3,
+	}
+}
+
+func (s *standby) start() {
+	s.closed = make(chan struct{})
+	s.renew()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.margin)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.renew()
+			case <-s.closed:
+				return
+			}
+		}
+	}()
+}
+func (s *standby) stop() {
+	close(s.closed)
+	s.wg.Wait()
+
+	s.lock.Lock()
+	s.until = time.Time{}
+	s.lock.Unlock()
+	if err := s.lease.Release(); err != nil {
+		s.log.Error("failed to release producer lease", "reason", err)
+	}
+}
+
+// renew acquires or renews the lease. On failure the node keeps its current lease, which expires on its own.
+func (s *standby) renew() {
+	until, err := s.lease.Acquire()
+	if err != nil {
+		s.log.Error("failed to renew producer lease", "reason", err)
+		return
+	}
+
+	s.lock.Lock()
+	wasActive := s.isActiveLocked()
+	s.until = until
+	active := s.isActiveLocked()
+	s.lock.Unlock()
+
+	if active != wasActive {
+		s.log.Info("producer lease changed", "active", active, "until", until)
+	}
+	if active {
+		standbyActiveGauge().Update(1)
+	} else {
+		standbyActiveGauge().Update(0)
+	}
+}
+
+// isActive returns true if the node holds the lease for at least the safety margin
+func (s *standby) isActive() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.isActiveLocked()
+}
+func (s *standby) isActiveLocked() bool {
+	return common.Clock.Now().Add(s.margin).Before(s.until)
+}
+
+// claim records the height of a momentum about to be signed, see Lease.Claim
+func (s *standby) claim(height uint64) error {
+	if !s.isActive() {
+		return ErrLeaseNotHeld
+	}
+	return s.lease.Claim(height, s.margin)
+}
+
+func standbyActiveGauge() metrics.Gauge {
+	return metrics.GetOrRegisterGauge("pillar/standby/active", nil)
+}
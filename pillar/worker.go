@@ -25,6 +25,7 @@ type worker struct {
 
 	contracts []types.Address
 	coinbase  *wallet.KeyPair
+	claim     func(height uint64) error // guards against signing a height twice in hot-standby mode
 
 	// modules
 	chain       chain.Chain
@@ -97,20 +98,21 @@ func (w *worker) work(task common.TaskResolver, e consensus.ProducerEvent) {
 	momentum, err := w.generateMomentum(e)
 	if err != nil {
 		w.log.Error("failed to generate momentum", "reason", err)
+		missedMomentumsCounter.Inc(1)
 		return
 	}
 
-	if task.ShouldStop() {
-		return
-	}
-	if w.shouldStop() {
+	if task.ShouldStop() || w.shouldStop() {
+		missedMomentumsCounter.Inc(1)
 		return
 	}
 	if common.Clock.Now().After(e.StartTime.Add(3 * time.Second)) {
 		w.log.Error("do not broadcast own momentum", "identifier", momentum.Momentum.Identifier(), "reason", "too-late")
+		missedMomentumsCounter.Inc(1)
 	} else {
 		w.log.Info("broadcasting own momentum", "identifier", momentum.Momentum.Identifier())
 		w.broadcaster.CreateMomentum(momentum)
+		producedMomentumsCounter.Inc(1)
 	}
 
 	if task.ShouldStop() {
@@ -26,6 +26,13 @@ func (w *worker) generateMomentum(e consensus.ProducerEvent) (*nom.MomentumTrans
 		Version:         uint64(1),
 	}
 	m.EnsureCache()
+
+	// Make sure no other node configured with the same producer signs this height
+	if w.claim != nil {
+		if err := w.claim(m.Height); err != nil {
+			return nil, err
+		}
+	}
 	return w.supervisor.GenerateMomentum(&nom.DetailedMomentum{
 		Momentum:      m,
 		AccountBlocks: blocks,
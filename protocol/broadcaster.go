@@ -62,3 +62,7 @@ func (b *broadcaster) CreateAccountBlock(accountBlockTransaction *nom.AccountBlo
 
 	b.protocol.BroadcastAccountBlock(accountBlockTransaction.Block)
 }
+
+func (b *broadcaster) BroadcastAccountBlock(block *nom.AccountBlock) {
+	b.protocol.BroadcastAccountBlock(block)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
@@ -14,6 +15,9 @@ import (
 	"github.com/zenon-network/go-zenon/vm"
 )
 
+// errInvalidMomentum wraps the errors of InsertChain caused by momentums or account-blocks which fail verification
+var errInvalidMomentum = errors.New("invalid momentum")
+
 type chainBridge struct {
 	chain      chain.Chain
 	consensus  consensus.Consensus
@@ -59,8 +63,9 @@ func (c chainBridge) GetTransactions() []*nom.AccountBlock {
 }
 
 func (c chainBridge) HasBlock(hash types.Hash) bool {
-	m, _ := c.chain.GetFrontierMomentumStore().GetMomentumByHash(hash)
-	return m != nil
+	m, err := c.chain.GetFrontierMomentumStore().GetMomentumByHash(hash)
+	// pruned momentums are still part of the chain
+	return m != nil || err == store.ErrPruned
 }
 func (c chainBridge) GetBlockHashesFromHash(hash types.Hash, amount uint64) ([]types.Hash, error) {
 	momentums, err := c.chain.GetFrontierMomentumStore().GetMomentumsByHash(hash, false, amount)
@@ -110,6 +115,10 @@ func (c chainBridge) Status() (td uint64, currentBlock types.Hash, genesisBlock
 	return frontier.Height, frontier.Hash, c.chain.GetGenesisMomentum().Hash
 }
 
+func (c chainBridge) VerifyMomentumProducer(momentum *nom.Momentum) (bool, error) {
+	return c.consensus.VerifyMomentumProducer(momentum)
+}
+
 func (c chainBridge) InsertChain(momentums []*nom.DetailedMomentum) (int, error) {
 	a := momentums[0]
 	b := momentums[len(momentums)-1]
@@ -191,7 +200,7 @@ func (c chainBridge) InsertChain(momentums []*nom.DetailedMomentum) (int, error)
 			transaction, err := c.supervisor.ApplyBlock(block)
 			if err != nil {
 				log.Error("error while applying account-block", "reason", err, "account-block-header", block.Header())
-				return index + start, err
+				return index + start, invalidMomentumError(err)
 			}
 			if err := c.chain.ForceAddAccountBlockTransaction(insert, transaction); err != nil {
 				log.Error("error while inserting account-block in pool", "reason", err, "account-block-header", block.Header())
@@ -201,7 +210,7 @@ func (c chainBridge) InsertChain(momentums []*nom.DetailedMomentum) (int, error)
 
 		transaction, err := c.supervisor.ApplyMomentum(detailed)
 		if err != nil {
-			return index + start, err
+			return index + start, invalidMomentumError(err)
 		}
 		if err := c.chain.AddMomentumTransaction(insert, transaction); err != nil {
 			log.Error("error while inserting momentum", "reason", err, "momentum-identifier", detailed.Momentum.Identifier())
@@ -211,3 +220,12 @@ func (c chainBridge) InsertChain(momentums []*nom.DetailedMomentum) (int, error)
 
 	return 0, nil
 }
+
+// invalidMomentumError marks the verification failures with errInvalidMomentum, so the peers which sent the momentums
+// are penalized. Other errors are caused by the local node and are returned as they are.
+func invalidMomentumError(err error) error {
+	if errors.Is(err, verifier.ErrInvalid) {
+		return fmt.Errorf("%w - %v", errInvalidMomentum, err)
+	}
+	return err
+}
@@ -15,11 +15,16 @@
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
 // Package downloader contains the manual full chain synchronisation.
+//
+// Every momentum is replayed from genesis. A momentum only commits to the hash of its own changes (ChangesHash) and
+// not to the resulting state, so a state snapshot received from a peer can't be verified against a momentum.
+// Fast-sync from snapshots requires a state commitment in the momentum, which is a consensus change.
 package downloader
 
 import (
 	"errors"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,6 +38,7 @@ import (
 
 const (
 	eth61 = 61 // Constant to check for new protocol support
+	eth62 = 62 // Constant to check for range retrieval support
 )
 
 var (
@@ -42,14 +48,22 @@ var (
 	MaxHashFetch  = 512 // Amount of hashes to be fetched per retrieval request
 	MaxBlockFetch = 128 // Amount of blocks to be fetched per retrieval request
 
+	MinRangeFetch     = 16 // Minimum amount of blocks to be fetched per range request (eth/62)
+	MaxRangesInFlight = 4  // Amount of range requests pipelined to each peer (eth/62)
+
 	hashTTL         = 5 * time.Second  // Time it takes for a hash request to time out
 	blockSoftTTL    = 3 * time.Second  // Request completion threshold for increasing or decreasing a peer's bandwidth
 	blockHardTTL    = 3 * blockSoftTTL // Maximum time allowance before a block request is considered expired
 	crossCheckCycle = time.Second      // Period after which to check for expired cross checks
+	rangeTargetRTT  = 2 * time.Second  // Time in which a peer should deliver a range at its measured throughput
+
+	measurementImpact = 0.1 // Impact of a single range delivery on the measured throughput and latency of a peer
 
-	maxQueuedHashes = 256 * 1024 // Maximum number of hashes to queue for import (DOS protection)
-	maxBannedHashes = 4096       // Number of bannable hashes before phasing old ones out
-	maxBlockProcess = 256        // Number of blocks to import at once into the chain
+	maxQueuedHashes  = 256 * 1024 // Maximum number of hashes to queue for import (DOS protection)
+	maxBannedHashes  = 4096       // Number of bannable hashes before phasing old ones out
+	maxBlockProcess  = 256        // Number of blocks to import at once into the chain
+	maxRangeWindow   = 4096       // Maximum distance of the requested ranges from the imported height
+	rangeImportQueue = 8          // Number of delivered ranges waiting for the importer
 )
 
 var (
@@ -69,6 +83,9 @@ var (
 	errCancelHashFetch  = errors.New("hash fetching canceled (requested)")
 	errCancelBlockFetch = errors.New("block downloading canceled (requested)")
 	errNoSyncActive     = errors.New("no sync active")
+
+	// ErrDeliveryTimeout is reported for the peers which don't deliver the requested blocks in time
+	ErrDeliveryTimeout = errors.New("block delivery timeout")
 )
 
 // hashCheckFn is a callback type for verifying a hash's presence in the local chain.
@@ -86,11 +103,20 @@ type chainInsertFn func([]*nom.DetailedMomentum) (int, error)
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
 
+// peerReportFn is a callback type for reporting the misbehaviour of a peer.
+type peerReportFn func(id string, reason error)
+
 type blockPack struct {
 	peerId string
 	blocks []*nom.DetailedMomentum
 }
 
+type rangePack struct {
+	peerId    string
+	requestId uint64
+	blocks    []*nom.DetailedMomentum
+}
+
 type hashPack struct {
 	peerId string
 	hashes []types.Hash
@@ -107,7 +133,8 @@ type Downloader struct {
 	checks map[types.Hash]*crossCheck // Pending cross checks to verify a hash chain
 	banned *set.Set                   // Set of hashes we've received and banned
 
-	interrupt int32 // Atomic boolean to signal termination
+	interrupt int32  // Atomic boolean to signal termination
+	requestId uint64 // Atomic counter for the ids of the range requests
 
 	// Statistics
 	importStart time.Time // Instance when the last blocks were taken from the cache
@@ -115,12 +142,16 @@ type Downloader struct {
 	importDone  int       // Number of taken blocks already imported from the last batch
 	importLock  sync.Mutex
 
+	progress     Progress     // State of the range download
+	progressLock sync.RWMutex // Lock protecting the range download state
+
 	// Callbacks
 	hasBlock    hashCheckFn      // Checks if a block is present in the chain
 	getBlock    blockRetrievalFn // Retrieves a block from the chain
 	headBlock   headRetrievalFn  // Retrieves the head block from the chain
 	insertChain chainInsertFn    // Injects a batch of blocks into the chain
 	dropPeer    peerDropFn       // Drops a peer for misbehaving
+	reportPeer  peerReportFn     // Reports the misbehaviour of a peer
 
 	// Status
 	synchroniseMock func(id string, hash types.Hash) error // Replacement for synchronise during testing
@@ -132,6 +163,7 @@ type Downloader struct {
 	newPeerCh chan *peer
 	hashCh    chan hashPack  // Channel receiving inbound hashes
 	blockCh   chan blockPack // Channel receiving inbound blocks
+	rangeCh   chan rangePack // Channel receiving inbound block ranges
 	processCh chan bool      // Channel to signal the block fetcher of new or finished work
 
 	cancelCh   chan struct{} // Channel to cancel mid-flight syncs
@@ -147,7 +179,7 @@ type Block struct {
 }
 
 // New creates a new downloader to fetch hashes and blocks from remote peers.
-func New(hasBlock hashCheckFn, getBlock blockRetrievalFn, headBlock headRetrievalFn, insertChain chainInsertFn, dropPeer peerDropFn) *Downloader {
+func New(hasBlock hashCheckFn, getBlock blockRetrievalFn, headBlock headRetrievalFn, insertChain chainInsertFn, dropPeer peerDropFn, reportPeer peerReportFn) *Downloader {
 	// Create the base downloader
 	downloader := &Downloader{
 		queue:       newQueue(),
@@ -157,9 +189,11 @@ func New(hasBlock hashCheckFn, getBlock blockRetrievalFn, headBlock headRetrieva
 		headBlock:   headBlock,
 		insertChain: insertChain,
 		dropPeer:    dropPeer,
+		reportPeer:  reportPeer,
 		newPeerCh:   make(chan *peer, 1),
 		hashCh:      make(chan hashPack, 1),
 		blockCh:     make(chan blockPack, 1),
+		rangeCh:     make(chan rangePack, 1),
 		processCh:   make(chan bool, 1),
 	}
 	// Inject all the known bad hashes
@@ -198,7 +232,7 @@ func (d *Downloader) Synchronising() bool {
 
 // RegisterPeer injects a new download peer into the set of block source to be
 // used for fetching hashes and blocks from.
-func (d *Downloader) RegisterPeer(id string, version int, head types.Hash, getRelHashes relativeHashFetcherFn, getAbsHashes absoluteHashFetcherFn, getBlocks blockFetcherFn) error {
+func (d *Downloader) RegisterPeer(id string, version int, head types.Hash, getRelHashes relativeHashFetcherFn, getAbsHashes absoluteHashFetcherFn, getBlocks blockFetcherFn, getRange rangeFetcherFn) error {
 	// If the peer wants to send a banned hash, reject
 	if d.banned.Has(head) {
 		log.Debug("Register rejected, head hash banned:", id)
@@ -206,7 +240,7 @@ func (d *Downloader) RegisterPeer(id string, version int, head types.Hash, getRe
 	}
 	// Otherwise try to construct and register the peer
 	log.Debug("Registering peer", id)
-	if err := d.peers.Register(newPeer(id, version, head, getRelHashes, getAbsHashes, getBlocks)); err != nil {
+	if err := d.peers.Register(newPeer(id, version, head, getRelHashes, getAbsHashes, getBlocks, getRange)); err != nil {
 		log.Error("Register failed", "reason", err)
 		return err
 	}
@@ -311,6 +345,18 @@ func (d *Downloader) syncWithPeer(p *peer, hash types.Hash, td uint64) (err erro
 
 	log.Info("Synchronizing with the zenon network", "peer-id", p.id, "version", p.version)
 	switch p.version {
+	case eth62:
+		// eth/62, retrieve the blocks by height with range requests spread across all the peers
+		number, err := d.findAncestor(p)
+		if err != nil {
+			return err
+		}
+		if err := d.fetchRanges(p, td, number+1); err != nil {
+			return err
+		}
+		log.Info("Synchronization completed")
+		return nil
+
 	case eth61:
 		// New eth/61, use forward, concurrent hash and block retrieval algorithm
 		number, err := d.findAncestor(p)
@@ -374,6 +420,10 @@ func (d *Downloader) Terminate() {
 // In the rare scenario when we ended up on a long soft fork (i.e. none of the
 // head blocks match), we do a binary search to find the common ancestor.
 func (d *Downloader) findAncestor(p *peer) (uint64, error) {
+	d.cancelLock.RLock()
+	cancel := d.cancelCh
+	d.cancelLock.RUnlock()
+
 	log.Info("looking for common ancestor", "peer", p)
 
 	// Request out head blocks to short circuit ancestor location
@@ -390,7 +440,7 @@ func (d *Downloader) findAncestor(p *peer) (uint64, error) {
 
 	for finished := false; !finished; {
 		select {
-		case <-d.cancelCh:
+		case <-cancel:
 			return 0, errCancelHashFetch
 
 		case hashPack := <-d.hashCh:
@@ -417,6 +467,9 @@ func (d *Downloader) findAncestor(p *peer) (uint64, error) {
 		case <-d.blockCh:
 			// Out of bounds blocks received, ignore them
 
+		case <-d.rangeCh:
+			// Out of bounds block ranges received, ignore them
+
 		case <-timeout:
 			log.Info("head hash timeout", "peer", p)
 			return 0, errTimeout
@@ -439,7 +492,7 @@ func (d *Downloader) findAncestor(p *peer) (uint64, error) {
 		// Wait until a reply arrives to this request
 		for arrived := false; !arrived; {
 			select {
-			case <-d.cancelCh:
+			case <-cancel:
 				return 0, errCancelHashFetch
 
 			case hashPack := <-d.hashCh:
@@ -472,6 +525,9 @@ func (d *Downloader) findAncestor(p *peer) (uint64, error) {
 			case <-d.blockCh:
 				// Out of bounds blocks received, ignore them
 
+			case <-d.rangeCh:
+				// Out of bounds block ranges received, ignore them
+
 			case <-timeout:
 				log.Info("search hash timeout", "peer", p)
 				return 0, errTimeout
@@ -484,6 +540,10 @@ func (d *Downloader) findAncestor(p *peer) (uint64, error) {
 // fetchHashes keeps retrieving hashes from the requested number, until no more
 // are returned, potentially throttling on the way.
 func (d *Downloader) fetchHashes(p *peer, td uint64, from uint64) error {
+	d.cancelLock.RLock()
+	cancel := d.cancelCh
+	d.cancelLock.RUnlock()
+
 	log.Info("%downloading hashes from", "peer", p, "from-height", from)
 
 	// Create a timeout timer, and the associated hash fetcher
@@ -503,7 +563,7 @@ func (d *Downloader) fetchHashes(p *peer, td uint64, from uint64) error {
 
 	for {
 		select {
-		case <-d.cancelCh:
+		case <-cancel:
 			return errCancelHashFetch
 
 		case hashPack := <-d.hashCh:
@@ -520,7 +580,7 @@ func (d *Downloader) fetchHashes(p *peer, td uint64, from uint64) error {
 
 				select {
 				case d.processCh <- false:
-				case <-d.cancelCh:
+				case <-cancel:
 				}
 				return nil
 			}
@@ -557,6 +617,10 @@ func (d *Downloader) fetchHashes(p *peer, td uint64, from uint64) error {
 // peers, reserving a chunk of blocks for each, waiting for delivery and also
 // periodically checking for timeouts.
 func (d *Downloader) fetchBlocks(from uint64) error {
+	d.cancelLock.RLock()
+	cancel := d.cancelCh
+	d.cancelLock.RUnlock()
+
 	log.Info("Downloading momentums", "from-height", from)
 	defer log.Info("Block download terminated", "")
 
@@ -572,7 +636,7 @@ func (d *Downloader) fetchBlocks(from uint64) error {
 
 	for {
 		select {
-		case <-d.cancelCh:
+		case <-cancel:
 			return errCancelBlockFetch
 
 		case blockPack := <-d.blockCh:
@@ -665,6 +729,7 @@ func (d *Downloader) fetchBlocks(from uint64) error {
 				if peer := d.peers.Peer(pid); peer != nil {
 					peer.Demote()
 					log.Debug("Block delivery timeout", "peer", peer)
+					d.reportPeer(pid, ErrDeliveryTimeout)
 				}
 			}
 			// If there's noting more to fetch, wait or terminate
@@ -704,6 +769,254 @@ func (d *Downloader) fetchBlocks(from uint64) error {
 	}
 }
 
+// rangeRequest is a range of blocks requested from a peer and not delivered yet.
+type rangeRequest struct {
+	peer    *peer
+	from    uint64
+	count   int
+	started time.Time
+}
+
+// rangeTask is a range of blocks which needs to be requested again, because
+// its request stalled or was only partially delivered.
+type rangeTask struct {
+	from  uint64
+	count int
+}
+
+// rangeResult is a delivered range of blocks waiting to be imported.
+type rangeResult struct {
+	peerId string
+	blocks []*nom.DetailedMomentum
+}
+
+// importResult is the outcome of the import of a delivered range.
+type importResult struct {
+	result *rangeResult
+	index  int
+	err    error
+}
+
+// Progress is the state of the range download of the current synchronisation.
+type Progress struct {
+	Peers      int     // Number of peers the ranges are downloaded from
+	Downloaded uint64  // Height up to which the blocks are downloaded without gaps
+	Throughput float64 // Combined throughput of the peers in blocks per second
+}
+
+// Progress retrieves the state of the range download, the zero value if no
+// range download is active.
+func (d *Downloader) Progress() Progress {
+	d.progressLock.RLock()
+	defer d.progressLock.RUnlock()
+
+	return d.progress
+}
+
+func (d *Downloader) setProgress(progress Progress) {
+	d.progressLock.Lock()
+	defer d.progressLock.Unlock()
+
+	d.progress = progress
+}
+
+// fetchRanges retrieves the blocks from the requested number up to the height
+// advertised by the origin peer. The heights are split in ranges across all the
+// eth/62 peers, each peer keeping up to MaxRangesInFlight requests in flight and
+// getting ranges sized by its measured throughput. Stalled ranges are assigned
+// to other peers. The ranges are correlated to their requests by id, so they can
+// arrive in any order, and are imported into the chain in order, in the
+// background.
+func (d *Downloader) fetchRanges(origin *peer, td uint64, from uint64) error {
+	d.cancelLock.RLock()
+	cancel := d.cancelCh
+	d.cancelLock.RUnlock()
+
+	log.Info("Downloading momentum ranges", "origin", origin, "from-height", from, "to-height", td)
+	defer log.Info("Momentum range download terminated", "")
+	defer d.setProgress(Progress{})
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var (
+		next     = from                           // Next height never requested
+		queued   = from                           // Next height to hand to the importer
+		imported = from                           // Next height to be imported
+		retries  []*rangeTask                     // Ranges to request again, ordered by height
+		pending  = make(map[uint64]*rangeRequest) // Requests in flight by id
+		inflight = make(map[string]int)           // Number of requests in flight by peer
+		results  = make(map[uint64]*rangeResult)  // Delivered ranges by starting height
+		importCh = make(chan *rangeResult, rangeImportQueue)
+		doneCh   = make(chan importResult, rangeImportQueue)
+		quit     = make(chan struct{})
+	)
+	defer close(quit)
+
+	// Import the delivered ranges in the background, so the download goes on
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for {
+			select {
+			case result := <-importCh:
+				index, err := d.insertChain(result.blocks)
+				select {
+				case doneCh <- importResult{result, index, err}:
+				case <-quit:
+					return
+				}
+				if err != nil {
+					return
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	// retry schedules a range to be requested again, keeping the lowest heights first
+	retry := func(from uint64, count int) {
+		task := &rangeTask{from: from, count: count}
+		index := sort.Search(len(retries), func(i int) bool { return retries[i].from > from })
+		retries = append(retries, nil)
+		copy(retries[index+1:], retries[index:])
+		retries[index] = task
+	}
+	// take selects the next range to request from the peer, nil if there's none
+	take := func(p *peer) *rangeTask {
+		for i, task := range retries {
+			if !p.Lacks(task.from) {
+				retries = append(retries[:i], retries[i+1:]...)
+				return task
+			}
+		}
+		if next > td || next >= queued+uint64(maxRangeWindow) || p.Lacks(next) {
+			return nil
+		}
+		task := &rangeTask{from: next, count: p.RangeCapacity()}
+		if remaining := td - next + 1; remaining < uint64(task.count) {
+			task.count = int(remaining)
+		}
+		next += uint64(task.count)
+		return task
+	}
+
+	for {
+		// Hand the delivered ranges which continue the chain to the importer
+		for result, ok := results[queued]; ok; result, ok = results[queued] {
+			select {
+			case importCh <- result:
+				delete(results, queued)
+				queued += uint64(len(result.blocks))
+				continue
+			default:
+			}
+			break
+		}
+		if imported > td {
+			return nil
+		}
+		// Short circuit if we lost all our peers
+		if d.peers.Len() == 0 {
+			return errNoPeers
+		}
+		// Fill the pipelines of the peers, fastest peers first
+		peers := d.peers.RangePeers()
+		for _, p := range peers {
+			for inflight[p.id] < MaxRangesInFlight {
+				task := take(p)
+				if task == nil {
+					break
+				}
+				id := atomic.AddUint64(&d.requestId, 1)
+				if err := p.getRange(id, task.from, task.count); err != nil {
+					log.Debug("range request failed, rescheduling", "peer", p, "reason", err)
+					retry(task.from, task.count)
+					break
+				}
+				pending[id] = &rangeRequest{peer: p, from: task.from, count: task.count, started: time.Now()}
+				inflight[p.id]++
+			}
+		}
+		// Make sure that some peer can deliver the missing ranges
+		if len(pending) == 0 && (len(retries) > 0 || (next <= td && next < queued+uint64(maxRangeWindow))) {
+			return errPeersUnavailable
+		}
+		throughput := 0.0
+		for _, p := range peers {
+			throughput += p.Throughput()
+		}
+		d.setProgress(Progress{Peers: len(inflight), Downloaded: queued - 1, Throughput: throughput})
+
+		select {
+		case <-cancel:
+			return errCancelBlockFetch
+
+		case pack := <-d.rangeCh:
+			req, ok := pending[pack.requestId]
+			if !ok || pack.peerId != req.peer.id {
+				// Unknown request or a stalled request which was assigned to another peer
+				log.Debug("Received unrequested momentum range", "peer-id", pack.peerId, "request-id", pack.requestId)
+				break
+			}
+			delete(pending, pack.requestId)
+			if inflight[req.peer.id]--; inflight[req.peer.id] == 0 {
+				delete(inflight, req.peer.id)
+			}
+
+			// Make sure the peer delivered what was requested
+			valid := len(pack.blocks) <= req.count
+			for i, block := range pack.blocks {
+				if block.Momentum.Height != req.from+uint64(i) {
+					valid = false
+					break
+				}
+			}
+			if !valid {
+				log.Info("invalid momentum range", "peer", req.peer, "from-height", req.from, "num-blocks", len(pack.blocks))
+				retry(req.from, req.count)
+				d.dropPeer(req.peer.id)
+				break
+			}
+			req.peer.UpdateRangeStats(len(pack.blocks), time.Since(req.started))
+			if len(pack.blocks) > 0 {
+				results[req.from] = &rangeResult{peerId: req.peer.id, blocks: pack.blocks}
+			}
+			// The peer doesn't have the rest of the range, let the other peers deliver it
+			if delivered := len(pack.blocks); delivered < req.count {
+				req.peer.SetLacks(req.from + uint64(delivered))
+				retry(req.from+uint64(delivered), req.count-delivered)
+			}
+
+		case done := <-doneCh:
+			if done.err != nil {
+				log.Info("Block import failed", "momentum-height", done.result.blocks[done.index].Momentum.Height, "reason", done.err)
+				d.reportPeer(done.result.peerId, done.err)
+				d.dropPeer(done.result.peerId)
+				return errCancelBlockFetch
+			}
+			imported += uint64(len(done.result.blocks))
+
+		case <-ticker.C:
+			// Assign the stalled ranges to other peers
+			for id, req := range pending {
+				if time.Since(req.started) <= req.peer.RangeTTL() {
+					continue
+				}
+				log.Info("momentum range request timed out", "peer", req.peer, "from-height", req.from)
+				delete(pending, id)
+				if inflight[req.peer.id]--; inflight[req.peer.id] == 0 {
+					delete(inflight, req.peer.id)
+				}
+				retry(req.from, req.count)
+				req.peer.Stall()
+				d.reportPeer(req.peer.id, ErrDeliveryTimeout)
+			}
+		}
+	}
+}
+
 // process takes blocks from the queue and tries to import them into the chain.
 //
 // The algorithmic flow is as follows:
@@ -773,6 +1086,7 @@ func (d *Downloader) process() {
 			index, err := d.insertChain(raw)
 			if err != nil {
 				log.Info("Block import failed", "momentum-height", raw[index].Momentum.Height, "reason", err)
+				d.reportPeer(blocks[index].OriginPeer, err)
 				d.dropPeer(blocks[index].OriginPeer)
 				d.cancel()
 				return
@@ -803,6 +1117,28 @@ func (d *Downloader) DeliverBlocks(id string, blocks []*nom.DetailedMomentum) er
 	}
 }
 
+// DeliverRange injects a range of blocks received from a remote node in response
+// to the range request with the given id. This is usually invoked through the
+// MomentumRangeMsg by the protocol handler.
+func (d *Downloader) DeliverRange(id string, requestId uint64, blocks []*nom.DetailedMomentum) error {
+	// Make sure the downloader is active
+	if atomic.LoadInt32(&d.synchronising) == 0 {
+		return errNoSyncActive
+	}
+	// Deliver or abort if the sync is canceled while queuing
+	d.cancelLock.RLock()
+	cancel := d.cancelCh
+	d.cancelLock.RUnlock()
+
+	select {
+	case d.rangeCh <- rangePack{id, requestId, blocks}:
+		return nil
+
+	case <-cancel:
+		return errNoSyncActive
+	}
+}
+
 // DeliverHashes injects a new batch of hashes received from a remote node into
 // the download schedule. This is usually invoked through the BlockHashesMsg by
 // the protocol handler.
@@ -0,0 +1,448 @@
+package downloader
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+var errTestImport = errors.New("test import failure")
+
+// downloadTester is a local chain, synchronising with remote peers which serve the momentums of remote
+type downloadTester struct {
+	d      *Downloader
+	remote []*nom.DetailedMomentum // Momentums of the remote chain, by height - 1
+
+	lock      sync.Mutex
+	local     []*nom.DetailedMomentum // Momentums of the local chain, by height - 1
+	failAt    uint64                  // Height at which the import fails, zero if none
+	dropped   map[string]bool
+	reported  map[string][]error
+	insertion chan struct{} // Closed to let the imports go on, nil if the imports don't wait
+}
+
+func newDownloadTester(height uint64) *downloadTester {
+	remote := make([]*nom.DetailedMomentum, height)
+	for i := range remote {
+		momentum := &nom.Momentum{
+			Hash:   types.NewHash(common.Uint64ToBytes(uint64(i + 1))),
+			Height: uint64(i + 1),
+		}
+		if i > 0 {
+			momentum.PreviousHash = remote[i-1].Momentum.Hash
+		}
+		remote[i] = &nom.DetailedMomentum{Momentum: momentum}
+	}
+	tester := &downloadTester{
+		remote:   remote,
+		local:    remote[:1],
+		dropped:  make(map[string]bool),
+		reported: make(map[string][]error),
+	}
+	tester.d = New(tester.hasBlock, tester.getBlock, tester.headBlock, tester.insertChain, tester.dropPeer, tester.reportPeer)
+	return tester
+}
+
+func (dt *downloadTester) hasBlock(hash types.Hash) bool {
+	return dt.getBlock(hash) != nil
+}
+func (dt *downloadTester) getBlock(hash types.Hash) *nom.DetailedMomentum {
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+	for _, detailed := range dt.local {
+		if detailed.Momentum.Hash == hash {
+			return detailed
+		}
+	}
+	return nil
+}
+func (dt *downloadTester) headBlock() *nom.Momentum {
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+	return dt.local[len(dt.local)-1].Momentum
+}
+func (dt *downloadTester) insertChain(blocks []*nom.DetailedMomentum) (int, error) {
+	dt.lock.Lock()
+	insertion := dt.insertion
+	dt.lock.Unlock()
+	if insertion != nil {
+		<-insertion
+	}
+
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+	for i, block := range blocks {
+		if block.Momentum.Height == dt.failAt {
+			return i, errTestImport
+		}
+		if block.Momentum.Height != uint64(len(dt.local))+1 {
+			return i, fmt.Errorf("non contiguous import, have height %v, want %v", block.Momentum.Height, len(dt.local)+1)
+		}
+		dt.local = append(dt.local, block)
+	}
+	return len(blocks), nil
+}
+func (dt *downloadTester) dropPeer(id string) {
+	dt.lock.Lock()
+	dt.dropped[id] = true
+	dt.lock.Unlock()
+	dt.d.UnregisterPeer(id)
+}
+func (dt *downloadTester) reportPeer(id string, reason error) {
+	dt.lock.Lock()
+	defer dt.lock.Unlock()
+	dt.reported[id] = append(dt.reported[id], reason)
+}
+
+// sync downloads the remote chain with range requests, the same as synchronise after finding the common ancestor
+func (dt *downloadTester) sync(origin string) error {
+	d := dt.d
+	atomic.StoreInt32(&d.synchronising, 1)
+	defer atomic.StoreInt32(&d.synchronising, 0)
+	d.peers.Reset()
+	d.cancelLock.Lock()
+	d.cancelCh = make(chan struct{})
+	d.cancelLock.Unlock()
+	// release the deliveries of the requests which are still in flight
+	defer d.cancel()
+
+	return d.fetchRanges(d.peers.Peer(origin), uint64(len(dt.remote)), dt.headBlock().Height+1)
+}
+
+func (dt *downloadTester) localHeight() uint64 {
+	return dt.headBlock().Height
+}
+
+// testPeer serves range requests with the momentums of the remote chain
+type testPeer struct {
+	id     string
+	tester *downloadTester
+
+	delay   time.Duration // Time it takes to deliver a range
+	fail    bool          // Range requests fail to be sent
+	silent  bool          // Range requests are never answered
+	corrupt bool          // Ranges are delivered with the wrong heights
+	has     uint64        // Highest height the peer has, zero for all of them
+
+	requests int32  // Number of requests received
+	highest  uint64 // Highest height requested
+}
+
+func (dt *downloadTester) newPeer(t *testing.T, id string, configure func(p *testPeer)) *testPeer {
+	p := &testPeer{id: id, tester: dt}
+	if configure != nil {
+		configure(p)
+	}
+	head := dt.remote[len(dt.remote)-1].Momentum.Hash
+	if err := dt.d.RegisterPeer(id, eth62, head, nil, nil, nil, p.getRange); err != nil {
+		t.Fatalf("failed to register peer %v: %v", id, err)
+	}
+	return p
+}
+
+func (p *testPeer) getRange(requestId uint64, from uint64, count int) error {
+	if p.fail {
+		return errors.New("peer disconnected")
+	}
+	atomic.AddInt32(&p.requests, 1)
+	for {
+		highest := atomic.LoadUint64(&p.highest)
+		last := from + uint64(count) - 1
+		if last <= highest || atomic.CompareAndSwapUint64(&p.highest, highest, last) {
+			break
+		}
+	}
+	if p.silent {
+		return nil
+	}
+
+	blocks := make([]*nom.DetailedMomentum, 0, count)
+	for height := from; height < from+uint64(count) && height <= uint64(len(p.tester.remote)); height++ {
+		if p.has != 0 && height > p.has {
+			break
+		}
+		blocks = append(blocks, p.tester.remote[height-1])
+	}
+	if p.corrupt && len(blocks) > 1 {
+		blocks = blocks[1:]
+	}
+	go func() {
+		time.Sleep(p.delay)
+		p.tester.d.DeliverRange(p.id, requestId, blocks)
+	}()
+	return nil
+}
+
+// shortRangeTTL lowers the time after which range requests are assigned to other peers
+func shortRangeTTL(t *testing.T) {
+	soft, hard := blockSoftTTL, blockHardTTL
+	blockSoftTTL, blockHardTTL = 200*time.Millisecond, 500*time.Millisecond
+	t.Cleanup(func() { blockSoftTTL, blockHardTTL = soft, hard })
+}
+
+func TestFetchRanges_SinglePeer(t *testing.T) {
+	tester := newDownloadTester(1000)
+	tester.newPeer(t, "peer", nil)
+
+	if err := tester.sync("peer"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 1000 {
+		t.Fatalf("local height mismatch: have %v, want 1000", height)
+	}
+	if progress := tester.d.Progress(); progress != (Progress{}) {
+		t.Fatalf("progress not reset after the download: %+v", progress)
+	}
+}
+
+func TestFetchRanges_MultiplePeers(t *testing.T) {
+	tester := newDownloadTester(2000)
+	peers := []*testPeer{
+		tester.newPeer(t, "fast", nil),
+		tester.newPeer(t, "medium", func(p *testPeer) { p.delay = 5 * time.Millisecond }),
+		tester.newPeer(t, "slow", func(p *testPeer) { p.delay = 20 * time.Millisecond }),
+	}
+
+	if err := tester.sync("fast"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 2000 {
+		t.Fatalf("local height mismatch: have %v, want 2000", height)
+	}
+	for _, p := range peers {
+		if atomic.LoadInt32(&p.requests) == 0 {
+			t.Errorf("no range requested from %v", p.id)
+		}
+	}
+}
+
+func TestFetchRanges_StallingPeer(t *testing.T) {
+	shortRangeTTL(t)
+	tester := newDownloadTester(500)
+	tester.newPeer(t, "good", nil)
+	silent := tester.newPeer(t, "silent", func(p *testPeer) { p.silent = true })
+
+	if err := tester.sync("good"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 500 {
+		t.Fatalf("local height mismatch: have %v, want 500", height)
+	}
+	if atomic.LoadInt32(&silent.requests) == 0 {
+		t.Fatalf("no range requested from the silent peer")
+	}
+	reported := tester.reported["silent"]
+	if len(reported) == 0 || reported[0] != ErrDeliveryTimeout {
+		t.Fatalf("silent peer reports mismatch: have %v, want %v", reported, ErrDeliveryTimeout)
+	}
+	if len(tester.reported["good"]) != 0 {
+		t.Fatalf("good peer reported: %v", tester.reported["good"])
+	}
+}
+
+func TestFetchRanges_FailingPeer(t *testing.T) {
+	tester := newDownloadTester(500)
+	tester.newPeer(t, "good", nil)
+	tester.newPeer(t, "failing", func(p *testPeer) { p.fail = true })
+
+	if err := tester.sync("good"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 500 {
+		t.Fatalf("local height mismatch: have %v, want 500", height)
+	}
+}
+
+func TestFetchRanges_PartialPeer(t *testing.T) {
+	tester := newDownloadTester(1000)
+	partial := tester.newPeer(t, "partial", func(p *testPeer) { p.has = 300 })
+	tester.newPeer(t, "full", func(p *testPeer) { p.delay = 5 * time.Millisecond })
+
+	if err := tester.sync("full"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 1000 {
+		t.Fatalf("local height mismatch: have %v, want 1000", height)
+	}
+	if tester.dropped["partial"] {
+		t.Fatalf("peer dropped for a partial range")
+	}
+	// the peer isn't asked again for the heights it doesn't have
+	p := tester.d.peers.Peer("partial")
+	if !p.Lacks(400) {
+		t.Fatalf("peer not marked as lacking the heights it doesn't have")
+	}
+	if highest := atomic.LoadUint64(&partial.highest); highest > 300+uint64(MaxBlockFetch)*uint64(MaxRangesInFlight) {
+		t.Fatalf("peer asked for heights far beyond the ones it has: %v", highest)
+	}
+	// the next synchronisation asks the peer for every height again
+	tester.d.peers.Reset()
+	if p.Lacks(400) {
+		t.Fatalf("lacking heights not reset")
+	}
+}
+
+func TestFetchRanges_CorruptPeer(t *testing.T) {
+	tester := newDownloadTester(500)
+	tester.newPeer(t, "good", func(p *testPeer) { p.delay = 5 * time.Millisecond })
+	tester.newPeer(t, "corrupt", func(p *testPeer) { p.corrupt = true })
+
+	if err := tester.sync("good"); err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 500 {
+		t.Fatalf("local height mismatch: have %v, want 500", height)
+	}
+	if !tester.dropped["corrupt"] {
+		t.Fatalf("corrupt peer not dropped")
+	}
+	if tester.dropped["good"] {
+		t.Fatalf("good peer dropped")
+	}
+}
+
+func TestFetchRanges_NoUsablePeers(t *testing.T) {
+	tester := newDownloadTester(500)
+	tester.newPeer(t, "failing", func(p *testPeer) { p.fail = true })
+
+	if err := tester.sync("failing"); err != errPeersUnavailable {
+		t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errPeersUnavailable)
+	}
+}
+
+func TestFetchRanges_Cancel(t *testing.T) {
+	tester := newDownloadTester(500)
+	silent := tester.newPeer(t, "silent", func(p *testPeer) { p.silent = true })
+
+	errc := make(chan error, 1)
+	go func() { errc <- tester.sync("silent") }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&silent.requests) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no range requested from the silent peer")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	tester.d.cancel()
+
+	select {
+	case err := <-errc:
+		if err != errCancelBlockFetch {
+			t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errCancelBlockFetch)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("download not canceled")
+	}
+}
+
+func TestFetchRanges_ImportFailure(t *testing.T) {
+	tester := newDownloadTester(500)
+	tester.failAt = 250
+	tester.newPeer(t, "peer", nil)
+
+	if err := tester.sync("peer"); err != errCancelBlockFetch {
+		t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errCancelBlockFetch)
+	}
+	if height := tester.localHeight(); height >= 250 {
+		t.Fatalf("imported past the failing momentum: %v", height)
+	}
+	if !tester.dropped["peer"] {
+		t.Fatalf("peer which delivered the failing momentum not dropped")
+	}
+	if reported := tester.reported["peer"]; len(reported) != 1 || reported[0] != errTestImport {
+		t.Fatalf("peer reports mismatch: have %v, want %v", reported, errTestImport)
+	}
+}
+
+func TestFetchRanges_BackgroundImport(t *testing.T) {
+	tester := newDownloadTester(1000)
+	tester.insertion = make(chan struct{})
+	p := tester.newPeer(t, "peer", nil)
+
+	errc := make(chan error, 1)
+	go func() { errc <- tester.sync("peer") }()
+
+	// the whole chain is requested while the imports are blocked
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadUint64(&p.highest) < 1000 {
+		if time.Now().After(deadline) {
+			t.Fatalf("download blocked by the import, highest requested height %v", atomic.LoadUint64(&p.highest))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if progress := tester.d.Progress(); progress.Peers == 0 && progress.Downloaded == 0 {
+		t.Fatalf("progress not reported while downloading: %+v", progress)
+	}
+	tester.lock.Lock()
+	close(tester.insertion)
+	tester.lock.Unlock()
+
+	if err := <-errc; err != nil {
+		t.Fatalf("failed to synchronise: %v", err)
+	}
+	if height := tester.localHeight(); height != 1000 {
+		t.Fatalf("local height mismatch: have %v, want 1000", height)
+	}
+}
+
+func TestPeer_RangeStats(t *testing.T) {
+	p := newPeer("peer", eth62, types.Hash{}, nil, nil, nil, nil)
+	if capacity := p.RangeCapacity(); capacity != MinRangeFetch {
+		t.Fatalf("initial capacity mismatch: have %v, want %v", capacity, MinRangeFetch)
+	}
+	if ttl := p.RangeTTL(); ttl != blockHardTTL {
+		t.Fatalf("initial ttl mismatch: have %v, want %v", ttl, blockHardTTL)
+	}
+
+	tests := []struct {
+		delivered int
+		elapsed   time.Duration
+		capacity  int
+		ttl       time.Duration
+	}{
+		// the first measurement replaces the initial values
+		{20, time.Second, 40, blockSoftTTL},
+		// 0.9 * 20 + 0.1 * 1000 = 118 blocks per second
+		{100, 100 * time.Millisecond, MaxBlockFetch, blockSoftTTL},
+		// 0.9 * 118 + 0.1 * 0 = 106.2 blocks per second, latency 0.9 * 955ms + 0.1 * 30s = 3.86s
+		{0, 30 * time.Second, MaxBlockFetch, blockHardTTL},
+	}
+	for i, tt := range tests {
+		p.UpdateRangeStats(tt.delivered, tt.elapsed)
+		if capacity := p.RangeCapacity(); capacity != tt.capacity {
+			t.Fatalf("measurement %v: capacity mismatch: have %v, want %v", i, capacity, tt.capacity)
+		}
+		if ttl := p.RangeTTL(); ttl != tt.ttl {
+			t.Fatalf("measurement %v: ttl mismatch: have %v, want %v", i, ttl, tt.ttl)
+		}
+	}
+
+	// stalling halves the throughput, down to the minimum capacity
+	throughput := p.Throughput()
+	p.Stall()
+	if p.Throughput() != throughput/2 {
+		t.Fatalf("stall throughput mismatch: have %v, want %v", p.Throughput(), throughput/2)
+	}
+	for i := 0; i < 10; i++ {
+		p.Stall()
+	}
+	if capacity := p.RangeCapacity(); capacity != MinRangeFetch {
+		t.Fatalf("stalled capacity mismatch: have %v, want %v", capacity, MinRangeFetch)
+	}
+
+	// the lowest lacking height is kept
+	p.SetLacks(500)
+	p.SetLacks(700)
+	p.SetLacks(300)
+	if p.Lacks(299) || !p.Lacks(300) || !p.Lacks(600) {
+		t.Fatalf("lacking heights mismatch")
+	}
+}
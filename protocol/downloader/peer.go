@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,6 +36,7 @@ import (
 type relativeHashFetcherFn func(types.Hash) error
 type absoluteHashFetcherFn func(uint64, int) error
 type blockFetcherFn func([]types.Hash) error
+type rangeFetcherFn func(uint64, uint64, int) error
 
 var (
 	errAlreadyFetching   = errors.New("already fetching blocks from peer")
@@ -58,13 +60,19 @@ type peer struct {
 	getRelHashes relativeHashFetcherFn // Method to retrieve a batch of hashes from an origin hash
 	getAbsHashes absoluteHashFetcherFn // Method to retrieve a batch of hashes from an absolute position
 	getBlocks    blockFetcherFn        // Method to retrieve a batch of blocks
+	getRange     rangeFetcherFn        // Method to retrieve a range of blocks by height (eth/62)
 
 	version int // Eth protocol version number to switch strategies
+
+	throughput float64       // Measured range delivery rate in blocks per second (eth/62)
+	latency    time.Duration // Measured range delivery latency (eth/62)
+	lacks      uint64        // Lowest height the peer didn't deliver, zero if none (eth/62)
+	statsLock  sync.RWMutex  // Lock protecting the range statistics
 }
 
 // newPeer create a new downloader peer, with specific hash and block retrieval
 // mechanisms.
-func newPeer(id string, version int, head types.Hash, getRelHashes relativeHashFetcherFn, getAbsHashes absoluteHashFetcherFn, getBlocks blockFetcherFn) *peer {
+func newPeer(id string, version int, head types.Hash, getRelHashes relativeHashFetcherFn, getAbsHashes absoluteHashFetcherFn, getBlocks blockFetcherFn, getRange rangeFetcherFn) *peer {
 	return &peer{
 		id:           id,
 		head:         head,
@@ -72,6 +80,7 @@ func newPeer(id string, version int, head types.Hash, getRelHashes relativeHashF
 		getRelHashes: getRelHashes,
 		getAbsHashes: getAbsHashes,
 		getBlocks:    getBlocks,
+		getRange:     getRange,
 		ignored:      set.New(),
 		version:      version,
 	}
@@ -82,6 +91,10 @@ func (p *peer) Reset() {
 	atomic.StoreInt32(&p.idle, 0)
 	atomic.StoreInt32(&p.capacity, 1)
 	p.ignored = set.New()
+
+	p.statsLock.Lock()
+	p.lacks = 0
+	p.statsLock.Unlock()
 }
 
 // Fetch sends a block retrieval request to the remote peer.
@@ -138,6 +151,94 @@ func (p *peer) Capacity() int {
 	return int(atomic.LoadInt32(&p.capacity))
 }
 
+// UpdateRangeStats updates the throughput and latency of the peer with a range
+// delivery of the given size, which took elapsed since the request.
+func (p *peer) UpdateRangeStats(delivered int, elapsed time.Duration) {
+	if elapsed <= 0 {
+		elapsed = time.Millisecond
+	}
+	measured := float64(delivered) / elapsed.Seconds()
+
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	if p.latency == 0 {
+		p.throughput, p.latency = measured, elapsed
+		return
+	}
+	p.throughput = (1-measurementImpact)*p.throughput + measurementImpact*measured
+	p.latency = time.Duration((1-measurementImpact)*float64(p.latency) + measurementImpact*float64(elapsed))
+}
+
+// Stall halves the throughput of the peer after a range request timed out.
+func (p *peer) Stall() {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	p.throughput /= 2
+}
+
+// Throughput retrieves the measured range delivery rate in blocks per second.
+func (p *peer) Throughput() float64 {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+
+	return p.throughput
+}
+
+// RangeCapacity retrieves the number of blocks to request from the peer, so the
+// range can be delivered in rangeTargetRTT at the measured throughput.
+func (p *peer) RangeCapacity() int {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+
+	if p.latency == 0 {
+		return MinRangeFetch
+	}
+	capacity := int(p.throughput * rangeTargetRTT.Seconds())
+	if capacity < MinRangeFetch {
+		return MinRangeFetch
+	}
+	if capacity > MaxBlockFetch {
+		return MaxBlockFetch
+	}
+	return capacity
+}
+
+// RangeTTL retrieves the time after which a range request to the peer is
+// considered stalled, based on the measured latency.
+func (p *peer) RangeTTL() time.Duration {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+
+	ttl := 3 * p.latency
+	if p.latency == 0 || ttl > blockHardTTL {
+		return blockHardTTL
+	}
+	if ttl < blockSoftTTL {
+		return blockSoftTTL
+	}
+	return ttl
+}
+
+// Lacks returns whether the peer is known not to have the block at height.
+func (p *peer) Lacks(height uint64) bool {
+	p.statsLock.RLock()
+	defer p.statsLock.RUnlock()
+
+	return p.lacks != 0 && height >= p.lacks
+}
+
+// SetLacks marks the blocks starting at height as not available at the peer.
+func (p *peer) SetLacks(height uint64) {
+	p.statsLock.Lock()
+	defer p.statsLock.Unlock()
+
+	if p.lacks == 0 || height < p.lacks {
+		p.lacks = height
+	}
+}
+
 // Promote increases the peer's reputation.
 func (p *peer) Promote() {
 	atomic.AddInt32(&p.rep, 1)
@@ -266,3 +367,21 @@ func (ps *peerSet) IdlePeers() []*peer {
 	}
 	return list
 }
+
+// RangePeers retrieves a flat list of the peers supporting range retrieval,
+// ordered by their measured throughput.
+func (ps *peerSet) RangePeers() []*peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		if p.version >= eth62 && p.getRange != nil {
+			list = append(list, p)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Throughput() > list[j].Throughput()
+	})
+	return list
+}
@@ -67,6 +67,9 @@ type chainInsertFn func([]*nom.DetailedMomentum) (int, error)
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
 
+// peerReportFn is a callback type for reporting the misbehaviour of a peer.
+type peerReportFn func(id string, reason error)
+
 // announce is the hash notification of the availability of a new block in the
 // network.
 type announce struct {
@@ -105,11 +108,12 @@ type Fetcher struct {
 
 	// Callbacks
 	getBlock       blockRetrievalFn   // Retrieves a block from the local chain
-	validateBlock  blockValidatorFn   // Checks if a block's headers have a valid proof of work
+	validateBlock  blockValidatorFn   // Checks if a block's header is valid, skipping the parent checks if the parent is nil
 	broadcastBlock blockBroadcasterFn // Broadcasts a block to connected peers
 	chainHeight    chainHeightFn      // Retrieves the current chain's height
 	insertChain    chainInsertFn      // Injects a batch of blocks into the chain
 	dropPeer       peerDropFn         // Drops a peer for misbehaving
+	reportPeer     peerReportFn       // Reports the misbehaviour of a peer
 
 	// Testing hooks
 	fetchingHook func([]types.Hash)  // Method to call upon starting a block fetch
@@ -119,7 +123,7 @@ type Fetcher struct {
 }
 
 // New creates a block fetcher to retrieve blocks based on hash announcements.
-func New(getBlock blockRetrievalFn, validateBlock blockValidatorFn, broadcastBlock blockBroadcasterFn, chainHeight chainHeightFn, insertChain chainInsertFn, dropPeer peerDropFn) *Fetcher {
+func New(getBlock blockRetrievalFn, validateBlock blockValidatorFn, broadcastBlock blockBroadcasterFn, chainHeight chainHeightFn, insertChain chainInsertFn, dropPeer peerDropFn, reportPeer peerReportFn) *Fetcher {
 	return &Fetcher{
 		notify:         make(chan *announce),
 		inject:         make(chan *inject),
@@ -138,6 +142,7 @@ func New(getBlock blockRetrievalFn, validateBlock blockValidatorFn, broadcastBlo
 		chainHeight:    chainHeight,
 		insertChain:    insertChain,
 		dropPeer:       dropPeer,
+		reportPeer:     reportPeer,
 	}
 }
 
@@ -393,6 +398,17 @@ func (f *Fetcher) enqueue(peer string, detailed *nom.DetailedMomentum) {
 		log.Info("Peer discarded block", "peer", peer, "momentum", block.Height, "hash", hash.Bytes()[:4], "distance", dist)
 		return
 	}
+	// Validate the header before queueing, against the parent if it's already known
+	var parent *nom.Momentum
+	if detailed := f.getBlock(block.PreviousHash); detailed != nil {
+		parent = detailed.Momentum
+	}
+	if err := f.validateBlock(block, parent); errors.Is(err, ErrInvalidHeader) {
+		log.Info("Peer propagated invalid momentum", "peer", peer, "momentum", block.Height, "hash", hash.Bytes()[:4], "reason", err)
+		f.reportPeer(peer, err)
+		f.dropPeer(peer)
+		return
+	}
 	// Schedule the block for future importing
 	if _, ok := f.queued[hash]; !ok {
 		op := &inject{
@@ -432,15 +448,19 @@ func (f *Fetcher) insert(peer string, detailed *nom.DetailedMomentum) {
 			}()
 
 		default:
-			// Something went very wrong, drop the peer
+			// Something went very wrong, drop the peer if the momentum is invalid
 			log.Info("momentum verification failed", "peer", peer, "momentum", momentum.Height, "hash", hash[:4], "reason", err)
-			f.dropPeer(peer)
+			if errors.Is(err, ErrInvalidHeader) {
+				f.reportPeer(peer, err)
+				f.dropPeer(peer)
+			}
 			return
 		}
 		// Run the actual import and log any issues
 		f.wg.Add(1)
 		if _, err := f.insertChain([]*nom.DetailedMomentum{detailed}); err != nil {
 			log.Warn("momentum import failed", "peer", peer, "momentum", momentum.Height, "hash", hash[:4], "reason", err)
+			f.reportPeer(peer, err)
 			f.wg.Done()
 			return
 		} else {
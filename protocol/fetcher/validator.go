@@ -0,0 +1,76 @@
+package fetcher
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/consensus"
+)
+
+const (
+	maxFutureDrift = 10 * time.Second // Maximum allowed distance of a momentum timestamp in the future
+)
+
+var (
+	// ErrInvalidHeader is wrapped by all the errors returned for momentums which are invalid regardless of the local
+	// state, so the peers which propagate them can be dropped.
+	ErrInvalidHeader = errors.New("invalid momentum header")
+
+	errChainIdentifier = fmt.Errorf("%w - chain-identifier mismatch", ErrInvalidHeader)
+	errParentLinkage   = fmt.Errorf("%w - previous doesn't match the parent momentum", ErrInvalidHeader)
+	errTimestampFuture = fmt.Errorf("%w - timestamp is too far in the future", ErrInvalidHeader)
+	errTimestampOrder  = fmt.Errorf("%w - timestamp is not after the parent timestamp", ErrInvalidHeader)
+	errTimestampSlot   = fmt.Errorf("%w - timestamp is not aligned to a producing slot", ErrInvalidHeader)
+	errProducer        = fmt.Errorf("%w - producer is not elected for the timestamp", ErrInvalidHeader)
+)
+
+// HeaderValidator checks propagated momentums before they are queued for import.
+// The checks don't require applying the momentum, so they are cheap enough to run for every announcement.
+type HeaderValidator struct {
+	chainIdentifier uint64
+	blockTime       time.Duration
+	verifier        consensus.Verifier
+}
+
+// NewHeaderValidator creates a validator for the momentums of the chain identified by chainIdentifier,
+// produced every blockTime by the producers elected by verifier.
+func NewHeaderValidator(chainIdentifier uint64, blockTime time.Duration, verifier consensus.Verifier) *HeaderValidator {
+	return &HeaderValidator{
+		chainIdentifier: chainIdentifier,
+		blockTime:       blockTime,
+		verifier:        verifier,
+	}
+}
+
+// Validate checks the header of block. The checks against the parent are skipped if parent is nil.
+// Errors which wrap ErrInvalidHeader mean that block is invalid, other errors mean that block couldn't be checked.
+func (v *HeaderValidator) Validate(block *nom.Momentum, parent *nom.Momentum) error {
+	if block.ChainIdentifier != v.chainIdentifier {
+		return errChainIdentifier
+	}
+	if time.Unix(int64(block.TimestampUnix), 0).After(time.Now().Add(maxFutureDrift)) {
+		return errTimestampFuture
+	}
+	if parent != nil {
+		if block.Previous() != parent.Identifier() {
+			return errParentLinkage
+		}
+		if block.TimestampUnix <= parent.TimestampUnix {
+			return errTimestampOrder
+		}
+		if v.blockTime > 0 && time.Duration(block.TimestampUnix-parent.TimestampUnix)*time.Second%v.blockTime != 0 {
+			return errTimestampSlot
+		}
+	}
+
+	ok, err := v.verifier.VerifyMomentumProducer(block)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errProducer
+	}
+	return nil
+}
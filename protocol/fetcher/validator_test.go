@@ -0,0 +1,168 @@
+package fetcher
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+const (
+	testChainIdentifier = 3
+	testBlockTime       = 10 * time.Second
+)
+
+var errTestVerifier = errors.New("consensus not available")
+
+// testVerifier elects the producers of the momentums with a timestamp in elected
+type testVerifier struct {
+	elected map[uint64]bool
+	err     error
+}
+
+func (v *testVerifier) VerifyMomentumProducer(momentum *nom.Momentum) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	return v.elected[momentum.TimestampUnix], nil
+}
+
+func testMomentum(height uint64, timestamp uint64, previous types.Hash) *nom.Momentum {
+	return &nom.Momentum{
+		ChainIdentifier: testChainIdentifier,
+		Hash:            types.NewHash(common.Uint64ToBytes(height)),
+		PreviousHash:    previous,
+		Height:          height,
+		TimestampUnix:   timestamp,
+	}
+}
+
+func TestHeaderValidator_Validate(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	parent := testMomentum(10, now-100, types.NewHash(common.Uint64ToBytes(9)))
+	next := func(modify func(m *nom.Momentum)) *nom.Momentum {
+		momentum := testMomentum(11, parent.TimestampUnix+10, parent.Hash)
+		if modify != nil {
+			modify(momentum)
+		}
+		return momentum
+	}
+	future := now + uint64(maxFutureDrift/time.Second) + 60
+
+	tests := []struct {
+		name     string
+		momentum *nom.Momentum
+		parent   *nom.Momentum
+		verifier *testVerifier
+		err      error
+	}{
+		{"valid", next(nil), parent, nil, nil},
+		{"valid after missed slots", next(func(m *nom.Momentum) { m.TimestampUnix = parent.TimestampUnix + 30 }), parent, nil, nil},
+		{"valid without parent", next(nil), nil, nil, nil},
+		{"other chain", next(func(m *nom.Momentum) { m.ChainIdentifier = 1 }), parent, nil, errChainIdentifier},
+		{"future timestamp", next(func(m *nom.Momentum) { m.TimestampUnix = future }), parent, &testVerifier{elected: map[uint64]bool{future: true}}, errTimestampFuture},
+		{"future timestamp without parent", next(func(m *nom.Momentum) { m.TimestampUnix = future }), nil, &testVerifier{elected: map[uint64]bool{future: true}}, errTimestampFuture},
+		{"other previous hash", next(func(m *nom.Momentum) { m.PreviousHash = types.NewHash([]byte("other")) }), parent, nil, errParentLinkage},
+		{"other previous height", next(func(m *nom.Momentum) { m.Height = 12 }), parent, nil, errParentLinkage},
+		{"same timestamp as parent", next(func(m *nom.Momentum) { m.TimestampUnix = parent.TimestampUnix }), parent, nil, errTimestampOrder},
+		{"timestamp before parent", next(func(m *nom.Momentum) { m.TimestampUnix = parent.TimestampUnix - 10 }), parent, nil, errTimestampOrder},
+		{"timestamp between slots", next(func(m *nom.Momentum) { m.TimestampUnix = parent.TimestampUnix + 15 }), parent, nil, errTimestampSlot},
+		{"producer not elected", next(nil), parent, &testVerifier{}, errProducer},
+		{"producer not elected without parent", next(nil), nil, &testVerifier{}, errProducer},
+		{"verifier failure", next(nil), parent, &testVerifier{err: errTestVerifier}, errTestVerifier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := tt.verifier
+			if verifier == nil {
+				verifier = &testVerifier{elected: map[uint64]bool{tt.momentum.TimestampUnix: true}}
+			}
+			validator := NewHeaderValidator(testChainIdentifier, testBlockTime, verifier)
+			err := validator.Validate(tt.momentum, tt.parent)
+			if err != tt.err {
+				t.Fatalf("have %v, want %v", err, tt.err)
+			}
+			// only invalid momentums get their peers dropped, not failures to check them
+			if invalid := tt.err != nil && tt.err != errTestVerifier; errors.Is(err, ErrInvalidHeader) != invalid {
+				t.Fatalf("error %v wraps ErrInvalidHeader: have %v, want %v", err, !invalid, invalid)
+			}
+		})
+	}
+}
+
+func TestHeaderValidator_NoBlockTime(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	parent := testMomentum(10, now-100, types.NewHash(common.Uint64ToBytes(9)))
+	momentum := testMomentum(11, parent.TimestampUnix+15, parent.Hash)
+	validator := NewHeaderValidator(testChainIdentifier, 0, &testVerifier{elected: map[uint64]bool{momentum.TimestampUnix: true}})
+	if err := validator.Validate(momentum, parent); err != nil {
+		t.Fatalf("slot checked without a block time: %v", err)
+	}
+}
+
+// Test that the fetcher drops the peers which propagate momentums of producers which are not elected
+func TestFetcher_DropsInvalidProducer(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	parent := testMomentum(10, now-100, types.NewHash(common.Uint64ToBytes(9)))
+	valid := testMomentum(11, parent.TimestampUnix+10, parent.Hash)
+	invalid := testMomentum(11, parent.TimestampUnix+20, parent.Hash)
+	invalid.Hash = types.NewHash([]byte("invalid"))
+	validator := NewHeaderValidator(testChainIdentifier, testBlockTime, &testVerifier{elected: map[uint64]bool{valid.TimestampUnix: true}})
+
+	var lock sync.Mutex
+	dropped := make(map[string]bool)
+	reported := make(map[string]error)
+	inserted := make(chan *nom.Momentum, 1)
+	fetcher := New(
+		func(hash types.Hash) *nom.DetailedMomentum {
+			if hash == parent.Hash {
+				return &nom.DetailedMomentum{Momentum: parent}
+			}
+			return nil
+		},
+		validator.Validate,
+		func(*nom.DetailedMomentum, bool) {},
+		func() uint64 { return parent.Height },
+		func(blocks []*nom.DetailedMomentum) (int, error) {
+			inserted <- blocks[0].Momentum
+			return len(blocks), nil
+		},
+		func(id string) {
+			lock.Lock()
+			defer lock.Unlock()
+			dropped[id] = true
+		},
+		func(id string, reason error) {
+			lock.Lock()
+			defer lock.Unlock()
+			reported[id] = reason
+		},
+	)
+	fetcher.Start()
+	defer fetcher.Stop()
+
+	common.FailIfErr(t, fetcher.Enqueue("bad", &nom.DetailedMomentum{Momentum: invalid}))
+	common.FailIfErr(t, fetcher.Enqueue("good", &nom.DetailedMomentum{Momentum: valid}))
+
+	select {
+	case momentum := <-inserted:
+		if momentum.Hash != valid.Hash {
+			t.Fatalf("inserted momentum mismatch: have %v, want %v", momentum.Hash, valid.Hash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("valid momentum not inserted")
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if !dropped["bad"] || reported["bad"] != errProducer {
+		t.Fatalf("peer of the invalid momentum not dropped: dropped %v, reported %v", dropped["bad"], reported["bad"])
+	}
+	if dropped["good"] || reported["good"] != nil {
+		t.Fatalf("peer of the valid momentum dropped: reported %v", reported["good"])
+	}
+}
@@ -29,10 +29,11 @@ import (
 	"github.com/zenon-network/go-zenon/p2p"
 	"github.com/zenon-network/go-zenon/protocol/downloader"
 	"github.com/zenon-network/go-zenon/protocol/fetcher"
+	"github.com/zenon-network/go-zenon/vm/constants"
 )
 
 func errResp(code errCode, format string, v ...interface{}) error {
-	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
+	return fmt.Errorf("%w - %v", code, fmt.Sprintf(format, v...))
 }
 
 type ProtocolManager struct {
@@ -45,6 +46,7 @@ type ProtocolManager struct {
 	downloader *downloader.Downloader
 	fetcher    *fetcher.Fetcher
 	peers      *peerSet
+	reputation *peerReputation
 
 	SubProtocols []p2p.Protocol
 
@@ -64,14 +66,15 @@ type ProtocolManager struct {
 func NewProtocolManager(minPeers int, networkId uint64, bridge ChainBridge) *ProtocolManager {
 	// Create the protocol manager with the base fields
 	manager := &ProtocolManager{
-		minPeers:  minPeers,
-		txpool:    bridge,
-		chainman:  bridge,
-		peers:     newPeerSet(),
-		newPeerCh: make(chan *peer, 1),
-		txsyncCh:  make(chan *txsync),
-		quitSync:  make(chan struct{}),
-		netId:     int(networkId),
+		minPeers:   minPeers,
+		txpool:     bridge,
+		chainman:   bridge,
+		peers:      newPeerSet(),
+		reputation: newPeerReputation(),
+		newPeerCh:  make(chan *peer, 1),
+		txsyncCh:   make(chan *txsync),
+		quitSync:   make(chan struct{}),
+		netId:      int(networkId),
 	}
 	// Initiate a sub-protocol for every implemented version we can handle
 	manager.SubProtocols = make([]p2p.Protocol, len(ProtocolVersions))
@@ -95,23 +98,25 @@ func NewProtocolManager(minPeers int, networkId uint64, bridge ChainBridge) *Pro
 		manager.chainman.GetBlock,
 		manager.chainman.CurrentBlock,
 		manager.chainman.InsertChain,
-		manager.removePeer)
+		manager.removePeer,
+		manager.reportPeer)
 
-	validator := func(block *nom.Momentum, parent *nom.Momentum) error {
-		//return core.ValidateHeader(pow, block.Headerr(), parent, true)
-		return nil
-	}
+	validator := fetcher.NewHeaderValidator(
+		networkId,
+		time.Duration(constants.ConsensusConfig.BlockTime)*time.Second,
+		manager.chainman)
 	heighter := func() uint64 {
 		momentum := manager.chainman.CurrentBlock()
 		return momentum.Height
 	}
 	manager.fetcher = fetcher.New(
 		manager.chainman.GetBlock,
-		validator,
+		validator.Validate,
 		manager.BroadcastMomentum,
 		heighter,
 		manager.chainman.InsertChain,
-		manager.removePeer)
+		manager.removePeer,
+		manager.reportPeer)
 
 	return manager
 }
@@ -135,6 +140,27 @@ func (pm *ProtocolManager) removePeer(id string) {
 	}
 }
 
+// reportPeer adds the misbehaviour of a connected peer to its reputation and removes the peer if it got banned
+func (pm *ProtocolManager) reportPeer(id string, reason error) {
+	peer := pm.peers.Peer(id)
+	if peer == nil {
+		return
+	}
+	if pm.reputation.report(peer.ID(), reason) {
+		pm.removePeer(id)
+	}
+}
+
+// SetPeerBanner sets the banner used for the peers which reach the ban score. Without a banner, peers are only disconnected.
+func (pm *ProtocolManager) SetPeerBanner(banner PeerBanner) {
+	pm.reputation.setBanner(banner)
+}
+
+// PeerScores returns the misbehaviour scores of the peers which misbehaved recently
+func (pm *ProtocolManager) PeerScores() []*PeerScore {
+	return pm.reputation.list()
+}
+
 func (pm *ProtocolManager) Start() {
 	// start sync handlers
 	pm.wg.Add(1)
@@ -177,6 +203,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	td, head, genesis := pm.chainman.Status()
 	if err := p.Handshake(td, head, genesis); err != nil {
 		log.Info("handshake failed", "peer", p, "name", p.Name())
+		pm.reputation.report(p.ID(), err)
 		return err
 	}
 	// Register the peer locally
@@ -187,9 +214,14 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	defer pm.removePeer(p.id)
 
 	// Register the peer in the downloader. If the downloader considers it banned, we disconnect
-	if err := pm.downloader.RegisterPeer(p.id, p.version, p.Head(), p.RequestHashes, p.RequestHashesFromNumber, p.RequestBlocks); err != nil {
+	if err := pm.downloader.RegisterPeer(p.id, p.version, p.Head(), p.RequestHashes, p.RequestHashesFromNumber, p.RequestBlocks, p.RequestMomentumRange); err != nil {
 		return err
 	}
+	// eth/62 peers receive the account-blocks in batches
+	if p.version >= eth62 {
+		go p.broadcast()
+		defer p.close()
+	}
 	// Propagate existing transactions. new transactions appearing
 	// after this will be sent via broadcasts.
 	pm.syncTransactions(p)
@@ -198,6 +230,7 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	for {
 		if err := pm.handleMsg(p); err != nil {
 			log.Info("message handling failed", "peer-id", p.id, "reason", err)
+			pm.reputation.report(p.ID(), err)
 			return err
 		}
 	}
@@ -224,12 +257,12 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 	defer msg.Discard()
 
 	// Handle the message depending on its contents
-	switch msg.Code {
-	case StatusMsg:
+	switch {
+	case msg.Code == StatusMsg:
 		// Status messages should never arrive after the handshake
 		return errResp(ErrExtraStatusMsg, "uncontrolled status message")
 
-	case GetBlockHashesMsg:
+	case msg.Code == GetBlockHashesMsg:
 		// Retrieve the number of hashes to return and from which origin hash
 		var request getBlockHashesData
 		if err := msg.Decode(&request); err != nil {
@@ -248,7 +281,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		}
 		return p.SendBlockHashes(hashes)
 
-	case GetBlockHashesFromNumberMsg:
+	case msg.Code == GetBlockHashesFromNumberMsg:
 		// Retrieve and decode the number of hashes to return and from which origin number
 		var request getBlockHashesFromNumberData
 		if err := msg.Decode(&request); err != nil {
@@ -281,7 +314,45 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		}
 		return p.SendBlockHashes(hashes)
 
-	case BlockHashesMsg:
+	case p.version >= eth62 && msg.Code == GetMomentumRangeMsg:
+		// Decode the range request and cap it to the fetch limit
+		var request getMomentumRangeData
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		if request.Count > uint64(downloader.MaxBlockFetch) {
+			request.Count = uint64(downloader.MaxBlockFetch)
+		}
+		// Gather the momentums until the range ends or a momentum is missing, for example past our frontier or
+		// pruned, and send the partial range so the peer requests the rest from other peers
+		momentums := make([]*nom.DetailedMomentum, 0, request.Count)
+		for height := request.From; height < request.From+request.Count; height++ {
+			momentum, err := pm.chainman.GetBlockByNumber(height)
+			if err != nil || momentum == nil {
+				break
+			}
+			detailed := pm.chainman.GetBlock(momentum.Hash)
+			if detailed == nil {
+				break
+			}
+			momentums = append(momentums, detailed)
+		}
+		return p.SendMomentumRange(request.RequestId, momentums)
+
+	case p.version >= eth62 && msg.Code == MomentumRangeMsg:
+		// A range of momentums arrived to one of our previous requests
+		var response momentumRangeData
+		if err := msg.Decode(&response); err != nil {
+			return errResp(ErrDecode, "%v: %v", msg, err)
+		}
+		for _, detailed := range response.Momentums {
+			detailed.Momentum.EnsureCache()
+		}
+		if err := pm.downloader.DeliverRange(p.id, response.RequestId, response.Momentums); err != nil {
+			log.Debug("failed to deliver momentum range", "reason", err)
+		}
+
+	case msg.Code == BlockHashesMsg:
 		// A batch of hashes arrived to one of our previous requests
 		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
 
@@ -296,7 +367,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			log.Debug("failed to deliver hashes", "reason", err)
 		}
 
-	case GetBlocksMsg:
+	case msg.Code == GetBlocksMsg:
 		// Decode the retrieval message
 		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
 		if _, err := msgStream.List(); err != nil {
@@ -337,7 +408,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		}
 		return p.SendBlocks(blocks)
 
-	case BlocksMsg:
+	case msg.Code == BlocksMsg:
 		// Decode the arrived block message
 		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
 
@@ -361,7 +432,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			}
 		}
 
-	case NewBlockHashesMsg:
+	case msg.Code == NewBlockHashesMsg:
 		// Retrieve and deseralize the remote new block hashes notification
 		msgStream := rlp.NewStream(msg.Payload, uint64(msg.Size))
 
@@ -386,7 +457,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			pm.fetcher.Notify(p.id, hash, time.Now(), p.RequestBlocks)
 		}
 
-	case NewBlockMsg:
+	case msg.Code == NewBlockMsg:
 		// Retrieve and decode the propagated block
 		var detailed *nom.DetailedMomentum
 		if err := msg.Decode(&detailed); err != nil {
@@ -411,7 +482,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			}
 		}
 
-	case TxMsg:
+	case msg.Code == TxMsg:
 		// Transactions arrived, parse all of them and deliver to the pool
 		var txs []*nom.AccountBlock
 		if err := msg.Decode(&txs); err != nil {
@@ -472,6 +543,10 @@ func (pm *ProtocolManager) BroadcastAccountBlock(tx *nom.AccountBlock) {
 	// Broadcast transaction to a batch of peers not knowing about it
 	peers := pm.peers.PeersWithoutTx(tx.Hash)
 	for _, p := range peers {
+		if p.version >= eth62 {
+			p.AsyncSendTransactions(tx)
+			continue
+		}
 		if err := p.SendTransactions([]*nom.AccountBlock{tx}); err != nil {
 			log.Debug("failed to propagated account-block", "peer-id", p.id, "reason", err)
 		}
@@ -482,3 +557,8 @@ func (pm *ProtocolManager) BroadcastAccountBlock(tx *nom.AccountBlock) {
 func (pm *ProtocolManager) SyncInfo() *SyncInfo {
 	return pm.syncInfo()
 }
+
+// Downloader returns the downloader used to synchronise with the network
+func (pm *ProtocolManager) Downloader() *downloader.Downloader {
+	return pm.downloader
+}
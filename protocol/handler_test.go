@@ -0,0 +1,306 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/p2p/discover"
+	"github.com/zenon-network/go-zenon/protocol/downloader"
+)
+
+// testChain is a ChainBridge serving momentums [1, frontier], where the
+// momentums below prunedBelow were pruned.
+type testChain struct {
+	frontier    uint64
+	prunedBelow uint64
+	momentums   map[types.Hash]*nom.DetailedMomentum
+}
+
+func newTestChain(frontier, prunedBelow uint64) *testChain {
+	chain := &testChain{
+		frontier:    frontier,
+		prunedBelow: prunedBelow,
+		momentums:   make(map[types.Hash]*nom.DetailedMomentum),
+	}
+	for height := uint64(1); height <= frontier; height++ {
+		momentum := testMomentum(height)
+		chain.momentums[momentum.Hash] = &nom.DetailedMomentum{Momentum: momentum}
+	}
+	return chain
+}
+
+func testMomentum(height uint64) *nom.Momentum {
+	return &nom.Momentum{
+		Hash:          types.NewHash(common.Uint64ToBytes(height)),
+		PreviousHash:  types.NewHash(common.Uint64ToBytes(height - 1)),
+		Height:        height,
+		TimestampUnix: 1000000000 + height*10,
+	}
+}
+
+func (c *testChain) AddAccountBlocks([]*nom.AccountBlock) error { return nil }
+func (c *testChain) GetTransactions() []*nom.AccountBlock       { return nil }
+func (c *testChain) HasBlock(hash types.Hash) bool {
+	_, ok := c.momentums[hash]
+	return ok
+}
+func (c *testChain) GetBlockHashesFromHash(types.Hash, uint64) ([]types.Hash, error) { return nil, nil }
+func (c *testChain) GetBlock(hash types.Hash) *nom.DetailedMomentum                  { return c.momentums[hash] }
+func (c *testChain) GetBlockByNumber(num uint64) (*nom.Momentum, error) {
+	if num < c.prunedBelow {
+		return nil, store.ErrPruned
+	}
+	if num == 0 || num > c.frontier {
+		return nil, nil
+	}
+	return testMomentum(num), nil
+}
+func (c *testChain) CurrentBlock() *nom.Momentum { return testMomentum(c.frontier) }
+func (c *testChain) Status() (uint64, types.Hash, types.Hash) {
+	return c.frontier, testMomentum(c.frontier).Hash, testMomentum(1).Hash
+}
+func (c *testChain) InsertChain([]*nom.DetailedMomentum) (int, error)   { return 0, nil }
+func (c *testChain) VerifyMomentumProducer(*nom.Momentum) (bool, error) { return true, nil }
+
+// newTestPeer creates a peer of the given version connected to the returned
+// message pipe end, which plays the remote side.
+func newTestPeer(pm *ProtocolManager, version int, seed byte) (*peer, *p2p.MsgPipeRW) {
+	var id discover.NodeID
+	id[0] = seed
+	local, remote := p2p.MsgPipe()
+	return pm.newPeer(version, pm.netId, p2p.NewPeer(id, "test", nil), local), remote
+}
+
+func momentumHeights(momentums []*nom.DetailedMomentum) []uint64 {
+	heights := make([]uint64, len(momentums))
+	for i, detailed := range momentums {
+		heights[i] = detailed.Momentum.Height
+	}
+	return heights
+}
+
+func TestGetMomentumRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		prunedBelow uint64
+		from, count uint64
+		want        []uint64
+	}{
+		{"full range", 0, 3, 4, []uint64{3, 4, 5, 6}},
+		{"past frontier", 0, 8, 5, []uint64{8, 9, 10}},
+		{"beyond frontier", 0, 11, 5, []uint64{}},
+		{"pruned start", 5, 2, 5, []uint64{}},
+		{"pruned inside", 5, 5, 3, []uint64{5, 6, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := NewProtocolManager(1, 1, newTestChain(10, tt.prunedBelow))
+			p, remote := newTestPeer(pm, eth62, 1)
+			defer remote.Close()
+
+			errc := make(chan error, 1)
+			go func() { errc <- pm.handleMsg(p) }()
+			if err := p2p.Send(remote, GetMomentumRangeMsg, &getMomentumRangeData{RequestId: 7, From: tt.from, Count: tt.count}); err != nil {
+				t.Fatalf("failed to send request: %v", err)
+			}
+
+			msg, err := remote.ReadMsg()
+			if err != nil {
+				t.Fatalf("failed to read response: %v", err)
+			}
+			if msg.Code != MomentumRangeMsg {
+				t.Fatalf("response code mismatch: have %d, want %d", msg.Code, MomentumRangeMsg)
+			}
+			var response momentumRangeData
+			if err := msg.Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.RequestId != 7 {
+				t.Fatalf("request id mismatch: have %d, want 7", response.RequestId)
+			}
+			have := momentumHeights(response.Momentums)
+			if len(have) != len(tt.want) {
+				t.Fatalf("range mismatch: have %v, want %v", have, tt.want)
+			}
+			for i := range have {
+				if have[i] != tt.want[i] {
+					t.Fatalf("range mismatch: have %v, want %v", have, tt.want)
+				}
+			}
+			if err := <-errc; err != nil {
+				t.Fatalf("request failed, the peer would be dropped: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetMomentumRange_CappedToMaxBlockFetch(t *testing.T) {
+	frontier := uint64(2 * downloader.MaxBlockFetch)
+	pm := NewProtocolManager(1, 1, newTestChain(frontier, 0))
+	p, remote := newTestPeer(pm, eth62, 1)
+	defer remote.Close()
+
+	go pm.handleMsg(p)
+	if err := p2p.Send(remote, GetMomentumRangeMsg, &getMomentumRangeData{RequestId: 1, From: 1, Count: frontier}); err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	msg, err := remote.ReadMsg()
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	var response momentumRangeData
+	if err := msg.Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Momentums) != downloader.MaxBlockFetch {
+		t.Fatalf("range size mismatch: have %d, want %d", len(response.Momentums), downloader.MaxBlockFetch)
+	}
+}
+
+func TestGetMomentumRange_RequiresEth62(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth61, 1)
+	defer remote.Close()
+
+	errc := make(chan error, 1)
+	go func() { errc <- pm.handleMsg(p) }()
+	if err := p2p.Send(remote, GetMomentumRangeMsg, &getMomentumRangeData{RequestId: 1, From: 1, Count: 5}); err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	if err := <-errc; err == nil {
+		t.Fatalf("eth/61 peer range request accepted")
+	}
+}
+
+func TestMomentumRange_UnrequestedDelivery(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth62, 1)
+	defer remote.Close()
+
+	errc := make(chan error, 1)
+	go func() { errc <- pm.handleMsg(p) }()
+	response := &momentumRangeData{
+		RequestId: 3,
+		Momentums: []*nom.DetailedMomentum{{Momentum: testMomentum(2)}},
+	}
+	if err := p2p.Send(remote, MomentumRangeMsg, response); err != nil {
+		t.Fatalf("failed to send response: %v", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("unrequested range dropped the peer: %v", err)
+	}
+}
+
+func testAccountBlock(seed uint64) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Height: seed,
+		Hash:   types.NewHash(common.Uint64ToBytes(seed)),
+	}
+}
+
+func readTxBatch(t *testing.T, remote *p2p.MsgPipeRW) []*nom.AccountBlock {
+	msg, err := remote.ReadMsg()
+	if err != nil {
+		t.Fatalf("failed to read account-blocks: %v", err)
+	}
+	if msg.Code != TxMsg {
+		t.Fatalf("message code mismatch: have %d, want %d", msg.Code, TxMsg)
+	}
+	var txs []*nom.AccountBlock
+	if err := msg.Decode(&txs); err != nil {
+		t.Fatalf("failed to decode account-blocks: %v", err)
+	}
+	return txs
+}
+
+func TestBroadcastAccountBlock_Batched(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth62, 1)
+	defer remote.Close()
+	if err := pm.peers.Register(p); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+	go p.broadcast()
+	defer p.close()
+
+	start := time.Now()
+	for i := uint64(1); i <= 3; i++ {
+		pm.BroadcastAccountBlock(testAccountBlock(i))
+	}
+	txs := readTxBatch(t, remote)
+	if len(txs) != 3 {
+		t.Fatalf("batch size mismatch: have %d, want 3", len(txs))
+	}
+	if elapsed := time.Since(start); elapsed < txBatchInterval/2 {
+		t.Fatalf("batch sent before the batch interval: %v", elapsed)
+	}
+	// Account-blocks known by the peer are not queued again
+	pm.BroadcastAccountBlock(testAccountBlock(1))
+	if len(p.queuedTxs) != 0 {
+		t.Fatalf("known account-block queued again")
+	}
+}
+
+func TestBroadcastAccountBlock_FullBatch(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth62, 1)
+	defer remote.Close()
+	if err := pm.peers.Register(p); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+	// Queue more than a batch before the broadcaster runs, the first batch
+	// must be sent full without waiting for the batch interval
+	for i := uint64(1); i <= maxTxBatch+1; i++ {
+		pm.BroadcastAccountBlock(testAccountBlock(i))
+	}
+	go p.broadcast()
+	defer p.close()
+
+	if txs := readTxBatch(t, remote); len(txs) != maxTxBatch {
+		t.Fatalf("first batch size mismatch: have %d, want %d", len(txs), maxTxBatch)
+	}
+	if txs := readTxBatch(t, remote); len(txs) != 1 {
+		t.Fatalf("second batch size mismatch: have %d, want 1", len(txs))
+	}
+}
+
+func TestBroadcastAccountBlock_Eth61(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth61, 1)
+	defer remote.Close()
+	if err := pm.peers.Register(p); err != nil {
+		t.Fatalf("failed to register peer: %v", err)
+	}
+
+	// eth/61 peers have no broadcaster, every account-block is sent on its own
+	go pm.BroadcastAccountBlock(testAccountBlock(1))
+	if txs := readTxBatch(t, remote); len(txs) != 1 {
+		t.Fatalf("batch size mismatch: have %d, want 1", len(txs))
+	}
+	if len(p.queuedTxs) != 0 {
+		t.Fatalf("eth/61 account-block queued")
+	}
+}
+
+func TestBroadcaster_StopsOnClose(t *testing.T) {
+	pm := NewProtocolManager(1, 1, newTestChain(10, 0))
+	p, remote := newTestPeer(pm, eth62, 1)
+	defer remote.Close()
+
+	done := make(chan struct{})
+	go func() {
+		p.broadcast()
+		close(done)
+	}()
+	p.close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("broadcaster still running after close")
+	}
+}
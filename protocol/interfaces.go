@@ -23,6 +23,11 @@ type SyncInfo struct {
 	State         SyncState `json:"state"`
 	CurrentHeight uint64    `json:"currentHeight"`
 	TargetHeight  uint64    `json:"targetHeight"`
+
+	// Progress of the range download, only set while syncing from eth/62 peers
+	DownloadedHeight uint64  `json:"downloadedHeight"`
+	DownloadPeers    int     `json:"downloadPeers"`
+	Throughput       float64 `json:"throughput"`
 }
 
 type txPool interface {
@@ -42,6 +47,8 @@ type chainManager interface {
 	Status() (td uint64, currentBlock types.Hash, genesisBlock types.Hash)
 
 	InsertChain(chain []*nom.DetailedMomentum) (int, error)
+	// VerifyMomentumProducer returns true if the producer of momentum is elected for its timestamp
+	VerifyMomentumProducer(momentum *nom.Momentum) (bool, error)
 }
 
 type ChainBridge interface {
@@ -53,4 +60,6 @@ type Broadcaster interface {
 	SyncInfo() *SyncInfo
 	CreateMomentum(*nom.MomentumTransaction)
 	CreateAccountBlock(*nom.AccountBlockTransaction)
+	// BroadcastAccountBlock broadcasts an account-block which is already inserted in the chain
+	BroadcastAccountBlock(*nom.AccountBlock)
 }
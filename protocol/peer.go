@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 
@@ -38,6 +39,10 @@ var (
 const (
 	maxKnownTxs    = 32768 // Maximum transactions hashes to keep in the known list (prevent DOS)
 	maxKnownBlocks = 1024  // Maximum block hashes to keep in the known list (prevent DOS)
+
+	maxQueuedTxs    = 4096                   // Maximum account-blocks waiting to be gossiped to an eth/62 peer
+	maxTxBatch      = 256                    // Maximum account-blocks gossiped to an eth/62 peer in one message
+	txBatchInterval = 100 * time.Millisecond // Time to wait for a batch of account-blocks to fill up before sending it
 )
 
 type peer struct {
@@ -56,6 +61,9 @@ type peer struct {
 
 	knownTxs    *lru.Cache // Set of transaction hashes known to be known by this peer
 	knownBlocks *lru.Cache // Set of block hashes known to be known by this peer
+
+	queuedTxs chan *nom.AccountBlock // Queue of account-blocks to gossip in batches (eth/62)
+	term      chan struct{}          // Termination channel to stop the broadcaster
 }
 
 func newPeer(version, network int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
@@ -74,9 +82,56 @@ func newPeer(version, network int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
 		id:          fmt.Sprintf("%x", id[:8]),
 		knownTxs:    knownTxs,
 		knownBlocks: knownBlocks,
+		queuedTxs:   make(chan *nom.AccountBlock, maxQueuedTxs),
+		term:        make(chan struct{}),
 	}
 }
 
+// broadcast gossips the queued account-blocks in batches, either when a batch
+// is full or when txBatchInterval elapsed since the first queued account-block.
+// It is only started for eth/62 peers, older peers are sent every account-block
+// on its own.
+func (p *peer) broadcast() {
+	var (
+		batch []*nom.AccountBlock
+		flush <-chan time.Time
+	)
+	send := func() bool {
+		if err := p.SendTransactions(batch); err != nil {
+			log.Debug("failed to propagate account-blocks", "peer-id", p.id, "reason", err)
+			return false
+		}
+		batch, flush = nil, nil
+		return true
+	}
+	for {
+		select {
+		case tx := <-p.queuedTxs:
+			batch = append(batch, tx)
+			if len(batch) >= maxTxBatch {
+				if !send() {
+					return
+				}
+			} else if flush == nil {
+				flush = time.After(txBatchInterval)
+			}
+
+		case <-flush:
+			if !send() {
+				return
+			}
+
+		case <-p.term:
+			return
+		}
+	}
+}
+
+// close signals the broadcaster to terminate.
+func (p *peer) close() {
+	close(p.term)
+}
+
 // Head retrieves a copy of the current head (most recent) hash of the peer.
 func (p *peer) Head() (hash types.Hash) {
 	p.lock.RLock()
@@ -131,6 +186,17 @@ func (p *peer) SendTransactions(txs []*nom.AccountBlock) error {
 	return p2p.Send(p.rw, TxMsg, txs)
 }
 
+// AsyncSendTransactions queues an account-block for batched propagation to the
+// peer. The account-block is dropped if the queue of the peer is full.
+func (p *peer) AsyncSendTransactions(tx *nom.AccountBlock) {
+	select {
+	case p.queuedTxs <- tx:
+		p.knownTxs.Add(tx.Hash, nil)
+	default:
+		log.Debug("dropping account-block propagation", "peer-id", p.id, "hash", tx.Hash)
+	}
+}
+
 // SendBlockHashes sends a batch of known hashes to the remote peer.
 func (p *peer) SendBlockHashes(hashes []types.Hash) error {
 	return p2p.Send(p.rw, BlockHashesMsg, hashes)
@@ -138,6 +204,25 @@ func (p *peer) SendBlockHashes(hashes []types.Hash) error {
 
 // SendBlocks sends a batch of blocks to the remote peer.
 func (p *peer) SendBlocks(blocks []*nom.DetailedMomentum) error {
+	restore := stripGenesis(blocks)
+	defer restore()
+	return p2p.Send(p.rw, BlocksMsg, blocks)
+}
+
+// SendMomentumRange sends the response to a momentum range request.
+func (p *peer) SendMomentumRange(requestId uint64, momentums []*nom.DetailedMomentum) error {
+	restore := stripGenesis(momentums)
+	defer restore()
+	return p2p.Send(p.rw, MomentumRangeMsg, &momentumRangeData{
+		RequestId: requestId,
+		Momentums: momentums,
+	})
+}
+
+// stripGenesis prepares momentums to be sent, removing the content of the
+// genesis momentum which is known by every peer. The returned function puts
+// the content back once the momentums were sent.
+func stripGenesis(blocks []*nom.DetailedMomentum) (restore func()) {
 	// make sure timestamp-unix is present
 	for _, block := range blocks {
 		block.Momentum.EnsureCache()
@@ -152,15 +237,14 @@ func (p *peer) SendBlocks(blocks []*nom.DetailedMomentum) error {
 			block.AccountBlocks = nil
 		}
 	}
-	err := p2p.Send(p.rw, BlocksMsg, blocks)
-	for _, block := range blocks {
-		if block.Momentum.Height == 1 {
-			block.Momentum.Content = content
-			block.AccountBlocks = accountBlocks
+	return func() {
+		for _, block := range blocks {
+			if block.Momentum.Height == 1 {
+				block.Momentum.Content = content
+				block.AccountBlocks = accountBlocks
+			}
 		}
 	}
-
-	return err
 }
 
 // SendNewBlockHashes announces the availability of a number of blocks through
@@ -199,6 +283,13 @@ func (p *peer) RequestBlocks(hashes []types.Hash) error {
 	return p2p.Send(p.rw, GetBlocksMsg, hashes)
 }
 
+// RequestMomentumRange fetches count consecutive momentums starting at height
+// from. The response is correlated to the request by requestId.
+func (p *peer) RequestMomentumRange(requestId uint64, from uint64, count int) error {
+	log.Debug("fetching momentum range", "peer-id", p.id, "request-id", requestId, "from", from, "count", count)
+	return p2p.Send(p.rw, GetMomentumRangeMsg, &getMomentumRangeData{requestId, from, uint64(count)})
+}
+
 // Handshake executes the eth protocol handshake, negotiating version number,
 // network IDs, difficulties, head and genesis blocks.
 func (p *peer) Handshake(td uint64, head types.Hash, genesis types.Hash) error {
@@ -17,14 +17,21 @@
 package protocol
 
 import (
+	"github.com/zenon-network/go-zenon/chain/nom"
 	"github.com/zenon-network/go-zenon/common/types"
 )
 
+// Constants to match up protocol versions and messages
+const (
+	eth61 = 61
+	eth62 = 62
+)
+
 // Supported versions of the eth protocol (first is primary).
-var ProtocolVersions = []uint{61}
+var ProtocolVersions = []uint{eth62, eth61}
 
 // Number of implemented message corresponding to different protocol versions.
-var ProtocolLengths = []uint64{9}
+var ProtocolLengths = []uint64{11, 9}
 
 const (
 	ProtocolMaxMsgSize = 10 * 1024 * 1024 // Maximum cap on the size of a protocol message
@@ -41,6 +48,10 @@ const (
 	BlocksMsg
 	NewBlockMsg
 	GetBlockHashesFromNumberMsg
+
+	// Protocol messages belonging to eth/62
+	GetMomentumRangeMsg = 0x09
+	MomentumRangeMsg    = 0x0a
 )
 
 type errCode int
@@ -61,6 +72,10 @@ func (e errCode) String() string {
 	return errorToString[int(e)]
 }
 
+func (e errCode) Error() string {
+	return e.String()
+}
+
 // XXX change once legacy code is out
 var errorToString = map[int]string{
 	ErrMsgTooLarge:             "Message too long",
@@ -96,3 +111,19 @@ type getBlockHashesFromNumberData struct {
 	Number uint64
 	Amount uint64
 }
+
+// getMomentumRangeData is the network packet for the height based momentum
+// retrieval message. The response echoes RequestId, so requests can be
+// pipelined without waiting for the previous response.
+type getMomentumRangeData struct {
+	RequestId uint64
+	From      uint64
+	Count     uint64
+}
+
+// momentumRangeData is the network packet for the response to a momentum
+// range request.
+type momentumRangeData struct {
+	RequestId uint64
+	Momentums []*nom.DetailedMomentum
+}
@@ -0,0 +1,133 @@
+package protocol
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zenon-network/go-zenon/p2p/discover"
+	"github.com/zenon-network/go-zenon/protocol/downloader"
+	"github.com/zenon-network/go-zenon/protocol/fetcher"
+)
+
+const (
+	banScore    = 100            // Score at which a peer is banned
+	banDuration = 24 * time.Hour // Duration of the bans decided by the reputation
+	scoreDecay  = time.Minute    // Time it takes for the score of a peer to decrease by one point
+
+	penaltyInvalidMomentum = 50  // Penalty for a momentum which fails verification
+	penaltyTimeout         = 10  // Penalty for a block request which is not delivered in time
+	penaltyMsgTooLarge     = 50  // Penalty for a message bigger than ProtocolMaxMsgSize
+	penaltyGenesisMismatch = 100 // Penalty for a peer which belongs to another chain
+)
+
+// PeerBanner bans the peers which misbehave too often, implemented by p2p.Server
+type PeerBanner interface {
+	BanPeer(id discover.NodeID, duration time.Duration) error
+}
+
+// PeerScore is the misbehaviour score of a peer, which decays over time
+type PeerScore struct {
+	ID    discover.NodeID
+	Score int
+}
+
+type peerScore struct {
+	score   int
+	updated time.Time
+}
+
+// current returns the score decayed up to now
+func (s *peerScore) current(now time.Time) int {
+	decayed := s.score - int(now.Sub(s.updated)/scoreDecay)
+	if decayed < 0 {
+		return 0
+	}
+	return decayed
+}
+
+// peerReputation scores the misbehaviour reported by the protocol and bans the peers which reach banScore
+type peerReputation struct {
+	lock   sync.Mutex
+	scores map[discover.NodeID]*peerScore
+	banner PeerBanner
+}
+
+func newPeerReputation() *peerReputation {
+	return &peerReputation{
+		scores: make(map[discover.NodeID]*peerScore),
+	}
+}
+
+// penaltyFor returns the penalty of the misbehaviour reported with reason, zero if reason is not a misbehaviour
+func penaltyFor(reason error) int {
+	var code errCode
+	switch {
+	case errors.Is(reason, errInvalidMomentum), errors.Is(reason, fetcher.ErrInvalidHeader):
+		return penaltyInvalidMomentum
+	case errors.Is(reason, downloader.ErrDeliveryTimeout):
+		return penaltyTimeout
+	case errors.As(reason, &code) && code == ErrMsgTooLarge:
+		return penaltyMsgTooLarge
+	case errors.As(reason, &code) && code == ErrGenesisBlockMismatch:
+		return penaltyGenesisMismatch
+	default:
+		return 0
+	}
+}
+
+func (r *peerReputation) setBanner(banner PeerBanner) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.banner = banner
+}
+
+// report adds the penalty of reason to the score of the peer and returns true if the peer got banned
+func (r *peerReputation) report(id discover.NodeID, reason error) bool {
+	penalty := penaltyFor(reason)
+	if penalty == 0 {
+		return false
+	}
+
+	r.lock.Lock()
+	now := time.Now()
+	score, ok := r.scores[id]
+	if !ok {
+		score = new(peerScore)
+		r.scores[id] = score
+	}
+	score.score = score.current(now) + penalty
+	score.updated = now
+	banned := score.score >= banScore
+	if banned {
+		delete(r.scores, id)
+	}
+	banner := r.banner
+	r.lock.Unlock()
+
+	log.Info("peer misbehaved", "id", id, "penalty", penalty, "banned", banned, "reason", reason)
+	if banned && banner != nil {
+		if err := banner.BanPeer(id, banDuration); err != nil {
+			log.Error("failed to ban peer", "id", id, "reason", err)
+		}
+	}
+	return banned
+}
+
+// list returns the peers with a non-zero score, forgetting the scores which decayed to zero
+func (r *peerReputation) list() []*PeerScore {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := time.Now()
+	list := make([]*PeerScore, 0, len(r.scores))
+	for id, score := range r.scores {
+		current := score.current(now)
+		if current == 0 {
+			delete(r.scores, id)
+			continue
+		}
+		list = append(list, &PeerScore{ID: id, Score: current})
+	}
+	return list
+}
@@ -0,0 +1,145 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zenon-network/go-zenon/p2p/discover"
+	"github.com/zenon-network/go-zenon/protocol/downloader"
+	"github.com/zenon-network/go-zenon/protocol/fetcher"
+	"github.com/zenon-network/go-zenon/verifier"
+)
+
+type recordingBanner struct {
+	bans map[discover.NodeID]time.Duration
+}
+
+func (b *recordingBanner) BanPeer(id discover.NodeID, duration time.Duration) error {
+	b.bans[id] = duration
+	return nil
+}
+
+func TestPenaltyFor(t *testing.T) {
+	tests := []struct {
+		reason  error
+		penalty int
+	}{
+		{fmt.Errorf("%w - %v", errInvalidMomentum, verifier.ErrMSignatureInvalid), penaltyInvalidMomentum},
+		{invalidMomentumError(verifier.ErrMProducerInvalid), penaltyInvalidMomentum},
+		{fmt.Errorf("%w - bad slot", fetcher.ErrInvalidHeader), penaltyInvalidMomentum},
+		{downloader.ErrDeliveryTimeout, penaltyTimeout},
+		{errResp(ErrMsgTooLarge, "%v > %v", ProtocolMaxMsgSize+1, ProtocolMaxMsgSize), penaltyMsgTooLarge},
+		{errResp(ErrGenesisBlockMismatch, "%x", []byte{1}), penaltyGenesisMismatch},
+		// errors which are not caused by the peer are not penalized
+		{invalidMomentumError(errors.New("leveldb: closed")), 0},
+		{invalidMomentumError(verifier.InternalError(errors.New("missing frontier"))), 0},
+		// errors which depend on the local chain are not proof that the momentum is invalid
+		{invalidMomentumError(verifier.ErrMPreviousMissing), 0},
+		{invalidMomentumError(verifier.ErrABPreviousMissing), 0},
+		{invalidMomentumError(verifier.ErrABSequencerNotNext), 0},
+		{errResp(ErrDecode, "bad rlp"), 0},
+		{errResp(ErrNetworkIdMismatch, "%d", 2), 0},
+		{errors.New("EOF"), 0},
+	}
+	for _, test := range tests {
+		if penalty := penaltyFor(test.reason); penalty != test.penalty {
+			t.Errorf("penalty for %q: expected %v, got %v", test.reason, test.penalty, penalty)
+		}
+	}
+}
+
+func TestPeerReputation_BanThresholds(t *testing.T) {
+	banner := &recordingBanner{bans: make(map[discover.NodeID]time.Duration)}
+	r := newPeerReputation()
+	r.setBanner(banner)
+
+	invalid, slow, foreign := discover.NodeID{1}, discover.NodeID{2}, discover.NodeID{3}
+
+	// two invalid momentums get the peer banned
+	if r.report(invalid, invalidMomentumError(verifier.ErrMSignatureInvalid)) {
+		t.Fatalf("peer banned after the first invalid momentum")
+	}
+	if !r.report(invalid, invalidMomentumError(verifier.ErrMSignatureInvalid)) {
+		t.Fatalf("peer not banned after the second invalid momentum")
+	}
+	if banner.bans[invalid] != banDuration {
+		t.Fatalf("peer not banned for %v: %v", banDuration, banner.bans)
+	}
+
+	// ten timeouts get the peer banned
+	for i := 1; i < banScore/penaltyTimeout; i++ {
+		if r.report(slow, downloader.ErrDeliveryTimeout) {
+			t.Fatalf("peer banned after %v timeouts", i)
+		}
+	}
+	if !r.report(slow, downloader.ErrDeliveryTimeout) {
+		t.Fatalf("peer not banned after %v timeouts", banScore/penaltyTimeout)
+	}
+
+	// a peer of another chain is banned right away
+	if !r.report(foreign, errResp(ErrGenesisBlockMismatch, "mismatch")) {
+		t.Fatalf("peer of another chain not banned")
+	}
+
+	// the scores of banned peers are forgotten
+	if scores := r.list(); len(scores) != 0 {
+		t.Fatalf("unexpected scores: %v", scores)
+	}
+	if len(banner.bans) != 3 {
+		t.Fatalf("unexpected bans: %v", banner.bans)
+	}
+}
+
+func TestPeerReputation_Decay(t *testing.T) {
+	r := newPeerReputation()
+	id := discover.NodeID{1}
+
+	r.report(id, invalidMomentumError(verifier.ErrMSignatureInvalid))
+	if scores := r.list(); len(scores) != 1 || scores[0].Score != penaltyInvalidMomentum {
+		t.Fatalf("unexpected scores: %v", scores)
+	}
+
+	// half of the score decayed, so the next invalid momentum is not enough for a ban
+	r.scores[id].updated = time.Now().Add(-penaltyInvalidMomentum / 2 * scoreDecay)
+	if r.report(id, invalidMomentumError(verifier.ErrMSignatureInvalid)) {
+		t.Fatalf("peer banned although its score decayed")
+	}
+
+	// scores which decayed to zero are forgotten
+	r.scores[id].updated = time.Now().Add(-banScore * scoreDecay)
+	if scores := r.list(); len(scores) != 0 {
+		t.Fatalf("unexpected scores: %v", scores)
+	}
+}
+
+func TestPeerReputation_PreviousMissing(t *testing.T) {
+	banner := &recordingBanner{bans: make(map[discover.NodeID]time.Duration)}
+	r := newPeerReputation()
+	r.setBanner(banner)
+	id := discover.NodeID{1}
+
+	// a peer which is ahead of the local node sends momentums whose previous is not known yet
+	for i := 0; i < banScore; i++ {
+		if r.report(id, invalidMomentumError(verifier.ErrMPreviousMissing)) {
+			t.Fatalf("peer banned after %v momentums with a missing previous", i+1)
+		}
+	}
+	if scores := r.list(); len(scores) != 0 {
+		t.Fatalf("unexpected scores: %v", scores)
+	}
+	if len(banner.bans) != 0 {
+		t.Fatalf("unexpected bans: %v", banner.bans)
+	}
+}
+
+func TestPeerReputation_NoBanner(t *testing.T) {
+	r := newPeerReputation()
+	id := discover.NodeID{1}
+
+	// without a banner the peer is still reported as banned, so it gets disconnected
+	if !r.report(id, errResp(ErrGenesisBlockMismatch, "mismatch")) {
+		t.Fatalf("peer of another chain not banned")
+	}
+}
@@ -173,10 +173,14 @@ func (pm *ProtocolManager) syncInfo() *SyncInfo {
 		state = NotEnoughPeers
 	}
 
+	progress := pm.downloader.Progress()
 	return &SyncInfo{
-		State:         state,
-		CurrentHeight: currentHeight,
-		TargetHeight:  targetHeight,
+		State:            state,
+		CurrentHeight:    currentHeight,
+		TargetHeight:     targetHeight,
+		DownloadedHeight: progress.Downloaded,
+		DownloadPeers:    progress.Peers,
+		Throughput:       progress.Throughput,
 	}
 }
 
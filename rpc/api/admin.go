@@ -0,0 +1,63 @@
+package api
+
+import (
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/p2p"
+	"github.com/zenon-network/go-zenon/p2p/discover"
+)
+
+// AdminApi lets operators manage the peers of the node. It's not public, so it must be enabled explicitly in the endpoints.
+type AdminApi struct {
+	p2p *p2p.Server
+	log log15.Logger
+}
+
+func NewAdminApi(p2p *p2p.Server) *AdminApi {
+	return &AdminApi{
+		p2p: p2p,
+		log: common.RPCLogger.New("module", "admin_api"),
+	}
+}
+
+type BannedPeer struct {
+	PublicKey   string `json:"publicKey"`
+	BannedUntil int64  `json:"bannedUntil"`
+}
+
+func toBannedPeers(raw []*p2p.BannedPeer) []*BannedPeer {
+	peers := make([]*BannedPeer, 0, len(raw))
+	for _, peer := range raw {
+		peers = append(peers, &BannedPeer{
+			PublicKey:   peer.ID.String(),
+			BannedUntil: peer.BannedUntil.Unix(),
+		})
+	}
+	return peers
+}
+
+func (api *AdminApi) BanPeer(publicKey string, durationInSec int64) error {
+	id, err := discover.HexID(publicKey)
+	if err != nil {
+		return err
+	}
+	if durationInSec <= 0 {
+		return ErrBanDurationInvalid
+	}
+	return api.p2p.BanPeer(id, time.Duration(durationInSec)*time.Second)
+}
+
+func (api *AdminApi) UnbanPeer(publicKey string) error {
+	id, err := discover.HexID(publicKey)
+	if err != nil {
+		return err
+	}
+	return api.p2p.UnbanPeer(id)
+}
+
+func (api *AdminApi) GetBannedPeers() ([]*BannedPeer, error) {
+	return toBannedPeers(api.p2p.BannedPeers()), nil
+}
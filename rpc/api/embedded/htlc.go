@@ -0,0 +1,86 @@
+package embedded
+
+import (
+	"sort"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+type HtlcApi struct {
+	chain chain.Chain
+	log   log15.Logger
+}
+
+func NewHtlcApi(z zenon.Zenon) *HtlcApi {
+	return &HtlcApi{
+		chain: z.Chain(),
+		log:   common.RPCLogger.New("module", "embedded_htlc_api"),
+	}
+}
+
+type HtlcInfoList struct {
+	Count int                    `json:"count"`
+	List  []*definition.HtlcInfo `json:"list"`
+}
+
+func (a *HtlcApi) GetById(id types.Hash) (*definition.HtlcInfo, error) {
+	_, context, err := api.GetFrontierContext(a.chain, types.HtlcContract)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.GetHtlcInfo(context.Storage(), id)
+}
+
+// GetTimeLockedBy returns the active htlcs created by address, which it can reclaim once they expire
+func (a *HtlcApi) GetTimeLockedBy(address types.Address, pageIndex, pageSize uint32) (*HtlcInfoList, error) {
+	return a.getHtlcList(pageIndex, pageSize, func(htlc *definition.HtlcInfo) bool {
+		return htlc.TimeLocked == address
+	})
+}
+
+// GetHashLockedBy returns the active htlcs which address can unlock with the preimage of the hash lock
+func (a *HtlcApi) GetHashLockedBy(address types.Address, pageIndex, pageSize uint32) (*HtlcInfoList, error) {
+	return a.getHtlcList(pageIndex, pageSize, func(htlc *definition.HtlcInfo) bool {
+		return htlc.HashLocked == address
+	})
+}
+
+func (a *HtlcApi) getHtlcList(pageIndex, pageSize uint32, filter func(*definition.HtlcInfo) bool) (*HtlcInfoList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+	_, context, err := api.GetFrontierContext(a.chain, types.HtlcContract)
+	if err != nil {
+		return nil, err
+	}
+
+	htlcs, err := definition.GetHtlcInfoList(context.Storage())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HtlcInfoList{
+		List: make([]*definition.HtlcInfo, 0),
+	}
+	for _, htlc := range htlcs {
+		if filter(htlc) {
+			result.List = append(result.List, htlc)
+		}
+	}
+	sort.SliceStable(result.List, func(i, j int) bool {
+		return result.List[i].ExpirationTime < result.List[j].ExpirationTime
+	})
+	result.Count = len(result.List)
+
+	start, end := api.GetRange(pageIndex, pageSize, uint32(len(result.List)))
+	result.List = result.List[start:end]
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package embedded
+
+import (
+	"sort"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+type MultisigApi struct {
+	chain chain.Chain
+	log   log15.Logger
+}
+
+func NewMultisigApi(z zenon.Zenon) *MultisigApi {
+	return &MultisigApi{
+		chain: z.Chain(),
+		log:   common.RPCLogger.New("module", "embedded_multisig_api"),
+	}
+}
+
+type MultisigWallet struct {
+	*definition.Wallet
+	Balances []*definition.WalletBalance `json:"balances"`
+}
+
+type MultisigWalletList struct {
+	Count int               `json:"count"`
+	List  []*MultisigWallet `json:"list"`
+}
+
+type ProposalList struct {
+	Count int                    `json:"count"`
+	List  []*definition.Proposal `json:"list"`
+}
+
+func (a *MultisigApi) GetWalletById(id types.Hash) (*MultisigWallet, error) {
+	_, context, err := api.GetFrontierContext(a.chain, types.MultisigContract)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := definition.GetWalletEntry(context.Storage(), id)
+	if err != nil {
+		return nil, err
+	}
+	return &MultisigWallet{
+		Wallet:   wallet,
+		Balances: definition.GetWalletBalanceList(context.Storage(), id),
+	}, nil
+}
+
+// GetWalletsBySigner returns the wallets which have address as a co-signer, newest first
+func (a *MultisigApi) GetWalletsBySigner(address types.Address, pageIndex, pageSize uint32) (*MultisigWalletList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+	_, context, err := api.GetFrontierContext(a.chain, types.MultisigContract)
+	if err != nil {
+		return nil, err
+	}
+
+	wallets, err := definition.GetWalletList(context.Storage())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MultisigWalletList{
+		List: make([]*MultisigWallet, 0),
+	}
+	for _, wallet := range wallets {
+		if wallet.IsSigner(address) {
+			result.List = append(result.List, &MultisigWallet{
+				Wallet:   wallet,
+				Balances: definition.GetWalletBalanceList(context.Storage(), wallet.Id),
+			})
+		}
+	}
+	sort.SliceStable(result.List, func(i, j int) bool {
+		return result.List[i].CreationTimestamp > result.List[j].CreationTimestamp
+	})
+	result.Count = len(result.List)
+
+	start, end := api.GetRange(pageIndex, pageSize, uint32(len(result.List)))
+	result.List = result.List[start:end]
+	return result, nil
+}
+
+func (a *MultisigApi) GetProposalById(id types.Hash) (*definition.Proposal, error) {
+	_, context, err := api.GetFrontierContext(a.chain, types.MultisigContract)
+	if err != nil {
+		return nil, err
+	}
+
+	return definition.GetProposalEntry(context.Storage(), id)
+}
+
+// GetPendingProposals returns the proposals of the wallet which don't have enough approvals yet, oldest first
+func (a *MultisigApi) GetPendingProposals(walletId types.Hash, pageIndex, pageSize uint32) (*ProposalList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+	_, context, err := api.GetFrontierContext(a.chain, types.MultisigContract)
+	if err != nil {
+		return nil, err
+	}
+
+	proposals, err := definition.GetProposalList(context.Storage())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProposalList{
+		List: make([]*definition.Proposal, 0),
+	}
+	for _, proposal := range proposals {
+		if proposal.WalletId == walletId && proposal.Status == definition.PendingProposalStatus {
+			result.List = append(result.List, proposal)
+		}
+	}
+	sort.SliceStable(result.List, func(i, j int) bool {
+		return result.List[i].CreationTimestamp < result.List[j].CreationTimestamp
+	})
+	result.Count = len(result.List)
+
+	start, end := api.GetRange(pageIndex, pageSize, uint32(len(result.List)))
+	result.List = result.List[start:end]
+	return result, nil
+}
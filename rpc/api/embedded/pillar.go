@@ -7,8 +7,10 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
+	capi "github.com/zenon-network/go-zenon/consensus/api"
 	"github.com/zenon-network/go-zenon/rpc/api"
 	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
@@ -37,17 +39,17 @@ var (
 
 // === Shared RPCs ===
 
-func (a *PillarApi) GetDepositedQsr(address types.Address) (*big.Int, error) {
-	return getDepositedQsr(a.chain, types.PillarContract, address)
+func (a *PillarApi) GetDepositedQsr(address types.Address, at *types.HashHeight) (*big.Int, error) {
+	return getDepositedQsr(a.chain, types.PillarContract, address, at)
 }
-func (a *PillarApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(a.chain, types.PillarContract, address)
+func (a *PillarApi) GetUncollectedReward(address types.Address, at *types.HashHeight) (*definition.RewardDeposit, error) {
+	return getUncollectedReward(a.chain, types.PillarContract, address, at)
 }
-func (a *PillarApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
+func (a *PillarApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*RewardHistoryList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
-	return getFrontierRewardByPage(a.chain, types.PillarContract, address, pageIndex, pageSize)
+	return getFrontierRewardByPage(a.chain, types.PillarContract, address, pageIndex, pageSize, at)
 }
 
 func (a *PillarApi) GetQsrRegistrationCost() (*big.Int, error) {
@@ -106,12 +108,12 @@ func (a PillarInfoByWeight) Less(i, j int) bool {
 	}
 }
 
-func (a *PillarApi) GetAll(pageIndex, pageSize uint32) (*PillarInfoList, error) {
+func (a *PillarApi) GetAll(pageIndex, pageSize uint32, at *types.HashHeight) (*PillarInfoList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	m, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
+	m, context, err := api.GetContext(a.chain, types.PillarContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +150,17 @@ func (a *PillarApi) GetAll(pageIndex, pageSize uint32) (*PillarInfoList, error)
 	}
 
 	// feed information from rpc consensus cache
-	weights, stats := a.consensusCache.Get()
+	// historical queries compute the weights at the requested momentum and have no stats
+	var weights map[string]*big.Int
+	var stats *capi.EpochStats
+	if at == nil {
+		weights, stats = a.consensusCache.Get()
+	} else {
+		weights, err = getPillarWeights(context.MomentumStore())
+		if err != nil {
+			return nil, err
+		}
+	}
 	if weights != nil {
 		for _, pillar := range targetList {
 			weight, ok := weights[pillar.Name]
@@ -182,8 +194,8 @@ func (a *PillarApi) GetAll(pageIndex, pageSize uint32) (*PillarInfoList, error)
 		List:  targetList[start:end],
 	}, nil
 }
-func (a *PillarApi) GetByOwner(stakeAddress types.Address) ([]*PillarInfo, error) {
-	list, err := a.GetAll(0, api.RpcMaxPageSize)
+func (a *PillarApi) GetByOwner(stakeAddress types.Address, at *types.HashHeight) ([]*PillarInfo, error) {
+	list, err := a.GetAll(0, api.RpcMaxPageSize, at)
 	if err != nil {
 		return nil, err
 	}
@@ -196,8 +208,8 @@ func (a *PillarApi) GetByOwner(stakeAddress types.Address) ([]*PillarInfo, error
 
 	return targetList, nil
 }
-func (a *PillarApi) GetByName(name string) (*PillarInfo, error) {
-	list, err := a.GetAll(0, api.RpcMaxPageSize)
+func (a *PillarApi) GetByName(name string, at *types.HashHeight) (*PillarInfo, error) {
+	list, err := a.GetAll(0, api.RpcMaxPageSize, at)
 	if err != nil {
 		return nil, err
 	}
@@ -210,6 +222,16 @@ func (a *PillarApi) GetByName(name string) (*PillarInfo, error) {
 	return nil, nil
 }
 
+// GetPayoutConfig returns the share of the block reward which the pillar distributes to its delegators on top of giveBlockRewardPercentage,
+// and the share committed for the epochs starting with nextEpoch, if any.
+func (a *PillarApi) GetPayoutConfig(name string, at *types.HashHeight) (*definition.PillarPayoutConfig, error) {
+	_, context, err := api.GetContext(a.chain, types.PillarContract, at)
+	if err != nil {
+		return nil, err
+	}
+	return definition.GetPillarPayoutConfig(context.Storage(), name)
+}
+
 func (a *PillarApi) CheckNameAvailability(name string) (bool, error) {
 	_, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
 	if err != nil {
@@ -230,6 +252,18 @@ func (a *PillarApi) CheckNameAvailability(name string) (bool, error) {
 	return true, nil
 }
 
+func getPillarWeights(momentumStore store.Momentum) (map[string]*big.Int, error) {
+	delegations, err := momentumStore.ComputePillarDelegations()
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[string]*big.Int, len(delegations))
+	for _, delegation := range delegations {
+		weights[delegation.Name] = delegation.Weight
+	}
+	return weights, nil
+}
+
 // User delegation
 type GetDelegatedPillarResponse struct {
 	Name       string   `json:"name"`
@@ -237,8 +271,8 @@ type GetDelegatedPillarResponse struct {
 	Balance    *big.Int `json:"weight"`
 }
 
-func (a *PillarApi) GetDelegatedPillar(addr types.Address) (*GetDelegatedPillarResponse, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.PillarContract)
+func (a *PillarApi) GetDelegatedPillar(addr types.Address, at *types.HashHeight) (*GetDelegatedPillarResponse, error) {
+	_, context, err := api.GetContext(a.chain, types.PillarContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +284,7 @@ func (a *PillarApi) GetDelegatedPillar(addr types.Address) (*GetDelegatedPillarR
 		return nil, err
 	}
 	if delegationInfo != nil {
-		balance, err := a.chain.GetFrontierMomentumStore().GetAccountStore(addr).GetBalance(types.ZnnTokenStandard)
+		balance, err := context.MomentumStore().GetAccountStore(addr).GetBalance(types.ZnnTokenStandard)
 		if err != nil {
 			return nil, err
 		}
@@ -62,13 +62,13 @@ func (a SortFusionEntryByHeight) Less(i, j int) bool {
 	return a[i].ExpirationHeight < a[j].ExpirationHeight
 }
 
-func (a *PlasmaApi) Get(address types.Address) (*PlasmaInfo, error) {
-	_, context, err := api.GetFrontierContext(a.chain, address)
+func (a *PlasmaApi) Get(address types.Address, at *types.HashHeight) (*PlasmaInfo, error) {
+	_, context, err := api.GetContext(a.chain, address, at)
 	if err != nil {
 		return nil, err
 	}
 
-	amount, err := a.chain.GetFrontierMomentumStore().GetStakeBeneficialAmount(address)
+	amount, err := context.MomentumStore().GetStakeBeneficialAmount(address)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +84,12 @@ func (a *PlasmaApi) Get(address types.Address) (*PlasmaInfo, error) {
 		QsrAmount:     amount,
 	}, nil
 }
-func (a *PlasmaApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32) (*FusionEntryList, error) {
+func (a *PlasmaApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*FusionEntryList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.PlasmaContract)
+	_, context, err := api.GetContext(a.chain, types.PlasmaContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -175,3 +175,64 @@ func (a *PlasmaApi) GetRequiredPoWForAccountBlock(param GetRequiredParam) (*GetR
 		}, nil
 	}
 }
+
+type PlasmaEstimation struct {
+	// BasePlasma is the plasma consumed by the block
+	BasePlasma uint64 `json:"basePlasma"`
+	// AvailablePlasma is the fused plasma of the account which is not used by confirmed or unconfirmed blocks
+	AvailablePlasma uint64 `json:"availablePlasma"`
+	// UnconfirmedBlocks and UnconfirmedPlasma are the blocks of the account which are not confirmed by a momentum yet,
+	// and the fused plasma they use
+	UnconfirmedBlocks int    `json:"unconfirmedBlocks"`
+	UnconfirmedPlasma uint64 `json:"unconfirmedPlasma"`
+	// FusedBlocks is the number of blocks like this one which can be published using only fused plasma
+	FusedBlocks uint64 `json:"fusedBlocks"`
+	// RequiredDifficulty is the PoW required to publish the block, 0 if there is enough fused plasma
+	RequiredDifficulty *big.Int `json:"requiredDifficulty"`
+}
+
+// EstimatePlasma returns the plasma consumed by the block template and the plasma available to its account,
+// taking into account the unconfirmed blocks of the account.
+func (a *PlasmaApi) EstimatePlasma(template *api.AccountBlock) (*PlasmaEstimation, error) {
+	if template == nil {
+		return nil, api.ErrParamIsNull
+	}
+	block, err := template.ToLedgerBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	_, context, err := api.GetFrontierContext(a.chain, block.Address)
+	if err != nil {
+		return nil, err
+	}
+	availablePlasma, err := vm.AvailablePlasma(context.MomentumStore(), context)
+	if err != nil {
+		return nil, err
+	}
+	basePlasma, err := vm.GetBasePlasmaForAccountBlock(context, block)
+	if err != nil {
+		return nil, err
+	}
+
+	unconfirmed := a.chain.GetUncommittedAccountBlocksByAddress(block.Address)
+	result := &PlasmaEstimation{
+		BasePlasma:         basePlasma,
+		AvailablePlasma:    availablePlasma,
+		UnconfirmedBlocks:  len(unconfirmed),
+		RequiredDifficulty: common.Big0,
+	}
+	for _, unconfirmedBlock := range unconfirmed {
+		result.UnconfirmedPlasma += unconfirmedBlock.FusedPlasma
+	}
+	if basePlasma != 0 {
+		result.FusedBlocks = availablePlasma / basePlasma
+	}
+	if availablePlasma < basePlasma {
+		result.RequiredDifficulty, err = vm.GetDifficultyForPlasma(basePlasma - availablePlasma)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
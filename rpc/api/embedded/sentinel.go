@@ -93,15 +93,15 @@ func (api *SentinelApi) GetAllActive(pageIndex, pageSize uint32) (*SentinelInfoL
 
 // === Shared RPCs ===
 
-func (api *SentinelApi) GetDepositedQsr(address types.Address) (*big.Int, error) {
-	return getDepositedQsr(api.chain, types.SentinelContract, address)
+func (api *SentinelApi) GetDepositedQsr(address types.Address, at *types.HashHeight) (*big.Int, error) {
+	return getDepositedQsr(api.chain, types.SentinelContract, address, at)
 }
-func (api *SentinelApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(api.chain, types.SentinelContract, address)
+func (api *SentinelApi) GetUncollectedReward(address types.Address, at *types.HashHeight) (*definition.RewardDeposit, error) {
+	return getUncollectedReward(api.chain, types.SentinelContract, address, at)
 }
-func (api *SentinelApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
+func (api *SentinelApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*RewardHistoryList, error) {
 	if pageSize > rpcapi.RpcMaxPageSize {
 		return nil, rpcapi.ErrPageSizeParamTooBig
 	}
-	return getFrontierRewardByPage(api.chain, types.SentinelContract, address, pageIndex, pageSize)
+	return getFrontierRewardByPage(api.chain, types.SentinelContract, address, pageIndex, pageSize, at)
 }
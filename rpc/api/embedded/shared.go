@@ -9,8 +9,8 @@ import (
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 )
 
-func getDepositedQsr(chain chain.Chain, contract types.Address, address types.Address) (*big.Int, error) {
-	_, context, err := api.GetFrontierContext(chain, contract)
+func getDepositedQsr(chain chain.Chain, contract types.Address, address types.Address, at *types.HashHeight) (*big.Int, error) {
+	_, context, err := api.GetContext(chain, contract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +21,8 @@ func getDepositedQsr(chain chain.Chain, contract types.Address, address types.Ad
 		return qsrDeposit.Qsr, nil
 	}
 }
-func getUncollectedReward(chain chain.Chain, contract types.Address, address types.Address) (*definition.RewardDeposit, error) {
-	_, context, err := api.GetFrontierContext(chain, contract)
+func getUncollectedReward(chain chain.Chain, contract types.Address, address types.Address, at *types.HashHeight) (*definition.RewardDeposit, error) {
+	_, context, err := api.GetContext(chain, contract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -39,12 +39,12 @@ type RewardHistoryList struct {
 	List  []*RewardHistoryEntry `json:"list"`
 }
 
-func getFrontierRewardByPage(chain chain.Chain, contract types.Address, address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
+func getFrontierRewardByPage(chain chain.Chain, contract types.Address, address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*RewardHistoryList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(chain, contract)
+	_, context, err := api.GetContext(chain, contract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
 	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 	"github.com/zenon-network/go-zenon/zenon"
 )
@@ -33,14 +34,14 @@ func NewStakeApi(z zenon.Zenon) *StakeApi {
 
 // === Shared RPCs ===
 
-func (a *StakeApi) GetUncollectedReward(address types.Address) (*definition.RewardDeposit, error) {
-	return getUncollectedReward(a.chain, types.StakeContract, address)
+func (a *StakeApi) GetUncollectedReward(address types.Address, at *types.HashHeight) (*definition.RewardDeposit, error) {
+	return getUncollectedReward(a.chain, types.StakeContract, address, at)
 }
-func (a *StakeApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32) (*RewardHistoryList, error) {
+func (a *StakeApi) GetFrontierRewardByPage(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*RewardHistoryList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
-	return getFrontierRewardByPage(a.chain, types.StakeContract, address, pageIndex, pageSize)
+	return getFrontierRewardByPage(a.chain, types.StakeContract, address, pageIndex, pageSize, at)
 }
 
 type StakeEntry struct {
@@ -58,12 +59,12 @@ type StakeList struct {
 	Entries             []*StakeEntry `json:"list"`
 }
 
-func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32) (*StakeList, error) {
+func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*StakeList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.StakeContract)
+	_, context, err := api.GetContext(a.chain, types.StakeContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -72,20 +73,86 @@ func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSiz
 		return nil, err
 	}
 
+	return toStakeList(list, total, totalWeighted, pageIndex, pageSize), nil
+}
+
+// GetExpiredEntriesByAddress returns the entries of address which can be cancelled at the momentum specified by at
+func (a *StakeApi) GetExpiredEntriesByAddress(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*StakeList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+
+	_, context, err := api.GetContext(a.chain, types.StakeContract, at)
+	if err != nil {
+		return nil, err
+	}
+	momentum, err := context.GetFrontierMomentum()
+	if err != nil {
+		return nil, err
+	}
+	list, _, _, err := definition.GetStakeListByAddress(context.Storage(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	expired := make([]*definition.StakeInfo, 0, len(list))
+	total := big.NewInt(0)
+	totalWeighted := big.NewInt(0)
+	for _, info := range list {
+		if info.ExpirationTime <= momentum.Timestamp.Unix() {
+			expired = append(expired, info)
+			total.Add(total, info.Amount)
+			totalWeighted.Add(totalWeighted, info.WeightedAmount)
+		}
+	}
+
+	return toStakeList(expired, total, totalWeighted, pageIndex, pageSize), nil
+}
+
+// GetEntryById returns the active entry of address identified by id.
+// Extending or partially cancelling an entry replaces it with a new entry, identified by the hash of the send-block.
+func (a *StakeApi) GetEntryById(address types.Address, id types.Hash, at *types.HashHeight) (*StakeEntry, error) {
+	_, context, err := api.GetContext(a.chain, types.StakeContract, at)
+	if err != nil {
+		return nil, err
+	}
+	info, err := definition.GetStakeInfo(context.Storage(), id, address)
+	if err != nil {
+		return nil, err
+	}
+	if info.RevokeTime != 0 {
+		return nil, constants.ErrDataNonExistent
+	}
+	return toStakeEntry(info), nil
+}
+
+// IsAutoCompound returns true if the QSR rewards of address are fused for plasma when collected
+func (a *StakeApi) IsAutoCompound(address types.Address, at *types.HashHeight) (bool, error) {
+	_, context, err := api.GetContext(a.chain, types.StakeContract, at)
+	if err != nil {
+		return false, err
+	}
+	return definition.IsStakeAutoCompound(context.Storage(), address)
+}
+
+func toStakeEntry(info *definition.StakeInfo) *StakeEntry {
+	return &StakeEntry{
+		Amount:              info.Amount,
+		WeightedAmount:      info.WeightedAmount,
+		StartTimestamp:      info.StartTime,
+		ExpirationTimestamp: info.ExpirationTime,
+		Address:             info.StakeAddress,
+		Id:                  info.Id,
+	}
+}
+func toStakeList(list []*definition.StakeInfo, total, totalWeighted *big.Int, pageIndex, pageSize uint32) *StakeList {
 	sort.Sort(definition.StakeByExpirationTime(list))
 
 	listLen := len(list)
 	start, end := api.GetRange(pageIndex, pageSize, uint32(listLen))
 	entryList := make([]*StakeEntry, end-start)
 	for index, info := range list[start:end] {
-		entryList[index] = &StakeEntry{
-			Amount:              info.Amount,
-			WeightedAmount:      info.WeightedAmount,
-			StartTimestamp:      info.StartTime,
-			ExpirationTimestamp: info.ExpirationTime,
-			Address:             info.StakeAddress,
-			Id:                  info.Id,
-		}
+		entryList[index] = toStakeEntry(info)
 	}
 
 	return &StakeList{
@@ -93,5 +160,5 @@ func (a *StakeApi) GetEntriesByAddress(address types.Address, pageIndex, pageSiz
 		TotalWeightedAmount: totalWeighted,
 		Count:               listLen,
 		Entries:             entryList,
-	}, nil
+	}
 }
@@ -34,12 +34,12 @@ type TokenList struct {
 	List  []*api.Token `json:"list"`
 }
 
-func (a *TokenAPI) GetAll(pageIndex, pageSize uint32) (*TokenList, error) {
+func (a *TokenAPI) GetAll(pageIndex, pageSize uint32, at *types.HashHeight) (*TokenList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+	_, context, err := api.GetContext(a.chain, types.TokenContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -54,12 +54,12 @@ func (a *TokenAPI) GetAll(pageIndex, pageSize uint32) (*TokenList, error) {
 		List:  tokenList[start:end],
 	}, nil
 }
-func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32) (*TokenList, error) {
+func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*TokenList, error) {
 	if pageSize > api.RpcMaxPageSize {
 		return nil, api.ErrPageSizeParamTooBig
 	}
 
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+	_, context, err := api.GetContext(a.chain, types.TokenContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -82,8 +82,8 @@ func (a *TokenAPI) GetByOwner(owner types.Address, pageIndex, pageSize uint32) (
 		List:  tokenList[start:end],
 	}, nil
 }
-func (a *TokenAPI) GetByZts(zts types.ZenonTokenStandard) (*api.Token, error) {
-	_, context, err := api.GetFrontierContext(a.chain, types.TokenContract)
+func (a *TokenAPI) GetByZts(zts types.ZenonTokenStandard, at *types.HashHeight) (*api.Token, error) {
+	_, context, err := api.GetContext(a.chain, types.TokenContract, at)
 	if err != nil {
 		return nil, err
 	}
@@ -99,3 +99,36 @@ func (a *TokenAPI) GetByZts(zts types.ZenonTokenStandard) (*api.Token, error) {
 	}
 	return nil, nil
 }
+func (a *TokenAPI) GetPolicy(zts types.ZenonTokenStandard, at *types.HashHeight) (*definition.TokenPolicy, error) {
+	_, context, err := api.GetContext(a.chain, types.TokenContract, at)
+	if err != nil {
+		return nil, err
+	}
+	return definition.GetTokenPolicy(context.Storage(), zts)
+}
+
+type TokenAddressPolicyList struct {
+	Count int                              `json:"count"`
+	List  []*definition.TokenAddressPolicy `json:"list"`
+}
+
+// GetAddressPolicies returns the addresses which are frozen or allowed for zts
+func (a *TokenAPI) GetAddressPolicies(zts types.ZenonTokenStandard, pageIndex, pageSize uint32, at *types.HashHeight) (*TokenAddressPolicyList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+
+	_, context, err := api.GetContext(a.chain, types.TokenContract, at)
+	if err != nil {
+		return nil, err
+	}
+	policyList, err := definition.GetTokenAddressPolicyList(context.Storage(), zts)
+	if err != nil {
+		return nil, err
+	}
+	start, end := api.GetRange(pageIndex, pageSize, uint32(len(policyList)))
+	return &TokenAddressPolicyList{
+		Count: len(policyList),
+		List:  policyList[start:end],
+	}, nil
+}
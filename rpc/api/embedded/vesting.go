@@ -0,0 +1,79 @@
+package embedded
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+type VestingApi struct {
+	chain chain.Chain
+	log   log15.Logger
+}
+
+func NewVestingApi(z zenon.Zenon) *VestingApi {
+	return &VestingApi{
+		chain: z.Chain(),
+		log:   common.RPCLogger.New("module", "embedded_vesting_api"),
+	}
+}
+
+type VestingSchedule struct {
+	*definition.VestingSchedule
+	VestedAmount       *big.Int `json:"vestedAmount"`
+	WithdrawableAmount *big.Int `json:"withdrawableAmount"`
+}
+
+type VestingScheduleList struct {
+	Count int                `json:"count"`
+	List  []*VestingSchedule `json:"list"`
+}
+
+// GetSchedulesByBeneficiary returns the schedules which still lock funds for address, with the amounts
+// computed at the timestamp of the frontier momentum
+func (a *VestingApi) GetSchedulesByBeneficiary(address types.Address, pageIndex, pageSize uint32) (*VestingScheduleList, error) {
+	if pageSize > api.RpcMaxPageSize {
+		return nil, api.ErrPageSizeParamTooBig
+	}
+	momentum, context, err := api.GetFrontierContext(a.chain, types.VestingContract)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := definition.GetVestingSchedulesByBeneficiary(context.Storage(), address)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(schedules, func(i, j int) bool {
+		if schedules[i].StartTime == schedules[j].StartTime {
+			return schedules[i].Id.String() < schedules[j].Id.String()
+		}
+		return schedules[i].StartTime < schedules[j].StartTime
+	})
+
+	result := &VestingScheduleList{
+		Count: len(schedules),
+		List:  make([]*VestingSchedule, len(schedules)),
+	}
+	timestamp := momentum.Timestamp.Unix()
+	for index, schedule := range schedules {
+		result.List[index] = &VestingSchedule{
+			VestingSchedule:    schedule,
+			VestedAmount:       schedule.VestedAmount(timestamp),
+			WithdrawableAmount: schedule.WithdrawableAmount(timestamp),
+		}
+	}
+
+	start, end := api.GetRange(pageIndex, pageSize, uint32(len(result.List)))
+	result.List = result.List[start:end]
+	return result, nil
+}
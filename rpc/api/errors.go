@@ -10,4 +10,9 @@ var (
 	ErrCountParamTooBig     = common.NewErrorWCode(-32000, "count parameter is too big")
 	ErrHeightParamIsZero    = common.NewErrorWCode(-32000, "height parameter must be strictly greater than zero")
 	ErrParamIsNull          = common.NewErrorWCode(-32000, "parameter must not be null")
+	ErrMomentumParamIsZero  = common.NewErrorWCode(-32000, "momentum parameter must specify a hash or a height")
+	ErrMomentumNotFound     = common.NewErrorWCode(-32000, "momentum not found")
+	ErrBatchParamTooBig     = common.NewErrorWCode(-32000, "batch parameter is too big")
+	ErrBanDurationInvalid   = common.NewErrorWCode(-32000, "ban duration must be strictly greater than zero")
+	ErrBatchAborted         = common.NewErrorWCode(-32000, "block not verified since a previous block of the batch failed")
 )
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/inconshreveable/log15"
+
+	"github.com/zenon-network/go-zenon/chain"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/indexer"
+	"github.com/zenon-network/go-zenon/zenon"
+)
+
+func NewIndexerApi(z zenon.Zenon) *IndexerApi {
+	return &IndexerApi{
+		chain:   z.Chain(),
+		indexer: z.Indexer(),
+		log:     common.RPCLogger.New("module", "indexer_api"),
+	}
+}
+
+type IndexerApi struct {
+	chain   chain.Chain
+	indexer indexer.Indexer
+	log     log15.Logger
+}
+
+func (a IndexerApi) String() string {
+	return "IndexerApi"
+}
+
+// GetFrontierMomentum returns the identifier of the last momentum processed by the indexer
+func (a *IndexerApi) GetFrontierMomentum() (*types.HashHeight, error) {
+	return a.indexer.Frontier()
+}
+
+// GetAccountBlocksByPage returns the account-blocks which have address as owner or as counterparty, newest first.
+// The optional filter restricts the result by counterparty, token standard, block type, embedded method and time range.
+// The total number of matching blocks is not computed, more reports whether there are blocks after the page.
+func (a *IndexerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pageSize uint32, filter *indexer.Filter) (*AccountBlockList, error) {
+	if pageSize > RpcMaxPageSize {
+		return nil, ErrPageSizeParamTooBig
+	}
+
+	entries, more, err := a.indexer.GetEntries(address, filter, pageIndex, pageSize)
+	if err != nil {
+		a.log.Error("GetAccountBlocksByPage failed", "reason", err, "method-called", "indexer.GetEntries")
+		return nil, err
+	}
+
+	momentumStore := a.chain.GetFrontierMomentumStore()
+	list := make([]*AccountBlock, 0, len(entries))
+	for _, entry := range entries {
+		block, err := momentumStore.GetAccountBlockByHash(entry.Hash)
+		if err != nil {
+			a.log.Error("GetAccountBlocksByPage failed", "reason", err, "method-called", "momentumStore.GetAccountBlockByHash")
+			return nil, err
+		}
+		// the indexer may be ahead of the frontier momentum store for a brief moment
+		if block == nil {
+			continue
+		}
+		rpcBlock, err := ledgerAccountBlockToRpc(a.chain, block)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, rpcBlock)
+	}
+
+	return &AccountBlockList{
+		List:  list,
+		Count: len(list),
+		More:  more,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm"
@@ -42,21 +44,10 @@ func (l LedgerApi) String() string {
 
 func (l *LedgerApi) PublishRawTransaction(block *AccountBlock) error {
 	defer common.RecoverStack()
-	if block == nil {
-		return ErrParamIsNull
-	}
-
-	if block.ChainIdentifier != 0 && block.ChainIdentifier != l.chain.ChainIdentifier() {
-		return errors.Errorf("the block has a different network Id (%d) from the node (%d)", block.ChainIdentifier, l.chain.ChainIdentifier())
-	}
-
-	lb, err := block.ToLedgerBlock()
+	lb, err := l.toRawLedgerBlock(block)
 	if err != nil {
 		return err
 	}
-	if err := checkTokenIdValid(l.chain, &lb.TokenStandard); err != nil {
-		return err
-	}
 	m, err := l.chain.GetFrontierMomentumStore().GetFrontierMomentum()
 	if m == nil {
 		return errors.New("failed to get latest momentum")
@@ -73,6 +64,156 @@ func (l *LedgerApi) PublishRawTransaction(block *AccountBlock) error {
 	return nil
 }
 
+// PublishBlockReport is the outcome of one block published with PublishRawTransactions.
+// Error is empty for blocks which passed verification.
+type PublishBlockReport struct {
+	Hash  types.Hash `json:"hash"`
+	Error string     `json:"error"`
+}
+type PublishBatchReport struct {
+	Published bool                  `json:"published"`
+	List      []*PublishBlockReport `json:"list"`
+}
+
+// PublishRawTransactions publishes an ordered batch of blocks with all-or-nothing semantics.
+// The blocks are verified one after another, on top of the previous blocks of the batch, and are inserted under a single insert lock.
+// If any block fails, none of the blocks are inserted and the report contains the reason of the failure.
+func (l *LedgerApi) PublishRawTransactions(blocks []*AccountBlock) (*PublishBatchReport, error) {
+	defer common.RecoverStack()
+	if len(blocks) == 0 {
+		return nil, ErrParamIsNull
+	}
+	if len(blocks) > RpcMaxCountSize {
+		return nil, ErrBatchParamTooBig
+	}
+
+	report := &PublishBatchReport{
+		List: make([]*PublishBlockReport, len(blocks)),
+	}
+	for index, block := range blocks {
+		report.List[index] = &PublishBlockReport{}
+		if block != nil {
+			report.List[index].Hash = block.Hash
+		}
+	}
+
+	transactions, err := l.insertBatch(blocks, report)
+	if err != nil {
+		l.log.Error("PublishRawTransactions failed", "reason", err, "method-called", "insertBatch")
+		return nil, err
+	}
+	if transactions == nil {
+		return report, nil
+	}
+
+	for _, transaction := range transactions {
+		l.z.Broadcaster().BroadcastAccountBlock(transaction.Block)
+	}
+	report.Published = true
+	return report, nil
+}
+
+// insertBatch inserts all blocks in the account pool, or none of them in case one fails.
+// Returns nil transactions in case a block failed, with the reason reported in report.
+func (l *LedgerApi) insertBatch(blocks []*AccountBlock, report *PublishBatchReport) ([]*nom.AccountBlockTransaction, error) {
+	insert := l.chain.AcquireInsert("rpc - publish raw transactions")
+	defer insert.Unlock()
+
+	supervisor := vm.NewSupervisor(l.z.Chain(), l.z.Consensus())
+	transactions := make([]*nom.AccountBlockTransaction, 0, len(blocks))
+	for index, block := range blocks {
+		transaction, err := l.insertRawTransaction(insert, supervisor, block)
+		if err == nil {
+			transactions = append(transactions, transaction)
+			continue
+		}
+
+		report.List[index].Error = err.Error()
+		for _, skipped := range report.List[index+1:] {
+			skipped.Error = ErrBatchAborted.Error()
+		}
+		for i := len(transactions) - 1; i >= 0; i -= 1 {
+			inserted := transactions[i].Block
+			if err := l.chain.RollbackAccountBlockTransaction(insert, inserted.Address, inserted.Identifier()); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+	return transactions, nil
+}
+
+func (l *LedgerApi) insertRawTransaction(insert sync.Locker, supervisor *vm.Supervisor, block *AccountBlock) (*nom.AccountBlockTransaction, error) {
+	lb, err := l.toRawLedgerBlock(block)
+	if err != nil {
+		return nil, err
+	}
+	// inserting on top of other unconfirmed blocks would roll them back, which can't be undone if the batch fails
+	frontier := l.chain.GetFrontierAccountStore(lb.Address).Identifier()
+	if frontier != lb.Previous() {
+		return nil, errors.Errorf("the block is not on top of the account frontier %v", frontier)
+	}
+
+	transaction, err := supervisor.ApplyBlock(lb)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.chain.AddAccountBlockTransaction(insert, transaction); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+func (l *LedgerApi) toRawLedgerBlock(block *AccountBlock) (*nom.AccountBlock, error) {
+	if block == nil {
+		return nil, ErrParamIsNull
+	}
+
+	if block.ChainIdentifier != 0 && block.ChainIdentifier != l.chain.ChainIdentifier() {
+		return nil, errors.Errorf("the block has a different network Id (%d) from the node (%d)", block.ChainIdentifier, l.chain.ChainIdentifier())
+	}
+
+	lb, err := block.ToLedgerBlock()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTokenIdValid(l.chain, &lb.TokenStandard); err != nil {
+		return nil, err
+	}
+	return lb, nil
+}
+
+// SimulateTransaction applies the block on top of the frontier without publishing it.
+// The block doesn't need to be signed. For calls to embedded contracts, the embedded receive is simulated as well.
+func (l *LedgerApi) SimulateTransaction(block *AccountBlock) (*TransactionSimulation, error) {
+	defer common.RecoverStack()
+	lb, err := l.toRawLedgerBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	supervisor := vm.NewSupervisor(l.z.Chain(), l.z.Consensus())
+	simulation, err := supervisor.SimulateBlock(lb)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TransactionSimulation{
+		BalanceChanges:   simulation.BalanceChanges,
+		DescendantBlocks: make([]*nom.AccountBlock, 0),
+		BasePlasma:       simulation.Block.BasePlasma,
+		UsedPlasma:       simulation.Block.TotalPlasma,
+	}
+	if simulation.ContractReceive != nil && simulation.ContractReceive.DescendantBlocks != nil {
+		result.DescendantBlocks = simulation.ContractReceive.DescendantBlocks
+	}
+	if simulation.ReturnedError != nil {
+		returnedError := simulation.ReturnedError.Error()
+		result.ReturnedError = &returnedError
+	}
+	return result, nil
+}
+
 // Unconfirmed AccountBlocks
 func (l *LedgerApi) GetUnconfirmedBlocksByAddress(address types.Address, pageIndex, pageSize uint32) (*AccountBlockList, error) {
 	if pageSize > RpcMaxPageSize {
@@ -95,8 +236,11 @@ func (l *LedgerApi) GetUnconfirmedBlocksByAddress(address types.Address, pageInd
 }
 
 // AccountBlocks
-func (l *LedgerApi) GetFrontierAccountBlock(address types.Address) (*AccountBlock, error) {
-	accountStore := l.chain.GetFrontierAccountStore(address)
+func (l *LedgerApi) GetFrontierAccountBlock(address types.Address, at *types.HashHeight) (*AccountBlock, error) {
+	accountStore, err := GetAccountStore(l.chain, address, at)
+	if err != nil {
+		return nil, err
+	}
 	block, err := accountStore.Frontier()
 	if err != nil {
 		return nil, err
@@ -119,7 +263,18 @@ func (l *LedgerApi) GetAccountBlockByHash(blockHash types.Hash) (*AccountBlock,
 
 	return ledgerAccountBlockToRpc(l.chain, block)
 }
-func (l *LedgerApi) GetAccountBlocksByHeight(address types.Address, height, count uint64) (*AccountBlockList, error) {
+
+// GetDecodedAccountBlockByHash is GetAccountBlockByHash with the data of embedded contract calls decoded.
+// For embedded receive-blocks, the execution status and the method of the paired send-block are also returned.
+func (l *LedgerApi) GetDecodedAccountBlockByHash(blockHash types.Hash) (*AccountBlock, error) {
+	block, err := l.GetAccountBlockByHash(blockHash)
+	if err != nil || block == nil {
+		return block, err
+	}
+	block.addDecodedData()
+	return block, nil
+}
+func (l *LedgerApi) GetAccountBlocksByHeight(address types.Address, height, count uint64, at *types.HashHeight) (*AccountBlockList, error) {
 	if height == 0 {
 		return nil, ErrHeightParamIsZero
 	}
@@ -127,7 +282,10 @@ func (l *LedgerApi) GetAccountBlocksByHeight(address types.Address, height, coun
 		return nil, ErrCountParamTooBig
 	}
 
-	accountStore := l.chain.GetFrontierAccountStore(address)
+	accountStore, err := GetAccountStore(l.chain, address, at)
+	if err != nil {
+		return nil, err
+	}
 	frontier, err := accountStore.Frontier()
 	if err != nil {
 		l.log.Error("GetAccountBlocksByHeight failed", "reason", err, "method-called", "accountStore.Frontier")
@@ -145,6 +303,12 @@ func (l *LedgerApi) GetAccountBlocksByHeight(address types.Address, height, coun
 		l.log.Error("GetAccountBlocksByHeight failed", "reason", err, "method-called", "GetAccountBlocksByHeight")
 		return nil, err
 	}
+	// account-blocks up to the frontier can only be missing if they were pruned
+	for index, block := range accountBlocks {
+		if block == nil && height+uint64(index) <= frontier.Height {
+			return nil, store.ErrPruned
+		}
+	}
 
 	list, err := ledgerAccountBlocksToRpc(l.chain, accountBlocks)
 	if err != nil {
@@ -157,12 +321,15 @@ func (l *LedgerApi) GetAccountBlocksByHeight(address types.Address, height, coun
 		Count: int(frontier.Height),
 	}, nil
 }
-func (l *LedgerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pageSize uint32) (*AccountBlockList, error) {
+func (l *LedgerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pageSize uint32, at *types.HashHeight) (*AccountBlockList, error) {
 	if pageSize > RpcMaxPageSize {
 		return nil, ErrPageSizeParamTooBig
 	}
 
-	accountStore := l.chain.GetFrontierAccountStore(address)
+	accountStore, err := GetAccountStore(l.chain, address, at)
+	if err != nil {
+		return nil, err
+	}
 	frontier, err := accountStore.Frontier()
 	if err != nil {
 		l.log.Error("GetAccountBlocksByHeight failed", "reason", err, "method-called", "accountStore.Frontier")
@@ -190,7 +357,7 @@ func (l *LedgerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pag
 		}, nil
 	}
 
-	ans, err := l.GetAccountBlocksByHeight(address, uint64(startHeight), uint64(count))
+	ans, err := l.GetAccountBlocksByHeight(address, uint64(startHeight), uint64(count), at)
 	if err != nil {
 		return nil, err
 	}
@@ -200,11 +367,17 @@ func (l *LedgerApi) GetAccountBlocksByPage(address types.Address, pageIndex, pag
 	}
 	return ans, nil
 }
-func (l *LedgerApi) GetAccountInfoByAddress(address types.Address) (*AccountInfo, error) {
+func (l *LedgerApi) GetAccountInfoByAddress(address types.Address, at *types.HashHeight) (*AccountInfo, error) {
 	l.log.Info("GetAccountInfoByAddress")
 
-	momentumStore := l.chain.GetFrontierMomentumStore()
-	accountStore := l.chain.GetFrontierAccountStore(address)
+	momentumStore, err := GetMomentumStore(l.chain, at)
+	if err != nil {
+		return nil, err
+	}
+	accountStore, err := GetAccountStore(l.chain, address, at)
+	if err != nil {
+		return nil, err
+	}
 	frontierAccountBlock, err := accountStore.Frontier()
 	if err != nil {
 		l.log.Error("GetFrontierAccountBlock failed, error is "+err.Error(), "method", "GetAccountInfoByAddress")
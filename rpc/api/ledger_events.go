@@ -0,0 +1,118 @@
+package api
+
+import (
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+type EventList struct {
+	Count int          `json:"count"`
+	List  []*nom.Event `json:"list"`
+}
+
+// EventFilter restricts the events returned by GetEventsByFilter. All non-empty fields need to match.
+type EventFilter struct {
+	Contract *types.Address `json:"contract"`
+	Names    []string       `json:"names"`
+	Caller   *types.Address `json:"caller"`
+}
+
+func (f *EventFilter) Matches(event *nom.Event) bool {
+	if f == nil {
+		return true
+	}
+	if f.Contract != nil && event.Contract != *f.Contract {
+		return false
+	}
+	if f.Caller != nil && event.Caller != *f.Caller {
+		return false
+	}
+	if len(f.Names) != 0 {
+		for _, name := range f.Names {
+			if name == event.Name {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// GetEventsByBlockHash returns the events emitted by an embedded contract call.
+// blockHash can be either the hash of the send-block which called the contract, or the hash of the embedded receive-block.
+func (l *LedgerApi) GetEventsByBlockHash(blockHash types.Hash) (*EventList, error) {
+	momentumStore := l.chain.GetFrontierMomentumStore()
+	events, err := momentumStore.GetEvents(blockHash)
+	if err != nil {
+		l.log.Error("GetEventsByBlockHash failed", "reason", err, "method-called", "momentumStore.GetEvents")
+		return nil, err
+	}
+	if events == nil {
+		block, err := momentumStore.GetBlockWhichReceives(blockHash)
+		if err != nil {
+			l.log.Error("GetEventsByBlockHash failed", "reason", err, "method-called", "momentumStore.GetBlockWhichReceives")
+			return nil, err
+		}
+		if block != nil && types.IsEmbeddedAddress(block.Address) {
+			events, err = momentumStore.GetEvents(block.Hash)
+			if err != nil {
+				l.log.Error("GetEventsByBlockHash failed", "reason", err, "method-called", "momentumStore.GetEvents")
+				return nil, err
+			}
+		}
+	}
+
+	result := &EventList{
+		List: make([]*nom.Event, 0),
+	}
+	result.add(events, nil)
+	return result, nil
+}
+
+// GetEventsByFilter returns the events emitted by the embedded contract calls confirmed in count momentums, starting at height
+func (l *LedgerApi) GetEventsByFilter(height, count uint64, filter *EventFilter) (*EventList, error) {
+	if height == 0 {
+		return nil, ErrHeightParamIsZero
+	}
+	if count > RpcMaxCountSize {
+		return nil, ErrCountParamTooBig
+	}
+
+	momentumStore := l.chain.GetFrontierMomentumStore()
+	momentums, err := momentumStore.GetMomentumsByHeight(height, true, count)
+	if err != nil {
+		l.log.Error("GetEventsByFilter failed", "reason", err, "method-called", "momentumStore.GetMomentumsByHeight")
+		return nil, err
+	}
+
+	result := &EventList{
+		List: make([]*nom.Event, 0),
+	}
+	for _, momentum := range momentums {
+		// the range can go past the frontier momentum
+		if momentum == nil {
+			break
+		}
+		for _, header := range momentum.Content {
+			if !types.IsEmbeddedAddress(header.Address) {
+				continue
+			}
+			events, err := momentumStore.GetEvents(header.Hash)
+			if err != nil {
+				l.log.Error("GetEventsByFilter failed", "reason", err, "method-called", "momentumStore.GetEvents")
+				return nil, err
+			}
+			result.add(events, filter)
+		}
+	}
+	return result, nil
+}
+
+func (list *EventList) add(events []*nom.Event, filter *EventFilter) {
+	for _, event := range events {
+		if filter.Matches(event) {
+			list.List = append(list.List, event)
+		}
+	}
+	list.Count = len(list.List)
+}
@@ -5,8 +5,11 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/embedded"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 )
 
@@ -35,6 +38,15 @@ type AccountBlock struct {
 	TokenInfo          *Token                          `json:"token"`
 	ConfirmationDetail *AccountBlockConfirmationDetail `json:"confirmationDetail"`
 	PairedAccountBlock *AccountBlock                   `json:"pairedAccountBlock"`
+	DecodedData        *DecodedData                    `json:"decodedData,omitempty"`
+}
+
+// DecodedData is the opt-in decoded view of the data of blocks sent to, or received by, embedded contracts
+type DecodedData struct {
+	MethodName string                 `json:"methodName"`
+	Params     map[string]interface{} `json:"params"`
+	// Status is only set for embedded receive-blocks and is either "success" or "fail"
+	Status string `json:"status"`
 }
 type AccountInfo struct {
 	Address        types.Address                             `json:"address"`
@@ -59,6 +71,14 @@ type Token struct {
 	IsUtility          bool                     `json:"isUtility"`
 }
 
+type TransactionSimulation struct {
+	BalanceChanges   map[types.Address]map[types.ZenonTokenStandard]*big.Int `json:"balanceChanges"`
+	DescendantBlocks []*nom.AccountBlock                                     `json:"descendantBlocks"`
+	BasePlasma       uint64                                                  `json:"basePlasma"`
+	UsedPlasma       uint64                                                  `json:"usedPlasma"`
+	ReturnedError    *string                                                 `json:"returnedError"`
+}
+
 type AccountBlockList struct {
 	List  []*AccountBlock `json:"list"`
 	Count int             `json:"count"`
@@ -96,12 +116,12 @@ func (block *AccountBlock) prefetchToken(chain chain.Chain) error {
 	return nil
 }
 func (block *AccountBlock) prefetchPaired(chain chain.Chain) error {
-	store := chain.GetFrontierMomentumStore()
+	momentumStore := chain.GetFrontierMomentumStore()
 	var err error
 	var paired *nom.AccountBlock
 	if block.BlockType == nom.BlockTypeGenesisReceive {
 		genesis := chain.GetGenesisMomentum()
-		frontier, _ := store.GetFrontierMomentum()
+		frontier, _ := momentumStore.GetFrontierMomentum()
 		block.PairedAccountBlock = &AccountBlock{
 			AccountBlock: nom.AccountBlock{
 				BlockType:        nom.BlockTypeContractSend,
@@ -119,9 +139,13 @@ func (block *AccountBlock) prefetchPaired(chain chain.Chain) error {
 	}
 
 	if nom.IsSendBlock(block.BlockType) {
-		paired, err = store.GetBlockWhichReceives(block.Hash)
+		paired, err = momentumStore.GetBlockWhichReceives(block.Hash)
 	} else {
-		paired, err = store.GetAccountBlockByHash(block.FromBlockHash)
+		paired, err = momentumStore.GetAccountBlockByHash(block.FromBlockHash)
+	}
+	// on pruned nodes, the paired account-block can be deleted before the block itself
+	if err == store.ErrPruned {
+		return nil
 	}
 	if err != nil {
 		return err
@@ -141,13 +165,21 @@ func (block *AccountBlock) prefetchPaired(chain chain.Chain) error {
 	return nil
 }
 func (block *AccountBlock) addConfirmationInfo(chain chain.Chain) error {
-	store := chain.GetFrontierMomentumStore()
-	frontier, err := store.GetFrontierMomentum()
+	momentumStore := chain.GetFrontierMomentumStore()
+	frontier, err := momentumStore.GetFrontierMomentum()
 	confirmationHeight, err := chain.GetFrontierMomentumStore().GetBlockConfirmationHeight(block.Hash)
 	if err != nil {
 		return err
 	}
 	confirmedBlock, err := chain.GetFrontierMomentumStore().GetMomentumByHeight(confirmationHeight)
+	if err == store.ErrPruned && frontier != nil {
+		// account-blocks which are still needed outlive their momentum on pruned nodes
+		block.ConfirmationDetail = &AccountBlockConfirmationDetail{
+			NumConfirmations: frontier.Height - confirmationHeight + 1,
+			MomentumHeight:   confirmationHeight,
+		}
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -162,6 +194,33 @@ func (block *AccountBlock) addConfirmationInfo(chain chain.Chain) error {
 
 	return nil
 }
+func (block *AccountBlock) addDecodedData() {
+	switch {
+	case nom.IsSendBlock(block.BlockType):
+		if methodName, params, err := embedded.DecodeEmbeddedCall(block.ToAddress, block.Data); err == nil {
+			block.DecodedData = &DecodedData{
+				MethodName: methodName,
+				Params:     params,
+			}
+			if paired := block.PairedAccountBlock; paired != nil && paired.BlockType == nom.BlockTypeContractReceive {
+				paired.DecodedData = &DecodedData{
+					MethodName: methodName,
+					Status:     vm.StatusToString(paired.Data),
+				}
+			}
+		}
+	case block.BlockType == nom.BlockTypeContractReceive:
+		block.DecodedData = &DecodedData{
+			Status: vm.StatusToString(block.Data),
+		}
+		if paired := block.PairedAccountBlock; paired != nil {
+			paired.addDecodedData()
+			if paired.DecodedData != nil {
+				block.DecodedData.MethodName = paired.DecodedData.MethodName
+			}
+		}
+	}
+}
 func (block *AccountBlock) addAllExtraInfo(chain chain.Chain) error {
 	if err := block.prefetchPaired(chain); err != nil {
 		return err
@@ -80,11 +80,13 @@ type Peer struct {
 	PublicKey string `json:"publicKey"`
 	IP        string `json:"ip"`
 	Name      string `json:"name"`
+	Score     int    `json:"score"`
 }
 type NetworkInfoResponse struct {
-	NumPeers int     `json:"numPeers"`
-	Peers    []*Peer `json:"peers"`
-	Self     *Peer   `json:"self"`
+	NumPeers    int           `json:"numPeers"`
+	Peers       []*Peer       `json:"peers"`
+	Self        *Peer         `json:"self"`
+	BannedPeers []*BannedPeer `json:"bannedPeers"`
 }
 
 func p2pPeerToPeer(peer *p2p.Peer) (*Peer, error) {
@@ -105,6 +107,13 @@ func selfToPeer(node *discover.Node) *Peer {
 }
 
 func (api *StatsApi) NetworkInfo() (*NetworkInfoResponse, error) {
+	scores := make(map[discover.NodeID]int)
+	if pm := api.z.Protocol(); pm != nil {
+		for _, score := range pm.PeerScores() {
+			scores[score.ID] = score.Score
+		}
+	}
+
 	peersRaw := api.p2p.Peers()
 	peers := make([]*Peer, 0, len(peersRaw))
 	for _, raw := range peersRaw {
@@ -112,13 +121,15 @@ func (api *StatsApi) NetworkInfo() (*NetworkInfoResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		peer.Score = scores[raw.ID()]
 		peers = append(peers, peer)
 	}
 
 	return &NetworkInfoResponse{
-		NumPeers: api.p2p.PeerCount(),
-		Peers:    peers,
-		Self:     selfToPeer(api.p2p.Self()),
+		NumPeers:    api.p2p.PeerCount(),
+		Peers:       peers,
+		Self:        selfToPeer(api.p2p.Self()),
+		BannedPeers: toBannedPeers(api.p2p.BannedPeers()),
 	}, nil
 }
 
@@ -16,6 +16,7 @@ import (
 const (
 	acChanSize    = 100
 	mChanSize     = 100
+	evChanSize    = 100
 	installSize   = 100
 	uninstallSize = 100
 )
@@ -66,6 +67,7 @@ type Server struct {
 	uninstallCh   chan *Subscription // remove subscription
 	acCh          chan []*AccountBlock
 	mCh           chan *Momentum
+	evCh          chan []*nom.AccountBlock // contract receive-blocks, their events are loaded by the worker
 	stopped       chan struct{}
 	subscriptions map[SubscriptionType]map[rpc.ID]*Subscription
 
@@ -86,6 +88,7 @@ func GetSubscribeServer(chain chain.Chain) *Server {
 
 			acCh:          make(chan []*AccountBlock, acChanSize),
 			mCh:           make(chan *Momentum, mChanSize),
+			evCh:          make(chan []*nom.AccountBlock, evChanSize),
 			uninstallCh:   make(chan *Subscription, uninstallSize),
 			stopped:       make(chan struct{}),
 			subscriptions: make(map[SubscriptionType]map[rpc.ID]*Subscription),
@@ -157,6 +160,22 @@ func (s *Server) InsertMomentum(detailed *nom.DetailedMomentum) {
 	default:
 		s.log.Error("can't insert account-blocks for broadcast", "reason", "channel is full", "momentum-identifier", detailed.Momentum.Identifier())
 	}
+
+	// only contract receive-blocks emit events, loading them is left to the worker
+	receives := make([]*nom.AccountBlock, 0)
+	for _, block := range detailed.AccountBlocks {
+		if block.BlockType == nom.BlockTypeContractReceive {
+			receives = append(receives, block)
+		}
+	}
+	if len(receives) == 0 {
+		return
+	}
+	select {
+	case s.evCh <- receives:
+	default:
+		s.log.Error("can't insert events for broadcast", "reason", "channel is full", "momentum-identifier", detailed.Momentum.Identifier())
+	}
 	return
 }
 func (s *Server) DeleteMomentum(*nom.DetailedMomentum) {
@@ -181,6 +200,8 @@ func (s *Server) work() {
 			s.broadcastMomentums(momentums)
 		case blocks := <-s.acCh:
 			s.broadcastBlocks(blocks)
+		case blocks := <-s.evCh:
+			s.broadcastEvents(blocks)
 		}
 	}
 }
@@ -259,6 +280,46 @@ func (s *Server) broadcastBlocks(blocks []*AccountBlock) {
 	s.log.Info("finish broadcasting account-blocks", "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
 }
 
+// broadcastEvents loads the events of the contract receive-blocks from the momentum store,
+// only if there are event subscriptions
+func (s *Server) broadcastEvents(blocks []*nom.AccountBlock) {
+	if len(s.subscriptions[EventsSubscription]) == 0 && len(s.subscriptions[EventsSubscriptionByContract]) == 0 {
+		return
+	}
+	startTime := common.Clock.Now()
+	stats := &BroadcastStats{}
+
+	momentumStore := s.chain.GetFrontierMomentumStore()
+	events := make([]*nom.Event, 0)
+	for _, block := range blocks {
+		blockEvents, err := momentumStore.GetEvents(block.Hash)
+		if err != nil {
+			s.log.Error("can't get events for broadcast", "reason", err, "block-hash", block.Hash)
+			continue
+		}
+		events = append(events, blockEvents...)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	byContract := make(map[types.Address][]*nom.Event)
+	for _, event := range events {
+		byContract[event.Contract] = append(byContract[event.Contract], event)
+	}
+
+	for _, f := range s.subscriptions[EventsSubscription] {
+		s.broadcast(f, events, stats)
+	}
+	for _, f := range s.subscriptions[EventsSubscriptionByContract] {
+		if events, ok := byContract[f.options.address]; ok {
+			s.broadcast(f, events, stats)
+		}
+	}
+
+	s.log.Info("finish broadcasting events", "elapsed", common.Clock.Now().Sub(startTime), "stats", stats)
+}
+
 func (s *Api) subscribe(ctx context.Context, options *subscriptionOptions) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
@@ -285,3 +346,11 @@ func (s *Api) UnreceivedAccountBlocksByAddress(ctx context.Context, address type
 	s.log.Info("new subscription", "type", "UnreceivedAccountBlocksByAddress")
 	return s.subscribe(ctx, NewToUnreceivedBlocksSubscription(address))
 }
+func (s *Api) Events(ctx context.Context) (*rpc.Subscription, error) {
+	s.log.Info("new subscription", "type", "Events")
+	return s.subscribe(ctx, NewEventsSubscription())
+}
+func (s *Api) EventsByContract(ctx context.Context, contract types.Address) (*rpc.Subscription, error) {
+	s.log.Info("new subscription", "type", "EventsByContract")
+	return s.subscribe(ctx, NewEventsByContractSubscription(contract))
+}
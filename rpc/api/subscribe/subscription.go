@@ -18,6 +18,8 @@ const (
 	AccountBlocksSubscriptionByAddress
 	UnreceivedAccountBlocksSubscriptionByAddress
 	MomentumsSubscription
+	EventsSubscription
+	EventsSubscriptionByContract
 	LastSubscriptionType
 )
 
@@ -49,6 +51,14 @@ func NewToUnreceivedBlocksSubscription(addr types.Address) *subscriptionOptions
 func NewMomentumsSubscription() *subscriptionOptions {
 	return newSubscription(MomentumsSubscription)
 }
+func NewEventsSubscription() *subscriptionOptions {
+	return newSubscription(EventsSubscription)
+}
+func NewEventsByContractSubscription(contract types.Address) *subscriptionOptions {
+	sub := newSubscription(EventsSubscriptionByContract)
+	sub.address = contract
+	return sub
+}
 
 type Subscription struct {
 	log      log15.Logger
@@ -5,6 +5,7 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/vm_context"
 )
@@ -27,19 +28,75 @@ func GetRange(index, count, listLen uint32) (uint32, uint32) {
 }
 
 func GetFrontierContext(c chain.Chain, addr types.Address) (*nom.Momentum, vm_context.AccountVmContext, error) {
-	store := c.GetFrontierMomentumStore()
+	return GetContext(c, addr, nil)
+}
+
+// GetMomentumStore returns the momentum store as of the momentum identified by at.
+// at can specify the hash, the height or both. A nil at returns the frontier momentum store.
+func GetMomentumStore(c chain.Chain, at *types.HashHeight) (store.Momentum, error) {
+	frontierStore := c.GetFrontierMomentumStore()
+	if at == nil {
+		return frontierStore, nil
+	}
+
+	var momentum *nom.Momentum
+	var err error
+	if !at.Hash.IsZero() {
+		momentum, err = frontierStore.GetMomentumByHash(at.Hash)
+	} else if at.Height != 0 {
+		momentum, err = frontierStore.GetMomentumByHeight(at.Height)
+	} else {
+		return nil, ErrMomentumParamIsZero
+	}
+	if err != nil {
+		return nil, err
+	}
+	if momentum == nil || (at.Height != 0 && momentum.Height != at.Height) {
+		return nil, ErrMomentumNotFound
+	}
+
+	momentumStore := c.GetMomentumStore(momentum.Identifier())
+	if momentumStore == nil {
+		return nil, ErrMomentumNotFound
+	}
+	return momentumStore, nil
+}
+
+// GetAccountStore returns the account store as of the momentum identified by at.
+// A nil at returns the frontier account store, which includes unconfirmed account-blocks.
+func GetAccountStore(c chain.Chain, addr types.Address, at *types.HashHeight) (store.Account, error) {
+	if at == nil {
+		return c.GetFrontierAccountStore(addr), nil
+	}
+	momentumStore, err := GetMomentumStore(c, at)
+	if err != nil {
+		return nil, err
+	}
+	return momentumStore.GetAccountStore(addr), nil
+}
+
+// GetContext returns the context of addr as of the momentum identified by at, or the frontier context if at is nil.
+func GetContext(c chain.Chain, addr types.Address, at *types.HashHeight) (*nom.Momentum, vm_context.AccountVmContext, error) {
+	momentumStore, err := GetMomentumStore(c, at)
+	if err != nil {
+		return nil, nil, err
+	}
+	accountStore, err := GetAccountStore(c, addr, at)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	frontier, err := store.GetFrontierMomentum()
+	momentum, err := momentumStore.GetFrontierMomentum()
 	if err != nil {
 		return nil, nil, err
 	}
 
 	context := vm_context.NewAccountContext(
-		store,
-		c.GetFrontierAccountStore(addr),
+		momentumStore,
+		accountStore,
 		nil,
 	)
-	return frontier, context, nil
+	return momentum, context, nil
 }
 
 func checkTokenIdValid(chain chain.Chain, ts *types.ZenonTokenStandard) error {
@@ -79,6 +79,37 @@ func getApi(z zenon.Zenon, p2p *p2p.Server, apiModule string) []rpc.API {
 				Service:   embedded.NewAcceleratorApi(z),
 				Public:    true,
 			},
+			{
+				Namespace: "embedded.multisig",
+				Version:   "1.0",
+				Service:   embedded.NewMultisigApi(z),
+				Public:    true,
+			},
+			{
+				Namespace: "embedded.vesting",
+				Version:   "1.0",
+				Service:   embedded.NewVestingApi(z),
+				Public:    true,
+			},
+			{
+				Namespace: "embedded.htlc",
+				Version:   "1.0",
+				Service:   embedded.NewHtlcApi(z),
+				Public:    true,
+			},
+		}
+	case "indexer":
+		// the indexer is optional
+		if z.Indexer() == nil {
+			return []rpc.API{}
+		}
+		return []rpc.API{
+			{
+				Namespace: "indexer",
+				Version:   "1.0",
+				Service:   api.NewIndexerApi(z),
+				Public:    true,
+			},
 		}
 	case "stats":
 		return []rpc.API{
@@ -89,6 +120,15 @@ func getApi(z zenon.Zenon, p2p *p2p.Server, apiModule string) []rpc.API {
 				Public:    true,
 			},
 		}
+	case "admin":
+		return []rpc.API{
+			{
+				Namespace: "admin",
+				Version:   "1.0",
+				Service:   api.NewAdminApi(p2p),
+				Public:    false,
+			},
+		}
 	default:
 		return []rpc.API{}
 	}
@@ -101,5 +141,5 @@ func GetApis(z zenon.Zenon, p2p *p2p.Server, apiModule ...string) []rpc.API {
 	return apis
 }
 func GetPublicApis(z zenon.Zenon, p2p *p2p.Server) []rpc.API {
-	return GetApis(z, p2p, "ledger", "ledgerSubscribe", "embedded", "stats")
+	return GetApis(z, p2p, "ledger", "ledgerSubscribe", "embedded", "stats", "indexer")
 }
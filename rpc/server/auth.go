@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AuthKey grants access to the namespaces in Endpoints, for example `ledger` or `embedded.*`.
+// Requests present the key in the `Authorization: Bearer <token>` header, where token is either
+// the APIKey or a JWT signed with JWTSecret using HS256.
+type AuthKey struct {
+	APIKey    string
+	JWTSecret string
+
+	Endpoints []string
+}
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+	errExpiredToken = errors.New("expired bearer token")
+	errEarlyToken   = errors.New("bearer token is not valid yet")
+)
+
+type authScopeKey struct{}
+
+// authScope is the list of namespaces the connection is allowed to call.
+// A nil scope means the server doesn't require authentication.
+type authScope struct {
+	endpoints []string
+}
+
+func (s *authScope) allows(method string) bool {
+	if s == nil {
+		return true
+	}
+	endIndex := strings.LastIndex(method, serviceMethodSeparator)
+	if endIndex == -1 {
+		return false
+	}
+	namespace := method[:endIndex]
+	for _, endpoint := range s.endpoints {
+		if endpoint == "*" || endpoint == namespace {
+			return true
+		}
+		if strings.HasSuffix(endpoint, ".*") && strings.HasPrefix(namespace, endpoint[:len(endpoint)-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewAuthHandler only lets through requests authenticated with one of keys.
+// The namespaces of the matched key are enforced by the handler of each call.
+// Authentication is disabled if there are no keys.
+func NewAuthHandler(keys []AuthKey, next http.Handler) http.Handler {
+	if len(keys) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, err := authenticate(keys, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		ctx := context.WithValue(r.Context(), authScopeKey{}, &authScope{endpoints: key.Endpoints})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func authenticate(keys []AuthKey, r *http.Request) (*AuthKey, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, errMissingToken
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+
+	for i := range keys {
+		if keys[i].APIKey != "" && subtle.ConstantTimeCompare([]byte(keys[i].APIKey), []byte(token)) == 1 {
+			return &keys[i], nil
+		}
+	}
+	for i := range keys {
+		if keys[i].JWTSecret == "" {
+			continue
+		}
+		err := verifyJWT(token, []byte(keys[i].JWTSecret), time.Now())
+		if err == errInvalidToken {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &keys[i], nil
+	}
+	return nil, errInvalidToken
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+type jwtClaims struct {
+	ExpiresAt *int64 `json:"exp"`
+	NotBefore *int64 `json:"nbf"`
+}
+
+// verifyJWT checks the HS256 signature of token and the optional exp and nbf claims.
+// errInvalidToken is returned if the token was not signed with secret.
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errInvalidToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errInvalidToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return errInvalidToken
+	}
+
+	header := new(jwtHeader)
+	if err := decodeJWTPart(parts[0], header); err != nil || header.Alg != "HS256" {
+		return errInvalidToken
+	}
+	claims := new(jwtClaims)
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return errInvalidToken
+	}
+	if claims.ExpiresAt != nil && now.Unix() >= *claims.ExpiresAt {
+		return errExpiredToken
+	}
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return errEarlyToken
+	}
+	return nil
+}
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,246 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// makeJWT returns a token with header and claims, signed with secret using HS256
+func makeJWT(secret string, header, claims string) string {
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	const secret = "jwt-secret"
+	const hs256 = `{"alg":"HS256","typ":"JWT"}`
+	now := time.Unix(1000000000, 0)
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"no claims", makeJWT(secret, hs256, `{}`), nil},
+		{"valid exp and nbf", makeJWT(secret, hs256, `{"exp":1000000001,"nbf":1000000000}`), nil},
+		{"expired", makeJWT(secret, hs256, `{"exp":999999999}`), errExpiredToken},
+		{"expires now", makeJWT(secret, hs256, `{"exp":1000000000}`), errExpiredToken},
+		{"not valid yet", makeJWT(secret, hs256, `{"nbf":1000000001}`), errEarlyToken},
+		{"other secret", makeJWT("other-secret", hs256, `{}`), errInvalidToken},
+		{"other algorithm", makeJWT(secret, `{"alg":"none"}`, `{}`), errInvalidToken},
+		{"malformed claims", makeJWT(secret, hs256, `{"exp":"soon"}`), errInvalidToken},
+		{"missing signature", "eyJhbGciOiJIUzI1NiJ9.e30", errInvalidToken},
+		{"malformed signature", "eyJhbGciOiJIUzI1NiJ9.e30.!!!", errInvalidToken},
+		{"not a jwt", "api-key", errInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := verifyJWT(tt.token, []byte(secret), now); err != tt.err {
+				t.Fatalf("have %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	keys := []AuthKey{
+		{APIKey: "first-api-key", Endpoints: []string{"ledger"}},
+		{JWTSecret: "jwt-secret", Endpoints: []string{"embedded.*"}},
+	}
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Hour).Unix()
+	validJWT := makeJWT("jwt-secret", `{"alg":"HS256"}`, `{"exp":`+jsonInt(future)+`}`)
+	expiredJWT := makeJWT("jwt-secret", `{"alg":"HS256"}`, `{"exp":`+jsonInt(past)+`}`)
+
+	tests := []struct {
+		name   string
+		header string
+		key    int
+		err    error
+	}{
+		{"api key", "Bearer first-api-key", 0, nil},
+		{"api key with spaces", "Bearer  first-api-key ", 0, nil},
+		{"api key prefix", "Bearer first-api", -1, errInvalidToken},
+		{"longer api key", "Bearer first-api-key2", -1, errInvalidToken},
+		{"jwt", "Bearer " + validJWT, 1, nil},
+		{"expired jwt", "Bearer " + expiredJWT, -1, errExpiredToken},
+		{"missing header", "", -1, errMissingToken},
+		{"basic auth", "Basic Zmlyc3QtYXBpLWtleQ==", -1, errMissingToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			key, err := authenticate(keys, r)
+			if err != tt.err {
+				t.Fatalf("have %v, want %v", err, tt.err)
+			}
+			if tt.key == -1 && key != nil {
+				t.Fatalf("authenticated with key %v", key)
+			}
+			if tt.key != -1 && key != &keys[tt.key] {
+				t.Fatalf("authenticated with key %v, want %v", key, &keys[tt.key])
+			}
+		})
+	}
+}
+
+func jsonInt(v int64) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func TestAuthScope_Allows(t *testing.T) {
+	tests := []struct {
+		endpoints []string
+		method    string
+		allowed   bool
+	}{
+		{[]string{"ledger"}, "ledger.getFrontierMomentum", true},
+		{[]string{"ledger"}, "stats.networkInfo", false},
+		{[]string{"ledger"}, "ledger.subscribe", true},
+		{[]string{"ledger"}, "ledgerx.getFrontierMomentum", false},
+		{[]string{"*"}, "stats.networkInfo", true},
+		{[]string{"embedded.*"}, "embedded.pillar.getAll", true},
+		{[]string{"embedded.*"}, "embedded.token.subscribe", true},
+		{[]string{"embedded.*"}, "embedded.getAll", false},
+		{[]string{"embedded.*"}, "embeddedx.pillar.getAll", false},
+		{[]string{"embedded.pillar"}, "embedded.pillar.getAll", true},
+		{[]string{"embedded.pillar"}, "embedded.sentinel.getAll", false},
+		{[]string{"ledger", "stats"}, "stats.networkInfo", true},
+		{[]string{"*"}, "nonamespace", false},
+		{nil, "ledger.getFrontierMomentum", false},
+	}
+	for _, tt := range tests {
+		scope := &authScope{endpoints: tt.endpoints}
+		if allowed := scope.allows(tt.method); allowed != tt.allowed {
+			t.Errorf("endpoints %v method %v: have %v, want %v", tt.endpoints, tt.method, allowed, tt.allowed)
+		}
+	}
+
+	// without authentication everything is allowed
+	var scope *authScope
+	if !scope.allows("stats.networkInfo") {
+		t.Errorf("nil scope doesn't allow calls")
+	}
+}
+
+type authTestService struct{}
+
+func (authTestService) Echo(s string) string { return s }
+
+func TestAuthHandler(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("ledger", authTestService{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName("stats", authTestService{}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(NewAuthHandler([]AuthKey{{APIKey: "api-key", Endpoints: []string{"ledger"}}}, server))
+	defer httpServer.Close()
+
+	post := func(token, body string) (int, string) {
+		r, err := http.NewRequest(http.MethodPost, httpServer.URL, bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", contentType)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(bytes.TrimSpace(data))
+	}
+
+	tests := []struct {
+		name     string
+		token    string
+		body     string
+		status   int
+		response string
+	}{
+		{
+			"missing token",
+			"",
+			`{"jsonrpc":"2.0","id":1,"method":"ledger.echo","params":["a"]}`,
+			http.StatusUnauthorized,
+			errMissingToken.Error(),
+		},
+		{
+			"invalid token",
+			"other-key",
+			`{"jsonrpc":"2.0","id":1,"method":"ledger.echo","params":["a"]}`,
+			http.StatusUnauthorized,
+			errInvalidToken.Error(),
+		},
+		{
+			"allowed call",
+			"api-key",
+			`{"jsonrpc":"2.0","id":1,"method":"ledger.echo","params":["a"]}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","id":1,"result":"a"}`,
+		},
+		{
+			"denied call",
+			"api-key",
+			`{"jsonrpc":"2.0","id":1,"method":"stats.echo","params":["a"]}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"not authorized to call the method stats.echo"}}`,
+		},
+		{
+			"batch with a denied call",
+			"api-key",
+			`[{"jsonrpc":"2.0","id":1,"method":"ledger.echo","params":["a"]},{"jsonrpc":"2.0","id":2,"method":"stats.echo","params":["b"]}]`,
+			http.StatusOK,
+			`[{"jsonrpc":"2.0","id":1,"result":"a"},{"jsonrpc":"2.0","id":2,"error":{"code":-32001,"message":"not authorized to call the method stats.echo"}}]`,
+		},
+		{
+			// allowed subscriptions pass the scope check and fail later, since HTTP doesn't support notifications
+			"allowed subscription",
+			"api-key",
+			`{"jsonrpc":"2.0","id":1,"method":"ledger.subscribe","params":["updates"]}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"` + ErrNotificationsUnsupported.Error() + `"}}`,
+		},
+		{
+			"denied subscription",
+			"api-key",
+			`{"jsonrpc":"2.0","id":1,"method":"stats.subscribe","params":["updates"]}`,
+			http.StatusOK,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32001,"message":"not authorized to call the method stats.subscribe"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := post(tt.token, tt.body)
+			if status != tt.status {
+				t.Fatalf("status mismatch: have %v, want %v", status, tt.status)
+			}
+			if response != tt.response {
+				t.Fatalf("response mismatch:\nhave %v\nwant %v", response, tt.response)
+			}
+		})
+	}
+}
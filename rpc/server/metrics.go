@@ -18,22 +18,37 @@ package server
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/metrics"
 )
 
 var (
-	rpcRequestGauge        = metrics.NewRegisteredGauge("rpc/requests", nil)
-	successfulRequestGauge = metrics.NewRegisteredGauge("rpc/success", nil)
-	failedReqeustGauge     = metrics.NewRegisteredGauge("rpc/failure", nil)
-	rpcServingTimer        = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+	rpcRequestGauge        metrics.Gauge = metrics.NilGauge{}
+	successfulRequestGauge metrics.Gauge = metrics.NilGauge{}
+	failedRequestGauge     metrics.Gauge = metrics.NilGauge{}
+	rpcServingTimer        metrics.Timer = metrics.NilTimer{}
+
+	registerMetrics sync.Once
 )
 
+// RegisterMetrics creates the request gauges and the serving timer of all methods.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		rpcRequestGauge = metrics.GetOrRegisterGauge("rpc/requests", nil)
+		successfulRequestGauge = metrics.GetOrRegisterGauge("rpc/success", nil)
+		failedRequestGauge = metrics.GetOrRegisterGauge("rpc/failure", nil)
+		rpcServingTimer = metrics.GetOrRegisterTimer("rpc/duration/all", nil)
+	})
+}
+
 func newRPCServingTimer(method string, valid bool) metrics.Timer {
 	flag := "success"
 	if !valid {
 		flag = "failure"
 	}
-	m := fmt.Sprintf("rpc/duration/%s/%s", method, flag)
+	// method names contain dots, which are not allowed in prometheus metric names
+	m := fmt.Sprintf("rpc/duration/%s/%s", strings.Replace(method, ".", "_", -1), flag)
 	return metrics.GetOrRegisterTimer(m, nil)
 }
@@ -60,7 +60,12 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			return
 		}
 		codec := newWebsocketCodec(conn)
-		s.ServeCodec(codec, 0)
+		// only keep the authentication scope, the request context ends when the connection is upgraded
+		connCtx := context.Background()
+		if scope := r.Context().Value(authScopeKey{}); scope != nil {
+			connCtx = context.WithValue(connCtx, authScopeKey{}, scope)
+		}
+		s.serveCodec(connCtx, codec)
 	})
 }
 
@@ -150,6 +150,9 @@ func (abv *accountBlockVerifier) all() error {
 	if err := abv.sequencer(); err != nil {
 		return err
 	}
+	if err := abv.sporkRules(); err != nil {
+		return err
+	}
 	return nil
 }
 func (abv *accountBlockVerifier) version() error {
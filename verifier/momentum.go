@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/inconshreveable/log15"
-	"github.com/pkg/errors"
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
@@ -168,7 +167,7 @@ func (rmv *rawMomentumVerifier) content() error {
 
 	// sizes are the same
 	if len(blocksLookup) != len(rmv.momentum.Content) {
-		return errors.Errorf("momentum content size is different than the size of the prefetched account-blocks")
+		return fmt.Errorf("%w - content size is different than the size of the prefetched account-blocks", ErrMContentMismatch)
 	}
 
 	// account identifiers make sense when 'applying' blocks; i.e: all pairs of (previous, identifier) match
@@ -196,11 +195,11 @@ func (rmv *rawMomentumVerifier) content() error {
 			continue
 		}
 		if ok == false {
-			return errors.Errorf("momentum content header is not present in prefetched account-blocks")
+			return fmt.Errorf("%w - content header is not present in prefetched account-blocks", ErrMContentMismatch)
 		}
 
 		if block.Previous() != previous {
-			return errors.Errorf("gap in previous Expected %v but got %v", previous, block.Previous())
+			return fmt.Errorf("%w - gap in previous Expected %v but got %v", ErrMContentMismatch, previous, block.Previous())
 		}
 
 		heads[header.Address] = block.Identifier()
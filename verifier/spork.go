@@ -0,0 +1,36 @@
+package verifier
+
+import (
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+// AccountBlockRule is an account-block verification enforced once Spork is enforced at the momentum acknowledged by the block
+type AccountBlockRule struct {
+	Spork  *types.ImplementedSpork
+	Verify func(block *nom.AccountBlock, momentumStore store.Momentum) error
+}
+
+// AccountBlockRules is the registry of the account-block verifications gated by sporks.
+// Rules are checked in order, after all the other verifications passed.
+var AccountBlockRules = []*AccountBlockRule{}
+
+func (abv *accountBlockVerifier) sporkRules() error {
+	if len(AccountBlockRules) == 0 {
+		return nil
+	}
+	enforced, err := abv.momentumStore.GetEnforcedSporks()
+	if err != nil {
+		return InternalError(err)
+	}
+	for _, rule := range AccountBlockRules {
+		if !enforced[rule.Spork.SporkId] {
+			continue
+		}
+		if err := rule.Verify(abv.block, abv.momentumStore); err != nil {
+			return err
+		}
+	}
+	return nil
+}
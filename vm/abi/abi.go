@@ -80,6 +80,23 @@ func (abi ABIContract) UnpackEmptyMethod(name string, input []byte) (err error)
 	}
 	return errCouldNotLocateNamedMethod
 }
+
+// UnpackMethodValues finds the method called by input and returns its arguments, by name
+func (abi ABIContract) UnpackMethodValues(input []byte) (*Method, map[string]interface{}, error) {
+	method, err := abi.MethodById(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	named := make(map[string]interface{}, len(values))
+	for index, value := range values {
+		named[method.Inputs[index].Name] = value
+	}
+	return method, named, nil
+}
 func (abi ABIContract) UnpackVariable(v interface{}, name string, input []byte) (err error) {
 	if len(input) == 0 {
 		return errEmptyInput
@@ -35,6 +35,19 @@ var (
 	AcceleratorProjectVotingPeriod        = 14 * PhaseTimeUnit
 	MaxBlocksPerUpdate                    = 40
 
+	/// === Multisig constants ===
+
+	MultisigMaxSigners = 10
+
+	/// === Vesting constants ===
+
+	VestingDurationMax int64 = 10 * 365 * SecsInDay
+
+	/// === Htlc constants ===
+
+	HtlcPreimageMinSize = 1
+	HtlcPreimageMaxSize = 255
+
 	/// ==== Pillar constants ===
 
 	PillarStakeAmount = big.NewInt(15e3 * Decimals)
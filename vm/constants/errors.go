@@ -29,15 +29,36 @@ var (
 	ErrAcceleratorInvalidFunds = errors.New("invalid accelerator funds")
 	ErrInvalidDescription      = errors.New("invalid description")
 
+	// Multisig
+	ErrMultisigInvalidSigners  = errors.New("invalid multisig signers or threshold")
+	ErrMultisigAlreadyApproved = errors.New("proposal is already approved by signer")
+	ErrMultisigNotPending      = errors.New("proposal is not pending")
+
+	// Vesting
+	ErrInvalidVestingSchedule = errors.New("invalid vesting schedule")
+
+	// Htlc
+	ErrInvalidHashType       = errors.New("invalid hash type")
+	ErrInvalidHashDigest     = errors.New("invalid hash digest")
+	ErrInvalidPreimage       = errors.New("invalid preimage")
+	ErrInvalidExpirationTime = errors.New("invalid expiration time")
+	ErrHtlcExpired           = errors.New("htlc is expired")
+	ErrHtlcNotExpired        = errors.New("htlc is not expired yet")
+
 	// Pillar
 	ErrInvalidName = errors.New("invalid name")
 	ErrNotUnique   = errors.New("name or producing address not unique")
 	ErrNotActive   = errors.New("pillar is not active")
 
+	ErrPayoutConfigLocked = errors.New("pillar payout config is locked until the next epoch")
+
 	// Token
 	ErrIDNotUnique        = errors.New("there is another token with the same id")
 	ErrTokenInvalidText   = errors.New("invalid token name/symbol/domain/decimals")
 	ErrTokenInvalidAmount = errors.New("invalid token total/max supply")
+	ErrTokenAddressFrozen = errors.New("address is frozen for this token")
+	ErrTokenNotAllowed    = errors.New("address is not allowed to receive this token")
+	ErrTokenMintCapped    = errors.New("mint cap per epoch reached for this token")
 
 	// Stake
 	RevokeNotDue            = errors.New("staking period still active")
@@ -1,6 +1,10 @@
 package constants
 
-import "math/big"
+import (
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/common/types"
+)
 
 // PlasmaTable is used to query plasma used by op code and transactions
 type PlasmaTable struct {
@@ -13,7 +17,17 @@ type PlasmaTable struct {
 	EmbeddedWDoubleWithdraw uint64
 }
 
+// PlasmaTableUpgrade replaces the plasma table once Spork is enforced
+type PlasmaTableUpgrade struct {
+	Spork *types.ImplementedSpork
+	Table *PlasmaTable
+}
+
 var (
+	// PlasmaTableUpgrades is the registry of the plasma tables gated by sporks.
+	// The last upgrade which is enforced replaces AlphanetPlasmaTable.
+	PlasmaTableUpgrades = []*PlasmaTableUpgrade{}
+
 	AlphanetPlasmaTable = PlasmaTable{
 		TxPlasma:     AccountBlockBasePlasma,
 		TxDataPlasma: ABByteDataPlasma,
@@ -0,0 +1,147 @@
+package definition
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/abi"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+const (
+	HashTypeSHA3 uint8 = iota
+	HashTypeSHA256
+	HashTypeNotValid
+
+	jsonHtlc = `
+	[
+		{"type":"function","name":"Create", "inputs":[
+			{"name":"hashLocked","type":"address"},
+			{"name":"expirationTime","type":"int64"},
+			{"name":"hashType","type":"uint8"},
+			{"name":"keyMaxSize","type":"uint8"},
+			{"name":"hashLock","type":"bytes"}
+		]},
+		{"type":"function","name":"Reclaim", "inputs":[
+			{"name":"id","type":"hash"}
+		]},
+		{"type":"function","name":"Unlock", "inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"preimage","type":"bytes"}
+		]},
+
+		{"type":"variable","name":"htlcInfo","inputs":[
+			{"name":"timeLocked","type":"address"},
+			{"name":"hashLocked","type":"address"},
+			{"name":"tokenStandard","type":"tokenStandard"},
+			{"name":"amount","type":"uint256"},
+			{"name":"expirationTime","type":"int64"},
+			{"name":"hashType","type":"uint8"},
+			{"name":"keyMaxSize","type":"uint8"},
+			{"name":"hashLock","type":"bytes"}
+		]}
+	]`
+
+	CreateHtlcMethodName  = "Create"
+	ReclaimHtlcMethodName = "Reclaim"
+	UnlockHtlcMethodName  = "Unlock"
+
+	htlcInfoVariableName = "htlcInfo"
+
+	_ byte = iota
+	htlcInfoKeyPrefix
+)
+
+var (
+	ABIHtlc = abi.JSONToABIContract(strings.NewReader(jsonHtlc))
+)
+
+type CreateHtlcParam struct {
+	HashLocked     types.Address
+	ExpirationTime int64
+	HashType       uint8
+	KeyMaxSize     uint8
+	HashLock       []byte
+}
+
+type UnlockHtlcParam struct {
+	Id       types.Hash
+	Preimage []byte
+}
+
+// HtlcInfo locks Amount until either the HashLocked address unlocks it with the preimage of HashLock,
+// or the ExpirationTime passes and the TimeLocked address, which created the htlc, reclaims it.
+type HtlcInfo struct {
+	Id             types.Hash               `json:"id"`
+	TimeLocked     types.Address            `json:"timeLocked"`
+	HashLocked     types.Address            `json:"hashLocked"`
+	TokenStandard  types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount         *big.Int                 `json:"amount"`
+	ExpirationTime int64                    `json:"expirationTime"`
+	HashType       uint8                    `json:"hashType"`
+	KeyMaxSize     uint8                    `json:"keyMaxSize"`
+	HashLock       []byte                   `json:"hashLock"`
+}
+
+func (htlc *HtlcInfo) Save(context db.DB) {
+	common.DealWithErr(context.Put(htlc.Key(), htlc.Data()))
+}
+func (htlc *HtlcInfo) Delete(context db.DB) {
+	common.DealWithErr(context.Delete(htlc.Key()))
+}
+func (htlc *HtlcInfo) Key() []byte {
+	return common.JoinBytes([]byte{htlcInfoKeyPrefix}, htlc.Id.Bytes())
+}
+func (htlc *HtlcInfo) Data() []byte {
+	return ABIHtlc.PackVariablePanic(
+		htlcInfoVariableName,
+		htlc.TimeLocked,
+		htlc.HashLocked,
+		htlc.TokenStandard,
+		htlc.Amount,
+		htlc.ExpirationTime,
+		htlc.HashType,
+		htlc.KeyMaxSize,
+		htlc.HashLock,
+	)
+}
+
+func parseHtlcInfo(key, data []byte) *HtlcInfo {
+	htlc := new(HtlcInfo)
+	ABIHtlc.UnpackVariablePanic(htlc, htlcInfoVariableName, data)
+	common.DealWithErr(htlc.Id.SetBytes(key[1:]))
+	return htlc
+}
+
+func GetHtlcInfo(context db.DB, id types.Hash) (*HtlcInfo, error) {
+	key := (&HtlcInfo{Id: id}).Key()
+	data, err := context.Get(key)
+	common.DealWithErr(err)
+	if len(data) == 0 {
+		return nil, constants.ErrDataNonExistent
+	} else {
+		return parseHtlcInfo(key, data), nil
+	}
+}
+
+func GetHtlcInfoList(context db.DB) ([]*HtlcInfo, error) {
+	iterator := context.NewIterator([]byte{htlcInfoKeyPrefix})
+	defer iterator.Release()
+	htlcList := make([]*HtlcInfo, 0)
+
+	for {
+		if !iterator.Next() {
+			common.DealWithErr(iterator.Error())
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		htlcList = append(htlcList, parseHtlcInfo(iterator.Key(), iterator.Value()))
+	}
+
+	return htlcList, nil
+}
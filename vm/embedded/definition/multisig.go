@@ -0,0 +1,295 @@
+package definition
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/abi"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+const (
+	PendingProposalStatus uint8 = iota
+	ExecutedProposalStatus
+
+	jsonMultisig = `
+	[
+		{"type":"function","name":"CreateWallet", "inputs":[
+			{"name":"signers","type":"address[]"},
+			{"name":"threshold","type":"uint8"}
+		]},
+		{"type":"function","name":"Deposit", "inputs":[
+			{"name":"id","type":"hash"}
+		]},
+		{"type":"function","name":"Propose", "inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"toAddress","type":"address"},
+			{"name":"tokenStandard","type":"tokenStandard"},
+			{"name":"amount","type":"uint256"}
+		]},
+		{"type":"function","name":"Approve", "inputs":[
+			{"name":"id","type":"hash"}
+		]},
+
+		{"type":"variable","name":"wallet","inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"signers","type":"address[]"},
+			{"name":"threshold","type":"uint8"},
+			{"name":"creationTimestamp","type":"int64"}
+		]},
+		{"type":"variable","name":"walletBalance","inputs":[
+			{"name":"amount","type":"uint256"}
+		]},
+		{"type":"variable","name":"proposal","inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"walletId","type":"hash"},
+			{"name":"proposer","type":"address"},
+			{"name":"toAddress","type":"address"},
+			{"name":"tokenStandard","type":"tokenStandard"},
+			{"name":"amount","type":"uint256"},
+			{"name":"approvals","type":"address[]"},
+			{"name":"creationTimestamp","type":"int64"},
+			{"name":"status","type":"uint8"}
+		]}
+	]`
+
+	CreateWalletMethodName    = "CreateWallet"
+	DepositMultisigMethodName = "Deposit"
+	ProposeMethodName         = "Propose"
+	ApproveMethodName         = "Approve"
+
+	WalletVariableName        = "wallet"
+	WalletBalanceVariableName = "walletBalance"
+	ProposalVariableName      = "proposal"
+
+	_ byte = iota
+	walletKeyPrefix
+	walletBalanceKeyPrefix
+	proposalKeyPrefix
+)
+
+var (
+	ABIMultisig = abi.JSONToABIContract(strings.NewReader(jsonMultisig))
+)
+
+type CreateWalletParam struct {
+	Signers   []types.Address
+	Threshold uint8
+}
+
+type ProposeParam struct {
+	Id            types.Hash
+	ToAddress     types.Address
+	TokenStandard types.ZenonTokenStandard
+	Amount        *big.Int
+}
+
+type Wallet struct {
+	Id                types.Hash      `json:"id"`
+	Signers           []types.Address `json:"signers"`
+	Threshold         uint8           `json:"threshold"`
+	CreationTimestamp int64           `json:"creationTimestamp"`
+}
+
+func (wallet *Wallet) Save(context db.DB) {
+	common.DealWithErr(context.Put(wallet.Key(), wallet.Data()))
+}
+func (wallet *Wallet) Key() []byte {
+	return common.JoinBytes([]byte{walletKeyPrefix}, wallet.Id.Bytes())
+}
+func (wallet *Wallet) Data() []byte {
+	return ABIMultisig.PackVariablePanic(
+		WalletVariableName,
+		wallet.Id,
+		wallet.Signers,
+		wallet.Threshold,
+		wallet.CreationTimestamp,
+	)
+}
+func (wallet *Wallet) IsSigner(address types.Address) bool {
+	for _, signer := range wallet.Signers {
+		if signer == address {
+			return true
+		}
+	}
+	return false
+}
+
+func parseWallet(data []byte) *Wallet {
+	wallet := new(Wallet)
+	ABIMultisig.UnpackVariablePanic(wallet, WalletVariableName, data)
+	return wallet
+}
+
+// WalletBalance is the amount of a token owned by a multisig wallet.
+// All the wallets share the balance of the multisig contract.
+type WalletBalance struct {
+	WalletId      types.Hash               `json:"-"`
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount        *big.Int                 `json:"amount"`
+}
+
+func (balance *WalletBalance) Save(context db.DB) {
+	common.DealWithErr(context.Put(balance.Key(), balance.Data()))
+}
+func (balance *WalletBalance) Delete(context db.DB) {
+	common.DealWithErr(context.Delete(balance.Key()))
+}
+func (balance *WalletBalance) Key() []byte {
+	return common.JoinBytes([]byte{walletBalanceKeyPrefix}, balance.WalletId.Bytes(), balance.TokenStandard.Bytes())
+}
+func (balance *WalletBalance) Data() []byte {
+	return ABIMultisig.PackVariablePanic(
+		WalletBalanceVariableName,
+		balance.Amount,
+	)
+}
+
+func parseWalletBalance(key, data []byte) *WalletBalance {
+	balance := new(WalletBalance)
+	ABIMultisig.UnpackVariablePanic(balance, WalletBalanceVariableName, data)
+	common.DealWithErr(balance.WalletId.SetBytes(key[1 : 1+types.HashSize]))
+	common.DealWithErr(balance.TokenStandard.SetBytes(key[1+types.HashSize:]))
+	return balance
+}
+
+type Proposal struct {
+	Id                types.Hash               `json:"id"`
+	WalletId          types.Hash               `json:"walletId"`
+	Proposer          types.Address            `json:"proposer"`
+	ToAddress         types.Address            `json:"toAddress"`
+	TokenStandard     types.ZenonTokenStandard `json:"tokenStandard"`
+	Amount            *big.Int                 `json:"amount"`
+	Approvals         []types.Address          `json:"approvals"`
+	CreationTimestamp int64                    `json:"creationTimestamp"`
+	Status            uint8                    `json:"status"`
+}
+
+func (proposal *Proposal) Save(context db.DB) {
+	common.DealWithErr(context.Put(proposal.Key(), proposal.Data()))
+}
+func (proposal *Proposal) Key() []byte {
+	return common.JoinBytes([]byte{proposalKeyPrefix}, proposal.Id.Bytes())
+}
+func (proposal *Proposal) Data() []byte {
+	return ABIMultisig.PackVariablePanic(
+		ProposalVariableName,
+		proposal.Id,
+		proposal.WalletId,
+		proposal.Proposer,
+		proposal.ToAddress,
+		proposal.TokenStandard,
+		proposal.Amount,
+		proposal.Approvals,
+		proposal.CreationTimestamp,
+		proposal.Status,
+	)
+}
+func (proposal *Proposal) IsApprovedBy(address types.Address) bool {
+	for _, approval := range proposal.Approvals {
+		if approval == address {
+			return true
+		}
+	}
+	return false
+}
+
+func parseProposal(data []byte) *Proposal {
+	proposal := new(Proposal)
+	ABIMultisig.UnpackVariablePanic(proposal, ProposalVariableName, data)
+	return proposal
+}
+
+func GetWalletEntry(context db.DB, id types.Hash) (*Wallet, error) {
+	key := (&Wallet{Id: id}).Key()
+	data, err := context.Get(key)
+	common.DealWithErr(err)
+	if len(data) == 0 {
+		return nil, constants.ErrDataNonExistent
+	} else {
+		return parseWallet(data), nil
+	}
+}
+
+func GetWalletList(context db.DB) ([]*Wallet, error) {
+	iterator := context.NewIterator([]byte{walletKeyPrefix})
+	defer iterator.Release()
+	walletList := make([]*Wallet, 0)
+
+	for {
+		if !iterator.Next() {
+			common.DealWithErr(iterator.Error())
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		walletList = append(walletList, parseWallet(iterator.Value()))
+	}
+
+	return walletList, nil
+}
+
+// GetWalletBalance returns the amount of zts owned by the wallet, which is zero if nothing was deposited
+func GetWalletBalance(context db.DB, id types.Hash, zts types.ZenonTokenStandard) *WalletBalance {
+	balance := &WalletBalance{WalletId: id, TokenStandard: zts}
+	data, err := context.Get(balance.Key())
+	common.DealWithErr(err)
+	if len(data) == 0 {
+		balance.Amount = big.NewInt(0)
+		return balance
+	}
+	return parseWalletBalance(balance.Key(), data)
+}
+
+func GetWalletBalanceList(context db.DB, id types.Hash) []*WalletBalance {
+	iterator := context.NewIterator(common.JoinBytes([]byte{walletBalanceKeyPrefix}, id.Bytes()))
+	defer iterator.Release()
+	balanceList := make([]*WalletBalance, 0)
+
+	for {
+		if !iterator.Next() {
+			common.DealWithErr(iterator.Error())
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		balanceList = append(balanceList, parseWalletBalance(iterator.Key(), iterator.Value()))
+	}
+
+	return balanceList
+}
+
+func GetProposalEntry(context db.DB, id types.Hash) (*Proposal, error) {
+	key := (&Proposal{Id: id}).Key()
+	data, err := context.Get(key)
+	common.DealWithErr(err)
+	if len(data) == 0 {
+		return nil, constants.ErrDataNonExistent
+	} else {
+		return parseProposal(data), nil
+	}
+}
+
+func GetProposalList(context db.DB) ([]*Proposal, error) {
+	iterator := context.NewIterator([]byte{proposalKeyPrefix})
+	defer iterator.Release()
+	proposalList := make([]*Proposal, 0)
+
+	for {
+		if !iterator.Next() {
+			common.DealWithErr(iterator.Error())
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		proposalList = append(proposalList, parseProposal(iterator.Value()))
+	}
+
+	return proposalList, nil
+}
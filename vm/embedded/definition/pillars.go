@@ -47,6 +47,10 @@ const (
 		{"type":"function","name":"Delegate", "inputs":[{"name":"name","type":"string"}]},
 		{"type":"function","name":"Undelegate","inputs":[]},
 		{"type":"function","name":"CollectReward","inputs":[]},
+		{"type":"function","name":"SetPayoutConfig","inputs":[
+			{"name":"name","type":"string"},
+			{"name":"blockRewardPercentage","type":"uint8"}
+		]},
 
 		{"type":"variable","name":"pillarInfo","inputs":[
 			{"name":"name","type":"string"},
@@ -75,6 +79,14 @@ const (
 			{"name":"producedBlockNum","type":"int32"},
 			{"name":"expectedBlockNum","type":"int32"},
 			{"name":"weight","type":"uint256"}
+		]},
+		{"type":"variable","name":"pillarPayoutConfig","inputs":[
+			{"name":"blockRewardPercentage","type":"uint8"},
+			{"name":"nextBlockRewardPercentage","type":"uint8"},
+			{"name":"nextEpoch","type":"uint64"}
+		]},
+		{"type":"variable","name":"pillarPayout","inputs":[
+			{"name":"znn","type":"uint256"}
 		]}
 	]`
 
@@ -86,11 +98,15 @@ const (
 	DelegateMethodName     = "Delegate"
 	UndelegateMethodName   = "Undelegate"
 
+	SetPayoutConfigMethodName = "SetPayoutConfig"
+
 	pillarInfoVariableName          = "pillarInfo"
 	producingPillarNameVariableName = "producingPillarName"
 	legacyPillarEntryVariableName   = "LegacyPillarEntry"
 	delegationInfoVariableName      = "delegationInfo"
 	pillarEpochHistoryVariableName  = "pillarEpochHistory"
+	pillarPayoutConfigVariableName  = "pillarPayoutConfig"
+	pillarPayoutVariableName        = "pillarPayout"
 )
 
 var (
@@ -102,6 +118,8 @@ var (
 	legacyPillarEntryKeyPrefix   = []byte{3}
 	delegationInfoKeyPrefix      = []byte{4}
 	pillarEpochHistoryKeyPrefix  = []byte{5}
+	pillarPayoutConfigKeyPrefix  = []byte{6}
+	pillarPayoutKeyPrefix        = []byte{7}
 
 	AnyPillarType    = uint8(0)
 	LegacyPillarType = uint8(1)
@@ -115,6 +133,10 @@ type RegisterParam struct {
 	GiveBlockRewardPercentage    uint8
 	GiveDelegateRewardPercentage uint8
 }
+type SetPayoutConfigParam struct {
+	Name                  string
+	BlockRewardPercentage uint8
+}
 type LegacyRegisterParam struct {
 	RegisterParam
 	PublicKey string
@@ -509,3 +531,130 @@ func GetPillarEpochHistoryList(context db.DB, epoch uint64) ([]*PillarEpochHisto
 
 	return list, nil
 }
+
+// PillarPayoutConfig is a share of its block reward which a pillar gives to its delegators, on top of GiveBlockRewardPercentage.
+// A new share is committed for the epochs starting with NextEpoch and replaces BlockRewardPercentage when NextEpoch is rewarded.
+type PillarPayoutConfig struct {
+	Name                      string `json:"name"`
+	BlockRewardPercentage     uint8  `json:"blockRewardPercentage"`
+	NextBlockRewardPercentage uint8  `json:"nextBlockRewardPercentage"`
+	NextEpoch                 uint64 `json:"nextEpoch"`
+}
+
+// IsLocked returns true if a share is committed but not yet in use
+func (config *PillarPayoutConfig) IsLocked() bool {
+	return config.NextEpoch != 0
+}
+
+// PercentageForEpoch returns the share committed for epoch and switches to the next share once it's in use.
+// The epochs must be rewarded in increasing order.
+func (config *PillarPayoutConfig) PercentageForEpoch(epoch uint64) uint8 {
+	if config.IsLocked() && epoch >= config.NextEpoch {
+		config.BlockRewardPercentage = config.NextBlockRewardPercentage
+		config.NextBlockRewardPercentage = 0
+		config.NextEpoch = 0
+	}
+	return config.BlockRewardPercentage
+}
+
+func (config *PillarPayoutConfig) Save(context db.DB) error {
+	if config.BlockRewardPercentage == 0 && !config.IsLocked() {
+		return context.Delete(getPillarPayoutConfigKey(config.Name))
+	}
+	data, err := ABIPillars.PackVariable(
+		pillarPayoutConfigVariableName,
+		config.BlockRewardPercentage,
+		config.NextBlockRewardPercentage,
+		config.NextEpoch)
+	if err != nil {
+		return err
+	}
+	return context.Put(getPillarPayoutConfigKey(config.Name), data)
+}
+
+func getPillarPayoutConfigKey(name string) []byte {
+	return common.JoinBytes(pillarPayoutConfigKeyPrefix, types.NewHash([]byte(name)).Bytes())
+}
+func GetPillarPayoutConfig(context db.DB, name string) (*PillarPayoutConfig, error) {
+	data, err := context.Get(getPillarPayoutConfigKey(name))
+	if err != nil {
+		return nil, err
+	}
+	config := &PillarPayoutConfig{
+		Name: name,
+	}
+	if len(data) == 0 {
+		return config, nil
+	}
+	err = ABIPillars.UnpackVariable(config, pillarPayoutConfigVariableName, data)
+	return config, err
+}
+
+// PillarPayout is the ZNN owed to a delegator from the payout shares, which is sent by the next epoch updates
+type PillarPayout struct {
+	Address types.Address `json:"address"`
+	Znn     *big.Int      `json:"znnAmount"`
+}
+
+func (payout *PillarPayout) Save(context db.DB) error {
+	data, err := ABIPillars.PackVariable(
+		pillarPayoutVariableName,
+		payout.Znn)
+	if err != nil {
+		return err
+	}
+	return context.Put(getPillarPayoutKey(payout.Address), data)
+}
+func (payout *PillarPayout) Delete(context db.DB) error {
+	return context.Delete(getPillarPayoutKey(payout.Address))
+}
+
+func getPillarPayoutKey(address types.Address) []byte {
+	return common.JoinBytes(pillarPayoutKeyPrefix, address.Bytes())
+}
+func parsePillarPayout(key, data []byte) (*PillarPayout, error) {
+	payout := &PillarPayout{
+		Znn: big.NewInt(0),
+	}
+	if err := payout.Address.SetBytes(key[len(pillarPayoutKeyPrefix):]); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return payout, nil
+	}
+	err := ABIPillars.UnpackVariable(payout, pillarPayoutVariableName, data)
+	return payout, err
+}
+func GetPillarPayout(context db.DB, address types.Address) (*PillarPayout, error) {
+	key := getPillarPayoutKey(address)
+	data, err := context.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return parsePillarPayout(key, data)
+}
+
+// GetPillarPayoutList returns up to count payouts, in the order of their addresses
+func GetPillarPayoutList(context db.DB, count int) ([]*PillarPayout, error) {
+	iterator := context.NewIterator(pillarPayoutKeyPrefix)
+	defer iterator.Release()
+	list := make([]*PillarPayout, 0)
+	for len(list) < count {
+		if !iterator.Next() {
+			if iterator.Error() != nil {
+				return nil, iterator.Error()
+			}
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+
+		payout, err := parsePillarPayout(iterator.Key(), iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, payout)
+	}
+	return list, nil
+}
@@ -22,6 +22,11 @@ const (
 		{"type":"function","name":"CancelFuse","inputs":[
 			{"name":"id","type":"hash"}
 		]},
+		{"type":"function","name":"FuseReward","inputs":[
+			{"name":"owner","type":"address"},
+			{"name":"amount","type":"uint256"}
+		]},
+		{"type":"function","name":"Donate", "inputs":[]},
 
 		{"type":"variable","name":"fusionInfo","inputs":[
 			{"name":"amount","type":"uint256"},
@@ -35,6 +40,7 @@ const (
 
 	FuseMethodName       = "Fuse"
 	CancelFuseMethodName = "CancelFuse"
+	FuseRewardMethodName = "FuseReward"
 
 	variableNameFusionInfo  = "fusionInfo"
 	variableNameFusedAmount = "fusedAmount"
@@ -48,6 +54,11 @@ var (
 	fusedAmountKeyPrefix = []byte{2}
 )
 
+type FuseRewardParam struct {
+	Owner  types.Address
+	Amount *big.Int
+}
+
 type FusionInfo struct {
 	Owner            types.Address `json:"owner"`
 	Id               types.Hash    `json:"id"`
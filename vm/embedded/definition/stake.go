@@ -19,6 +19,21 @@ const (
 		{"type":"function","name":"Cancel","inputs":[{"name":"id","type":"hash"}]},
 		{"type":"function","name":"CollectReward","inputs":[]},
 		{"type":"function","name":"Update", "inputs":[]},
+		{"type":"function","name":"Extend","inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"durationInSec","type":"int64"}
+		]},
+		{"type":"function","name":"Split","inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"amount","type":"uint256"}
+		]},
+		{"type":"function","name":"CancelPartial","inputs":[
+			{"name":"id","type":"hash"},
+			{"name":"amount","type":"uint256"}
+		]},
+		{"type":"function","name":"SetAutoCompound","inputs":[
+			{"name":"enabled","type":"bool"}
+		]},
 
 		{"type":"variable", "name":"stakeInfo", "inputs":[
 			{"name":"amount", "type":"uint256"},
@@ -26,21 +41,41 @@ const (
 			{"name":"startTime", "type":"int64"},
 			{"name":"revokeTime", "type":"int64"},
 			{"name":"expirationTime", "type":"int64"}
+		]},
+		{"type":"variable", "name":"autoCompound", "inputs":[
+			{"name":"enabled", "type":"bool"}
 		]}
 	]`
 
-	StakeMethodName       = "Stake"
-	CancelStakeMethodName = "Cancel"
+	StakeMethodName              = "Stake"
+	CancelStakeMethodName        = "Cancel"
+	ExtendStakeMethodName        = "Extend"
+	SplitStakeMethodName         = "Split"
+	CancelPartialStakeMethodName = "CancelPartial"
+	SetAutoCompoundMethodName    = "SetAutoCompound"
 
-	stakeInfoVariableName = "stakeInfo"
+	stakeInfoVariableName    = "stakeInfo"
+	autoCompoundVariableName = "autoCompound"
 )
 
 var (
 	ABIStake = abi.JSONToABIContract(strings.NewReader(jsonStake))
 
-	stakeInfoPrefix = []byte{1}
+	stakeInfoPrefix    = []byte{1}
+	autoCompoundPrefix = []byte{2}
 )
 
+type ExtendStakeParam struct {
+	Id            types.Hash
+	DurationInSec int64
+}
+
+// StakeAmountParam is used by the methods which move only part of the amount of a stake entry
+type StakeAmountParam struct {
+	Id     types.Hash
+	Amount *big.Int
+}
+
 type StakeInfo struct {
 	Amount         *big.Int      `json:"amount"`
 	WeightedAmount *big.Int      `json:"weightedAmount"`
@@ -163,6 +198,24 @@ func GetStakeListByAddress(context db.DB, address types.Address) ([]*StakeInfo,
 	}
 }
 
+// IsStakeAutoCompound returns true if the QSR rewards of address are fused for plasma when collected
+func IsStakeAutoCompound(context db.DB, address types.Address) (bool, error) {
+	data, err := context.Get(getAutoCompoundKey(address))
+	if err != nil {
+		return false, err
+	}
+	return len(data) != 0, nil
+}
+func SetStakeAutoCompound(context db.DB, address types.Address, enabled bool) error {
+	if !enabled {
+		return context.Delete(getAutoCompoundKey(address))
+	}
+	return context.Put(getAutoCompoundKey(address), ABIStake.PackVariablePanic(autoCompoundVariableName, enabled))
+}
+func getAutoCompoundKey(address types.Address) []byte {
+	return append(autoCompoundPrefix, address.Bytes()...)
+}
+
 type StakeByExpirationTime []*StakeInfo
 
 func (a StakeByExpirationTime) Len() int      { return len(a) }
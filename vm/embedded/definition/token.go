@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/abi"
@@ -19,6 +20,9 @@ const (
 		{"type":"function","name":"Mint","inputs":[{"name":"tokenStandard","type":"tokenStandard"},{"name":"amount","type":"uint256"},{"name":"receiveAddress","type":"address"}]},
 		{"type":"function","name":"Burn","inputs":[]},
 		{"type":"function","name":"UpdateToken","inputs":[{"name":"tokenStandard","type":"tokenStandard"},{"name":"owner","type":"address"},{"name":"isMintable","type":"bool"},{"name":"isBurnable","type":"bool"}]},
+		{"type":"function","name":"SetTokenPolicy","inputs":[{"name":"tokenStandard","type":"tokenStandard"},{"name":"useAllowlist","type":"bool"},{"name":"mintCapPerEpoch","type":"uint256"}]},
+		{"type":"function","name":"SetAddressFrozen","inputs":[{"name":"tokenStandard","type":"tokenStandard"},{"name":"address","type":"address"},{"name":"isFrozen","type":"bool"}]},
+		{"type":"function","name":"SetAddressAllowed","inputs":[{"name":"tokenStandard","type":"tokenStandard"},{"name":"address","type":"address"},{"name":"isAllowed","type":"bool"}]},
 
 		{"type":"variable","name":"tokenInfo","inputs":[
 			{"name":"owner","type":"address"},
@@ -30,7 +34,15 @@ const (
 			{"name":"decimals","type":"uint8"},
 			{"name":"isMintable","type":"bool"},
 			{"name":"isBurnable","type":"bool"},
-			{"name":"isUtility","type":"bool"}]}
+			{"name":"isUtility","type":"bool"}]},
+		{"type":"variable","name":"tokenPolicy","inputs":[
+			{"name":"useAllowlist","type":"bool"},
+			{"name":"mintCapPerEpoch","type":"uint256"},
+			{"name":"mintEpoch","type":"uint64"},
+			{"name":"mintedInEpoch","type":"uint256"}]},
+		{"type":"variable","name":"tokenAddressPolicy","inputs":[
+			{"name":"isFrozen","type":"bool"},
+			{"name":"isAllowed","type":"bool"}]}
 	]`
 
 	IssueMethodName       = "IssueToken"
@@ -38,14 +50,22 @@ const (
 	BurnMethodName        = "Burn"
 	UpdateTokenMethodName = "UpdateToken"
 
-	tokenInfoVariableName = "tokenInfo"
+	SetTokenPolicyMethodName    = "SetTokenPolicy"
+	SetAddressFrozenMethodName  = "SetAddressFrozen"
+	SetAddressAllowedMethodName = "SetAddressAllowed"
+
+	tokenInfoVariableName          = "tokenInfo"
+	tokenPolicyVariableName        = "tokenPolicy"
+	tokenAddressPolicyVariableName = "tokenAddressPolicy"
 )
 
 var (
 	// ABIToken is abi definition of token contract
 	ABIToken = abi.JSONToABIContract(strings.NewReader(jsonToken))
 
-	tokenInfoKeyPrefix = []byte{1}
+	tokenInfoKeyPrefix          = []byte{1}
+	tokenPolicyKeyPrefix        = []byte{2}
+	tokenAddressPolicyKeyPrefix = []byte{3}
 )
 
 type IssueParam struct {
@@ -70,6 +90,21 @@ type UpdateTokenParam struct {
 	IsMintable    bool
 	IsBurnable    bool
 }
+type TokenPolicyParam struct {
+	TokenStandard   types.ZenonTokenStandard
+	UseAllowlist    bool
+	MintCapPerEpoch *big.Int
+}
+type SetAddressFrozenParam struct {
+	TokenStandard types.ZenonTokenStandard
+	Address       types.Address
+	IsFrozen      bool
+}
+type SetAddressAllowedParam struct {
+	TokenStandard types.ZenonTokenStandard
+	Address       types.Address
+	IsAllowed     bool
+}
 
 type TokenInfo struct {
 	Owner       types.Address `json:"owner"`
@@ -173,3 +208,143 @@ func GetTokenInfoList(context db.DB) ([]*TokenInfo, error) {
 	}
 	return tokenInfoList, nil
 }
+
+// TokenPolicy holds the transfer and mint restrictions set by the owner of a token.
+// If UseAllowlist = true, the token can only be sent to the addresses allowed by the owner.
+// MintCapPerEpoch = 0 implies that the amount minted per epoch is not capped.
+type TokenPolicy struct {
+	TokenStandard   types.ZenonTokenStandard `json:"tokenStandard"`
+	UseAllowlist    bool                     `json:"useAllowlist"`
+	MintCapPerEpoch *big.Int                 `json:"mintCapPerEpoch"`
+	MintEpoch       uint64                   `json:"mintEpoch"`
+	MintedInEpoch   *big.Int                 `json:"mintedInEpoch"`
+}
+
+func (policy *TokenPolicy) Save(context db.DB) error {
+	data, err := ABIToken.PackVariable(
+		tokenPolicyVariableName,
+		policy.UseAllowlist,
+		policy.MintCapPerEpoch,
+		policy.MintEpoch,
+		policy.MintedInEpoch,
+	)
+	if err != nil {
+		return err
+	}
+	return context.Put(
+		getTokenPolicyKey(policy.TokenStandard),
+		data,
+	)
+}
+
+// MintableInEpoch returns the amount which can still be minted in epoch, or nil if minting is not capped
+func (policy *TokenPolicy) MintableInEpoch(epoch uint64) *big.Int {
+	if policy.MintCapPerEpoch.Sign() == 0 {
+		return nil
+	}
+	if policy.MintEpoch != epoch {
+		return new(big.Int).Set(policy.MintCapPerEpoch)
+	}
+	return new(big.Int).Sub(policy.MintCapPerEpoch, policy.MintedInEpoch)
+}
+
+func getTokenPolicyKey(ts types.ZenonTokenStandard) []byte {
+	return common.JoinBytes(tokenPolicyKeyPrefix, ts.Bytes())
+}
+func GetTokenPolicy(context db.DB, ts types.ZenonTokenStandard) (*TokenPolicy, error) {
+	data, err := context.Get(getTokenPolicyKey(ts))
+	if err != nil {
+		return nil, err
+	}
+	policy := &TokenPolicy{
+		TokenStandard:   ts,
+		MintCapPerEpoch: big.NewInt(0),
+		MintedInEpoch:   big.NewInt(0),
+	}
+	if len(data) == 0 {
+		return policy, nil
+	}
+	err = ABIToken.UnpackVariable(policy, tokenPolicyVariableName, data)
+	return policy, err
+}
+
+// TokenAddressPolicy holds the restrictions set by the owner of a token for a single address.
+// A frozen address can't send the token and, if the token uses an allowlist, only allowed addresses can receive it.
+type TokenAddressPolicy struct {
+	TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+	Address       types.Address            `json:"address"`
+	IsFrozen      bool                     `json:"isFrozen"`
+	IsAllowed     bool                     `json:"isAllowed"`
+}
+
+func (policy *TokenAddressPolicy) Save(context db.DB) error {
+	// an address without restrictions doesn't need an entry
+	if !policy.IsFrozen && !policy.IsAllowed {
+		return context.Delete(getTokenAddressPolicyKey(policy.TokenStandard, policy.Address))
+	}
+	data, err := ABIToken.PackVariable(
+		tokenAddressPolicyVariableName,
+		policy.IsFrozen,
+		policy.IsAllowed,
+	)
+	if err != nil {
+		return err
+	}
+	return context.Put(
+		getTokenAddressPolicyKey(policy.TokenStandard, policy.Address),
+		data,
+	)
+}
+
+func getTokenAddressPolicyKey(ts types.ZenonTokenStandard, address types.Address) []byte {
+	return common.JoinBytes(tokenAddressPolicyKeyPrefix, ts.Bytes(), address.Bytes())
+}
+func parseTokenAddressPolicy(key, data []byte) (*TokenAddressPolicy, error) {
+	policy := new(TokenAddressPolicy)
+	if err := ABIToken.UnpackVariable(policy, tokenAddressPolicyVariableName, data); err != nil {
+		return nil, err
+	}
+	if err := policy.TokenStandard.SetBytes(key[1 : 1+types.ZenonTokenStandardSize]); err != nil {
+		return nil, err
+	}
+	if err := policy.Address.SetBytes(key[1+types.ZenonTokenStandardSize:]); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+func GetTokenAddressPolicy(context db.DB, ts types.ZenonTokenStandard, address types.Address) (*TokenAddressPolicy, error) {
+	key := getTokenAddressPolicyKey(ts, address)
+	data, err := context.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return &TokenAddressPolicy{
+			TokenStandard: ts,
+			Address:       address,
+		}, nil
+	}
+	return parseTokenAddressPolicy(key, data)
+}
+func GetTokenAddressPolicyList(context db.DB, ts types.ZenonTokenStandard) ([]*TokenAddressPolicy, error) {
+	iterator := context.NewIterator(common.JoinBytes(tokenAddressPolicyKeyPrefix, ts.Bytes()))
+	defer iterator.Release()
+	policyList := make([]*TokenAddressPolicy, 0)
+	for {
+		if !iterator.Next() {
+			if iterator.Error() != nil {
+				return nil, iterator.Error()
+			}
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		policy, err := parseTokenAddressPolicy(iterator.Key(), iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		policyList = append(policyList, policy)
+	}
+	return policyList, nil
+}
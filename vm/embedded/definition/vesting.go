@@ -0,0 +1,154 @@
+package definition
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/abi"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+const (
+	jsonVesting = `
+	[
+		{"type":"function","name":"CreateSchedule", "inputs":[
+			{"name":"beneficiary","type":"address"},
+			{"name":"startTime","type":"int64"},
+			{"name":"cliffDuration","type":"int64"},
+			{"name":"vestingDuration","type":"int64"}
+		]},
+		{"type":"function","name":"Withdraw", "inputs":[
+			{"name":"id","type":"hash"}
+		]},
+
+		{"type":"variable","name":"vestingSchedule","inputs":[
+			{"name":"creator","type":"address"},
+			{"name":"tokenStandard","type":"tokenStandard"},
+			{"name":"totalAmount","type":"uint256"},
+			{"name":"withdrawnAmount","type":"uint256"},
+			{"name":"startTime","type":"int64"},
+			{"name":"cliffDuration","type":"int64"},
+			{"name":"vestingDuration","type":"int64"}
+		]}
+	]`
+
+	CreateScheduleMethodName  = "CreateSchedule"
+	WithdrawVestingMethodName = "Withdraw"
+
+	vestingScheduleVariableName = "vestingSchedule"
+
+	_ byte = iota
+	vestingScheduleKeyPrefix
+)
+
+var (
+	ABIVesting = abi.JSONToABIContract(strings.NewReader(jsonVesting))
+)
+
+type CreateScheduleParam struct {
+	Beneficiary     types.Address
+	StartTime       int64
+	CliffDuration   int64
+	VestingDuration int64
+}
+
+type VestingScheduleKey struct {
+	Beneficiary types.Address `json:"beneficiary"`
+	Id          types.Hash    `json:"id"`
+}
+
+// VestingSchedule locks TotalAmount for Beneficiary. Nothing can be withdrawn before StartTime + CliffDuration,
+// after that the amount vests linearly until StartTime + VestingDuration.
+// A time-locked transfer is a schedule with the CliffDuration equal to the VestingDuration.
+type VestingSchedule struct {
+	VestingScheduleKey
+	Creator         types.Address            `json:"creator"`
+	TokenStandard   types.ZenonTokenStandard `json:"tokenStandard"`
+	TotalAmount     *big.Int                 `json:"totalAmount"`
+	WithdrawnAmount *big.Int                 `json:"withdrawnAmount"`
+	StartTime       int64                    `json:"startTime"`
+	CliffDuration   int64                    `json:"cliffDuration"`
+	VestingDuration int64                    `json:"vestingDuration"`
+}
+
+func (schedule *VestingSchedule) Save(context db.DB) {
+	common.DealWithErr(context.Put(schedule.Key(), schedule.Data()))
+}
+func (schedule *VestingSchedule) Delete(context db.DB) {
+	common.DealWithErr(context.Delete(schedule.Key()))
+}
+func (schedule *VestingSchedule) Data() []byte {
+	return ABIVesting.PackVariablePanic(
+		vestingScheduleVariableName,
+		schedule.Creator,
+		schedule.TokenStandard,
+		schedule.TotalAmount,
+		schedule.WithdrawnAmount,
+		schedule.StartTime,
+		schedule.CliffDuration,
+		schedule.VestingDuration,
+	)
+}
+func (key *VestingScheduleKey) Key() []byte {
+	return common.JoinBytes([]byte{vestingScheduleKeyPrefix}, key.Beneficiary.Bytes(), key.Id.Bytes())
+}
+
+// VestedAmount returns the amount unlocked by the schedule at timestamp, including the amount already withdrawn
+func (schedule *VestingSchedule) VestedAmount(timestamp int64) *big.Int {
+	elapsed := timestamp - schedule.StartTime
+	if elapsed < schedule.CliffDuration {
+		return big.NewInt(0)
+	}
+	if elapsed >= schedule.VestingDuration {
+		return new(big.Int).Set(schedule.TotalAmount)
+	}
+	vested := new(big.Int).Mul(schedule.TotalAmount, big.NewInt(elapsed))
+	return vested.Quo(vested, big.NewInt(schedule.VestingDuration))
+}
+
+// WithdrawableAmount returns the amount the beneficiary can withdraw at timestamp
+func (schedule *VestingSchedule) WithdrawableAmount(timestamp int64) *big.Int {
+	vested := schedule.VestedAmount(timestamp)
+	return vested.Sub(vested, schedule.WithdrawnAmount)
+}
+
+func parseVestingSchedule(key, data []byte) *VestingSchedule {
+	schedule := new(VestingSchedule)
+	ABIVesting.UnpackVariablePanic(schedule, vestingScheduleVariableName, data)
+	common.DealWithErr(schedule.Beneficiary.SetBytes(key[1 : 1+types.AddressSize]))
+	common.DealWithErr(schedule.Id.SetBytes(key[1+types.AddressSize:]))
+	return schedule
+}
+
+func GetVestingSchedule(context db.DB, beneficiary types.Address, id types.Hash) (*VestingSchedule, error) {
+	key := (&VestingScheduleKey{Beneficiary: beneficiary, Id: id}).Key()
+	data, err := context.Get(key)
+	common.DealWithErr(err)
+	if len(data) == 0 {
+		return nil, constants.ErrDataNonExistent
+	} else {
+		return parseVestingSchedule(key, data), nil
+	}
+}
+
+func GetVestingSchedulesByBeneficiary(context db.DB, beneficiary types.Address) ([]*VestingSchedule, error) {
+	iterator := context.NewIterator(common.JoinBytes([]byte{vestingScheduleKeyPrefix}, beneficiary.Bytes()))
+	defer iterator.Release()
+	scheduleList := make([]*VestingSchedule, 0)
+
+	for {
+		if !iterator.Next() {
+			common.DealWithErr(iterator.Error())
+			break
+		}
+		if len(iterator.Value()) == 0 {
+			continue
+		}
+		scheduleList = append(scheduleList, parseVestingSchedule(iterator.Key(), iterator.Value()))
+	}
+
+	return scheduleList, nil
+}
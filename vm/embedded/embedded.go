@@ -31,35 +31,6 @@ type embeddedImplementation struct {
 	abi abi.ABIContract
 }
 
-var (
-	originEmbedded      = getOrigin()
-	acceleratorEmbedded = getAccelerator()
-)
-
-func getAccelerator() map[types.Address]*embeddedImplementation {
-	contracts := getOrigin()
-	contracts[types.AcceleratorContract] = &embeddedImplementation{
-		map[string]Method{
-			cabi.DonateMethodName:        &implementation.DonateMethod{cabi.DonateMethodName},
-			cabi.CreateProjectMethodName: &implementation.CreateProjectMethod{cabi.CreateProjectMethodName},
-			cabi.AddPhaseMethodName:      &implementation.AddPhaseMethod{cabi.AddPhaseMethodName},
-			cabi.UpdateMethodName:        &implementation.UpdateEmbeddedAcceleratorMethod{cabi.UpdateMethodName},
-			cabi.UpdatePhaseMethodName:   &implementation.UpdatePhaseMethod{cabi.UpdatePhaseMethodName},
-			// common
-			cabi.VoteByNameMethodName:        &implementation.VoteByNameMethod{cabi.VoteByNameMethodName},
-			cabi.VoteByProdAddressMethodName: &implementation.VoteByProdAddressMethod{cabi.VoteByProdAddressMethodName},
-		},
-		cabi.ABIAccelerator,
-	}
-	contracts[types.PillarContract].m[cabi.CollectRewardMethodName] = &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple}
-	contracts[types.SentinelContract].m[cabi.CollectRewardMethodName] = &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple}
-	contracts[types.StakeContract].m[cabi.CollectRewardMethodName] = &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple}
-	contracts[types.LiquidityContract].m[cabi.FundMethodName] = &implementation.FundMethod{cabi.FundMethodName}
-	contracts[types.LiquidityContract].m[cabi.BurnZnnMethodName] = &implementation.BurnZnnMethod{cabi.BurnZnnMethodName}
-
-	return contracts
-}
-
 func getOrigin() map[types.Address]*embeddedImplementation {
 	return map[types.Address]*embeddedImplementation{
 		types.PlasmaContract: {
@@ -154,14 +125,7 @@ func GetEmbeddedMethod(context vm_context.AccountVmContext, address types.Addres
 		return nil, constants.ErrNotContractAddress
 	}
 
-	var contractsMap map[types.Address]*embeddedImplementation
-	if context.IsAcceleratorSporkEnforced() {
-		contractsMap = acceleratorEmbedded
-	} else {
-		contractsMap = originEmbedded
-	}
-
-	if p, found := contractsMap[address]; found {
+	if p, found := getContracts(context)[address]; found {
 		if method, err := p.abi.MethodById(abiSelector); err == nil {
 			c, ok := p.m[method.Name]
 			if ok {
@@ -173,3 +137,37 @@ func GetEmbeddedMethod(context vm_context.AccountVmContext, address types.Addres
 		return nil, constants.ErrContractDoesntExist
 	}
 }
+
+// GetEmbeddedMethodName returns the name of the embedded method called by data on address.
+// The lookup is done against all known ABIs, regardless of the sporks which are active.
+func GetEmbeddedMethodName(address types.Address, data []byte) (string, error) {
+	if !types.IsEmbeddedAddress(address) {
+		return "", constants.ErrNotContractAddress
+	}
+
+	if p, found := latestEmbedded[address]; found {
+		if method, err := p.abi.MethodById(data); err == nil {
+			return method.Name, nil
+		}
+		return "", constants.ErrContractMethodNotFound
+	} else {
+		return "", constants.ErrContractDoesntExist
+	}
+}
+
+// DecodeEmbeddedCall returns the name and the named arguments of the embedded method called by data on address.
+func DecodeEmbeddedCall(address types.Address, data []byte) (string, map[string]interface{}, error) {
+	if !types.IsEmbeddedAddress(address) {
+		return "", nil, constants.ErrNotContractAddress
+	}
+
+	if p, found := latestEmbedded[address]; found {
+		method, args, err := p.abi.UnpackMethodValues(data)
+		if err != nil {
+			return "", nil, err
+		}
+		return method.Name, args, nil
+	} else {
+		return "", nil, constants.ErrContractDoesntExist
+	}
+}
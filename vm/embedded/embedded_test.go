@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
 )
 
 func TestDumpContractsABIMethods(t *testing.T) {
@@ -63,3 +64,21 @@ func TestDumpContractsABIMethods(t *testing.T) {
 {"address":"z1qxemdeddedxt0kenxxxxxxxxxxxxxxxxh9amk0", "name":"UpdateToken", "id":"2a3cf32c", "signature":"UpdateToken(tokenStandard,address,bool,bool)"}
 ]`)
 }
+
+func TestSporkUpgrades(t *testing.T) {
+	methods := func(contracts map[types.Address]*embeddedImplementation, address types.Address) string {
+		names := make([]string, 0)
+		for name := range contracts[address].m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Sprint(names)
+	}
+
+	common.Expect(t, methods(buildContracts(func(int) bool { return false }), types.AcceleratorContract), "[Donate]")
+	common.Expect(t, methods(latestEmbedded, types.AcceleratorContract), "[AddPhase CreateProject Donate Update UpdatePhase VoteByName VoteByProdAddress]")
+	common.Expect(t, methods(latestEmbedded, types.LiquidityContract), "[BurnZnn Donate Fund Update]")
+	// applying the upgrades doesn't change the origin contracts
+	common.Expect(t, methods(originEmbedded, types.AcceleratorContract), "[Donate]")
+	common.Expect(t, methods(originEmbedded, types.LiquidityContract), "[Donate Update]")
+}
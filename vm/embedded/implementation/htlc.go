@@ -0,0 +1,223 @@
+package implementation
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/crypto"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/vm_context"
+)
+
+var (
+	htlcLog = common.EmbeddedLogger.New("contract", "htlc")
+)
+
+func hashPreimage(hashType uint8, preimage []byte) []byte {
+	switch hashType {
+	case definition.HashTypeSHA3:
+		return crypto.Hash(preimage)
+	case definition.HashTypeSHA256:
+		digest := sha256.Sum256(preimage)
+		return digest[:]
+	default:
+		return nil
+	}
+}
+
+func checkHtlcStatic(param *definition.CreateHtlcParam) error {
+	if param.HashType >= definition.HashTypeNotValid {
+		return constants.ErrInvalidHashType
+	}
+	// both hash types have 32 byte digests
+	if len(param.HashLock) != types.HashSize {
+		return constants.ErrInvalidHashDigest
+	}
+	if int(param.KeyMaxSize) < constants.HtlcPreimageMinSize {
+		return constants.ErrInvalidPreimage
+	}
+	if types.IsEmbeddedAddress(param.HashLocked) {
+		return constants.ErrForbiddenParam
+	}
+	return nil
+}
+
+func sendHtlcFunds(htlc *definition.HtlcInfo, toAddress types.Address) []*nom.AccountBlock {
+	return []*nom.AccountBlock{
+		{
+			Address:       types.HtlcContract,
+			ToAddress:     toAddress,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        htlc.Amount,
+			TokenStandard: htlc.TokenStandard,
+			Data:          nil,
+		},
+	}
+}
+
+type CreateHtlcMethod struct {
+	MethodName string
+}
+
+func (p *CreateHtlcMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *CreateHtlcMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.CreateHtlcParam)
+
+	if err := definition.ABIHtlc.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if err := checkHtlcStatic(param); err != nil {
+		return err
+	}
+
+	if block.Amount.Sign() == 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIHtlc.PackMethod(p.MethodName, param.HashLocked, param.ExpirationTime, param.HashType, param.KeyMaxSize, param.HashLock)
+	return err
+}
+func (p *CreateHtlcMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.CreateHtlcParam)
+	common.DealWithErr(definition.ABIHtlc.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	if param.ExpirationTime <= momentum.Timestamp.Unix() {
+		return nil, constants.ErrInvalidExpirationTime
+	}
+
+	htlc := &definition.HtlcInfo{
+		Id:             sendBlock.Hash,
+		TimeLocked:     sendBlock.Address,
+		HashLocked:     param.HashLocked,
+		TokenStandard:  sendBlock.TokenStandard,
+		Amount:         sendBlock.Amount,
+		ExpirationTime: param.ExpirationTime,
+		HashType:       param.HashType,
+		KeyMaxSize:     param.KeyMaxSize,
+		HashLock:       param.HashLock,
+	}
+	htlc.Save(context.Storage())
+
+	htlcLog.Debug("created htlc", "id", htlc.Id, "time-locked", htlc.TimeLocked, "hash-locked", htlc.HashLocked, "zts", htlc.TokenStandard, "amount", htlc.Amount, "expiration-time", htlc.ExpirationTime, "hash-type", htlc.HashType)
+	return nil, nil
+}
+
+type ReclaimHtlcMethod struct {
+	MethodName string
+}
+
+func (p *ReclaimHtlcMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *ReclaimHtlcMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	id := new(types.Hash)
+
+	if err := definition.ABIHtlc.UnpackMethod(id, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIHtlc.PackMethod(p.MethodName, id)
+	return err
+}
+func (p *ReclaimHtlcMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	id := new(types.Hash)
+	common.DealWithErr(definition.ABIHtlc.UnpackMethod(id, p.MethodName, sendBlock.Data))
+
+	htlc, err := definition.GetHtlcInfo(context.Storage(), *id)
+	if err != nil {
+		return nil, err
+	}
+	if htlc.TimeLocked != sendBlock.Address {
+		return nil, constants.ErrPermissionDenied
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	if momentum.Timestamp.Unix() < htlc.ExpirationTime {
+		return nil, constants.ErrHtlcNotExpired
+	}
+
+	htlc.Delete(context.Storage())
+	htlcLog.Debug("reclaimed htlc", "id", htlc.Id, "time-locked", htlc.TimeLocked, "zts", htlc.TokenStandard, "amount", htlc.Amount)
+	return sendHtlcFunds(htlc, htlc.TimeLocked), nil
+}
+
+type UnlockHtlcMethod struct {
+	MethodName string
+}
+
+func (p *UnlockHtlcMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *UnlockHtlcMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.UnlockHtlcParam)
+
+	if err := definition.ABIHtlc.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if len(param.Preimage) < constants.HtlcPreimageMinSize || len(param.Preimage) > constants.HtlcPreimageMaxSize {
+		return constants.ErrInvalidPreimage
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIHtlc.PackMethod(p.MethodName, param.Id, param.Preimage)
+	return err
+}
+func (p *UnlockHtlcMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.UnlockHtlcParam)
+	common.DealWithErr(definition.ABIHtlc.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	htlc, err := definition.GetHtlcInfo(context.Storage(), param.Id)
+	if err != nil {
+		return nil, err
+	}
+	if htlc.HashLocked != sendBlock.Address {
+		return nil, constants.ErrPermissionDenied
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	if momentum.Timestamp.Unix() >= htlc.ExpirationTime {
+		return nil, constants.ErrHtlcExpired
+	}
+
+	if len(param.Preimage) > int(htlc.KeyMaxSize) || !bytes.Equal(hashPreimage(htlc.HashType, param.Preimage), htlc.HashLock) {
+		return nil, constants.ErrInvalidPreimage
+	}
+
+	htlc.Delete(context.Storage())
+	htlcLog.Debug("unlocked htlc", "id", htlc.Id, "hash-locked", htlc.HashLocked, "zts", htlc.TokenStandard, "amount", htlc.Amount)
+	return sendHtlcFunds(htlc, htlc.HashLocked), nil
+}
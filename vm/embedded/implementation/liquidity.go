@@ -139,7 +139,7 @@ func (p *FundMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock
 	common.DealWithErr(err)
 
 	blocks := make([]*nom.AccountBlock, 0)
-	if context.IsAcceleratorSporkEnforced() {
+	if context.IsSporkEnforced(types.AcceleratorSpork) {
 		znnBalance, err := context.GetBalance(types.ZnnTokenStandard)
 		if err != nil {
 			return nil, err
@@ -210,7 +210,7 @@ func (p *BurnZnnMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBl
 	common.DealWithErr(err)
 
 	blocks := make([]*nom.AccountBlock, 0)
-	if context.IsAcceleratorSporkEnforced() {
+	if context.IsSporkEnforced(types.AcceleratorSpork) {
 		znnBalance, err := context.GetBalance(types.ZnnTokenStandard)
 		if err != nil {
 			return nil, err
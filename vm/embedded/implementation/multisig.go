@@ -0,0 +1,272 @@
+package implementation
+
+import (
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/vm_context"
+)
+
+var (
+	multisigLog = common.EmbeddedLogger.New("contract", "multisig")
+)
+
+func checkWalletStatic(param *definition.CreateWalletParam) error {
+	if len(param.Signers) == 0 || len(param.Signers) > constants.MultisigMaxSigners {
+		return constants.ErrMultisigInvalidSigners
+	}
+	if param.Threshold == 0 || int(param.Threshold) > len(param.Signers) {
+		return constants.ErrMultisigInvalidSigners
+	}
+	unique := make(map[types.Address]bool, len(param.Signers))
+	for _, signer := range param.Signers {
+		if types.IsEmbeddedAddress(signer) || unique[signer] {
+			return constants.ErrMultisigInvalidSigners
+		}
+		unique[signer] = true
+	}
+	return nil
+}
+
+// executeProposal sends the funds of the proposal from the wallet once enough signers approved it
+func executeProposal(context vm_context.AccountVmContext, wallet *definition.Wallet, proposal *definition.Proposal) ([]*nom.AccountBlock, error) {
+	if len(proposal.Approvals) < int(wallet.Threshold) {
+		proposal.Save(context.Storage())
+		return nil, nil
+	}
+
+	balance := definition.GetWalletBalance(context.Storage(), wallet.Id, proposal.TokenStandard)
+	if balance.Amount.Cmp(proposal.Amount) == -1 {
+		return nil, constants.ErrInsufficientBalance
+	}
+	balance.Amount.Sub(balance.Amount, proposal.Amount)
+	if balance.Amount.Sign() == 0 {
+		balance.Delete(context.Storage())
+	} else {
+		balance.Save(context.Storage())
+	}
+
+	proposal.Status = definition.ExecutedProposalStatus
+	proposal.Save(context.Storage())
+
+	multisigLog.Debug("executed proposal", "id", proposal.Id, "wallet-id", wallet.Id, "to-address", proposal.ToAddress, "zts", proposal.TokenStandard, "amount", proposal.Amount)
+	return []*nom.AccountBlock{
+		{
+			Address:       types.MultisigContract,
+			ToAddress:     proposal.ToAddress,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        new(big.Int).Set(proposal.Amount),
+			TokenStandard: proposal.TokenStandard,
+			Data:          nil,
+		},
+	}, nil
+}
+
+type CreateWalletMethod struct {
+	MethodName string
+}
+
+func (p *CreateWalletMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *CreateWalletMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.CreateWalletParam)
+
+	if err := definition.ABIMultisig.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if err := checkWalletStatic(param); err != nil {
+		return err
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIMultisig.PackMethod(p.MethodName, param.Signers, param.Threshold)
+	return err
+}
+func (p *CreateWalletMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.CreateWalletParam)
+	common.DealWithErr(definition.ABIMultisig.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	frontierMomentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	wallet := &definition.Wallet{
+		Id:                sendBlock.Hash,
+		Signers:           param.Signers,
+		Threshold:         param.Threshold,
+		CreationTimestamp: frontierMomentum.Timestamp.Unix(),
+	}
+	wallet.Save(context.Storage())
+
+	multisigLog.Debug("created wallet", "id", wallet.Id, "signers", wallet.Signers, "threshold", wallet.Threshold)
+	return nil, nil
+}
+
+type DepositMultisigMethod struct {
+	MethodName string
+}
+
+func (p *DepositMultisigMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *DepositMultisigMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	id := new(types.Hash)
+
+	if err := definition.ABIMultisig.UnpackMethod(id, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() == 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIMultisig.PackMethod(p.MethodName, id)
+	return err
+}
+func (p *DepositMultisigMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	id := new(types.Hash)
+	common.DealWithErr(definition.ABIMultisig.UnpackMethod(id, p.MethodName, sendBlock.Data))
+
+	if _, err := definition.GetWalletEntry(context.Storage(), *id); err != nil {
+		return nil, err
+	}
+
+	balance := definition.GetWalletBalance(context.Storage(), *id, sendBlock.TokenStandard)
+	balance.Amount.Add(balance.Amount, sendBlock.Amount)
+	balance.Save(context.Storage())
+
+	multisigLog.Debug("deposited to wallet", "id", id, "from-address", sendBlock.Address, "zts", sendBlock.TokenStandard, "amount", sendBlock.Amount)
+	return nil, nil
+}
+
+type ProposeMethod struct {
+	MethodName string
+}
+
+func (p *ProposeMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *ProposeMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.ProposeParam)
+
+	if err := definition.ABIMultisig.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if param.Amount.Sign() <= 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIMultisig.PackMethod(p.MethodName, param.Id, param.ToAddress, param.TokenStandard, param.Amount)
+	return err
+}
+func (p *ProposeMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.ProposeParam)
+	common.DealWithErr(definition.ABIMultisig.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	wallet, err := definition.GetWalletEntry(context.Storage(), param.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.IsSigner(sendBlock.Address) {
+		return nil, constants.ErrPermissionDenied
+	}
+
+	frontierMomentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	// the proposer approves the proposal
+	proposal := &definition.Proposal{
+		Id:                sendBlock.Hash,
+		WalletId:          wallet.Id,
+		Proposer:          sendBlock.Address,
+		ToAddress:         param.ToAddress,
+		TokenStandard:     param.TokenStandard,
+		Amount:            param.Amount,
+		Approvals:         []types.Address{sendBlock.Address},
+		CreationTimestamp: frontierMomentum.Timestamp.Unix(),
+		Status:            definition.PendingProposalStatus,
+	}
+
+	multisigLog.Debug("created proposal", "id", proposal.Id, "wallet-id", wallet.Id, "proposer", proposal.Proposer, "to-address", proposal.ToAddress, "zts", proposal.TokenStandard, "amount", proposal.Amount)
+	return executeProposal(context, wallet, proposal)
+}
+
+type ApproveMethod struct {
+	MethodName string
+}
+
+func (p *ApproveMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *ApproveMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	id := new(types.Hash)
+
+	if err := definition.ABIMultisig.UnpackMethod(id, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIMultisig.PackMethod(p.MethodName, id)
+	return err
+}
+func (p *ApproveMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	id := new(types.Hash)
+	common.DealWithErr(definition.ABIMultisig.UnpackMethod(id, p.MethodName, sendBlock.Data))
+
+	proposal, err := definition.GetProposalEntry(context.Storage(), *id)
+	if err != nil {
+		return nil, err
+	}
+	if proposal.Status != definition.PendingProposalStatus {
+		return nil, constants.ErrMultisigNotPending
+	}
+
+	wallet, err := definition.GetWalletEntry(context.Storage(), proposal.WalletId)
+	common.DealWithErr(err)
+	if !wallet.IsSigner(sendBlock.Address) {
+		return nil, constants.ErrPermissionDenied
+	}
+	if proposal.IsApprovedBy(sendBlock.Address) {
+		return nil, constants.ErrMultisigAlreadyApproved
+	}
+
+	proposal.Approvals = append(proposal.Approvals, sendBlock.Address)
+	multisigLog.Debug("approved proposal", "id", proposal.Id, "wallet-id", wallet.Id, "signer", sendBlock.Address, "approvals", len(proposal.Approvals), "threshold", wallet.Threshold)
+	return executeProposal(context, wallet, proposal)
+}
@@ -396,6 +396,8 @@ func computeDetailedPillarReward(context vm_context.AccountVmContext, epoch uint
 		}
 	}
 
+	payoutEnforced := context.IsSporkEnforced(types.PillarPayoutSpork)
+	payouts := make(map[string]*big.Int)
 	for _, pillar := range pillarInfos {
 		reward, ok := pillarReward[pillar.Name]
 		// pillar registered in later epochs
@@ -416,10 +418,20 @@ func computeDetailedPillarReward(context vm_context.AccountVmContext, epoch uint
 		toGiveN.Quo(toGiveN, common.Big100)
 		toGive[pillar.Name] = toGiveN
 
-		// rewards to pillar, total - toGive
+		// the payout share is paid on top of GiveBlockRewardPercentage, up to the whole block reward
+		payout := big.NewInt(0)
+		if payoutEnforced {
+			if payout, err = computePillarPayout(context, pillar, reward, epoch); err != nil {
+				return err
+			}
+		}
+		payouts[pillar.Name] = payout
+
+		// rewards to pillar, total - toGive - payout
+		toPillar := new(big.Int).Sub(reward.TotalReward, toGiveN)
 		addReward(context, epoch, definition.RewardDeposit{
 			Address: &pillar.RewardWithdrawAddress,
-			Znn:     new(big.Int).Sub(reward.TotalReward, toGiveN),
+			Znn:     toPillar.Sub(toPillar, payout),
 			Qsr:     common.Big0,
 		})
 	}
@@ -442,13 +454,15 @@ func computeDetailedPillarReward(context vm_context.AccountVmContext, epoch uint
 			backersAmount.Add(backersAmount, amount)
 		}
 
+		payout := payouts[pillarDetail.Name]
+
 		// no weight, all rewards go to pillar reward address
 		if backersAmount.Cmp(common.Big0) == 0 {
 			for _, pillar := range pillarInfos {
 				if pillar.Name == pillarDetail.Name {
 					addReward(context, epoch, definition.RewardDeposit{
 						Address: &pillar.RewardWithdrawAddress,
-						Znn:     toBackers,
+						Znn:     new(big.Int).Add(toBackers, payout),
 						Qsr:     common.Big0,
 					})
 					break
@@ -467,6 +481,14 @@ func computeDetailedPillarReward(context vm_context.AccountVmContext, epoch uint
 				Znn:     toBacker,
 				Qsr:     common.Big0,
 			})
+
+			if payout.Sign() == 0 {
+				continue
+			}
+			toBacker = new(big.Int).Set(payout)
+			toBacker.Mul(toBacker, amount)
+			toBacker.Quo(toBacker, backersAmount)
+			addPillarPayout(context, address, toBacker)
 		}
 	}
 
@@ -485,6 +507,66 @@ func computeDetailedPillarReward(context vm_context.AccountVmContext, epoch uint
 	return nil
 }
 
+// computePillarPayout returns the payout share of the block reward of pillar in epoch, using the share committed for epoch
+func computePillarPayout(context vm_context.AccountVmContext, pillar *definition.PillarInfo, reward *pillarEpochReward, epoch uint64) (*big.Int, error) {
+	config, err := definition.GetPillarPayoutConfig(context.Storage(), pillar.Name)
+	if err != nil {
+		return nil, err
+	}
+	locked := config.IsLocked()
+	percentage := int64(config.PercentageForEpoch(epoch))
+	if locked && !config.IsLocked() {
+		pillarLog.Info("using the next pillar payout config", "pillar-name", pillar.Name, "epoch", epoch, "block-reward-percentage", percentage)
+		common.DealWithErr(config.Save(context.Storage()))
+	}
+
+	if percentage > 100-int64(pillar.GiveBlockRewardPercentage) {
+		percentage = 100 - int64(pillar.GiveBlockRewardPercentage)
+	}
+	payout := big.NewInt(percentage)
+	payout.Mul(payout, reward.BlockReward)
+	return payout.Quo(payout, common.Big100), nil
+}
+
+func addPillarPayout(context vm_context.AccountVmContext, address types.Address, amount *big.Int) {
+	payout, err := definition.GetPillarPayout(context.Storage(), address)
+	common.DealWithErr(err)
+	payout.Znn.Add(payout.Znn, amount)
+	common.DealWithErr(payout.Save(context.Storage()))
+}
+
+// sendPillarPayouts mints the payouts owed to the delegators, at most MaxBlocksPerUpdate at a time.
+// The payouts which don't fit are sent by the next updates.
+func sendPillarPayouts(context vm_context.AccountVmContext) ([]*nom.AccountBlock, error) {
+	payouts, err := definition.GetPillarPayoutList(context.Storage(), constants.MaxBlocksPerUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*nom.AccountBlock, 0, len(payouts))
+	for _, payout := range payouts {
+		common.DealWithErr(payout.Delete(context.Storage()))
+		if payout.Znn.Sign() == 0 {
+			continue
+		}
+		pillarLog.Debug("sending pillar payout", "address", payout.Address, "amount", payout.Znn)
+		result = append(result, &nom.AccountBlock{
+			Address:       types.PillarContract,
+			ToAddress:     types.TokenContract,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        big.NewInt(0),
+			TokenStandard: types.ZnnTokenStandard,
+			Data: definition.ABIToken.PackMethodPanic(
+				definition.MintMethodName,
+				types.ZnnTokenStandard,
+				payout.Znn,
+				payout.Address,
+			),
+		})
+	}
+	return result, nil
+}
+
 // raw reward for all pillars in one epoch
 func computePillarsRewardForEpoch(context vm_context.AccountVmContext, epoch uint64) (m map[string]*pillarEpochReward, err error) {
 	detailList, err := context.EpochStats(epoch)
@@ -556,23 +638,28 @@ func computePillarRewardForEpoch(detail *api.EpochStats, name string) *pillarEpo
 	return reward
 }
 
-func updatePillarRewards(context vm_context.AccountVmContext) error {
+func updatePillarRewards(context vm_context.AccountVmContext) ([]*nom.AccountBlock, error) {
 	lastEpoch, err := definition.GetLastEpochUpdate(context.Storage())
 	if err != nil {
-		return err
+		return nil, err
 	}
 	for {
 		if err := checkAndPerformUpdateEpoch(context, lastEpoch); err == constants.ErrEpochUpdateTooRecent {
 			pillarLog.Debug("invalid update - rewards not due yet", "epoch", lastEpoch.LastEpoch+1)
-			return nil
+			break
 		} else if err != nil {
 			pillarLog.Error("unknown panic", "reason", err)
-			return err
+			return nil, err
 		}
 		if err := computeDetailedPillarReward(context, uint64(lastEpoch.LastEpoch)); err != nil {
-			return err
+			return nil, err
 		}
 	}
+
+	if !context.IsSporkEnforced(types.PillarPayoutSpork) {
+		return nil, nil
+	}
+	return sendPillarPayouts(context)
 }
 
 type UpdatePillarMethod struct {
@@ -660,6 +747,81 @@ func (p *UpdatePillarMethod) ReceiveBlock(context vm_context.AccountVmContext, s
 	return nil, nil
 }
 
+// SetPayoutConfigMethod commits the pillar to a new payout share starting with the next epoch.
+// The share can't be changed again until the epoch update starts using it, so the epochs already produced keep their share.
+type SetPayoutConfigMethod struct {
+	MethodName string
+}
+
+func (p *SetPayoutConfigMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SetPayoutConfigMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.SetPayoutConfigParam)
+
+	if err := definition.ABIPillars.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if err := checkPillarNameStatic(param.Name); err != nil {
+		return err
+	}
+	if param.BlockRewardPercentage > 100 {
+		return constants.ErrForbiddenParam
+	}
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIPillars.PackMethod(p.MethodName, param.Name, param.BlockRewardPercentage)
+	return err
+}
+func (p *SetPayoutConfigMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.SetPayoutConfigParam)
+	err := definition.ABIPillars.UnpackMethod(param, p.MethodName, sendBlock.Data)
+	common.DealWithErr(err)
+
+	pillar, err := definition.GetPillarInfo(context.Storage(), param.Name)
+	if err == constants.ErrDataNonExistent {
+		return nil, err
+	} else {
+		common.DealWithErr(err)
+	}
+
+	if pillar.StakeAddress != sendBlock.Address {
+		return nil, constants.ErrPermissionDenied
+	}
+
+	if !pillar.IsActive() {
+		return nil, constants.ErrNotActive
+	}
+
+	// the payout share can't exceed the part of the block reward kept by the pillar
+	if int(param.BlockRewardPercentage)+int(pillar.GiveBlockRewardPercentage) > 100 {
+		return nil, constants.ErrForbiddenParam
+	}
+
+	config, err := definition.GetPillarPayoutConfig(context.Storage(), param.Name)
+	common.DealWithErr(err)
+	if config.IsLocked() {
+		return nil, constants.ErrPayoutConfigLocked
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	config.NextBlockRewardPercentage = param.BlockRewardPercentage
+	config.NextEpoch = context.EpochTicker().ToTick(*momentum.Timestamp) + 1
+
+	pillarLog.Info("Updating pillar payout config", "pillar-name", param.Name, "block-reward-percentage", param.BlockRewardPercentage, "epoch", config.NextEpoch)
+	common.DealWithErr(config.Save(context.Storage()))
+	return nil, nil
+}
+
 type DelegateMethod struct {
 	MethodName string
 }
@@ -786,8 +948,5 @@ func (p *UpdateEmbeddedPillarMethod) ReceiveBlock(context vm_context.AccountVmCo
 		return nil, err
 	}
 
-	if err := updatePillarRewards(context); err != nil {
-		return nil, err
-	}
-	return nil, nil
+	return updatePillarRewards(context)
 }
@@ -142,3 +142,64 @@ func (p *CancelFuseMethod) ReceiveBlock(context vm_context.AccountVmContext, sen
 		},
 	}, nil
 }
+
+// FuseRewardMethod is called by the stake contract to fuse auto-compounded QSR rewards.
+// The QSR is minted to the plasma contract separately, so the call itself doesn't carry any amount.
+type FuseRewardMethod struct {
+	MethodName string
+}
+
+func (p *FuseRewardMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *FuseRewardMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.FuseRewardParam)
+
+	if err := definition.ABIPlasma.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 || param.Amount.Cmp(constants.FuseMinAmount) < 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+	mod := new(big.Int).Mod(param.Amount, big.NewInt(constants.CostPerFusionUnit))
+	if mod.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIPlasma.PackMethod(p.MethodName, param.Owner, param.Amount)
+	return err
+}
+func (p *FuseRewardMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+	if sendBlock.Address != types.StakeContract {
+		return nil, constants.ErrPermissionDenied
+	}
+
+	param := new(definition.FuseRewardParam)
+	err := definition.ABIPlasma.UnpackMethod(param, p.MethodName, sendBlock.Data)
+	common.DealWithErr(err)
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	fusionInfo := definition.FusionInfo{
+		Owner:            param.Owner,
+		Id:               sendBlock.Hash,
+		Amount:           param.Amount,
+		Beneficiary:      param.Owner,
+		ExpirationHeight: momentum.Height + constants.FuseExpiration,
+	}
+	common.DealWithErr(fusionInfo.Save(context.Storage()))
+
+	fused, err := definition.GetFusedAmount(context.Storage(), param.Owner)
+	common.DealWithErr(err)
+	fused.Amount.Add(fused.Amount, param.Amount)
+	common.DealWithErr(fused.Save(context.Storage()))
+
+	plasmaLog.Debug("fused stake reward", "fusionInfo", fusionInfo, "beneficiary", fused)
+	return nil, nil
+}
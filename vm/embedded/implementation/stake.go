@@ -252,3 +252,332 @@ func updateStakeRewards(context vm_context.AccountVmContext) error {
 		}
 	}
 }
+
+// getActiveStakeInfo returns the stake entry which is not cancelled yet
+func getActiveStakeInfo(context vm_context.AccountVmContext, id types.Hash, address types.Address) (*definition.StakeInfo, error) {
+	stakeInfo, err := definition.GetStakeInfo(context.Storage(), id, address)
+	if err == constants.ErrDataNonExistent {
+		return nil, constants.ErrDataNonExistent
+	}
+	common.DealWithErr(err)
+	if stakeInfo.RevokeTime != 0 {
+		return nil, constants.ErrDataNonExistent
+	}
+	return stakeInfo, nil
+}
+
+// getProportionalWeightedAmount returns the part of the weighted amount of stakeInfo which corresponds to amount
+func getProportionalWeightedAmount(stakeInfo *definition.StakeInfo, amount *big.Int) *big.Int {
+	weighted := new(big.Int).Mul(stakeInfo.WeightedAmount, amount)
+	return weighted.Quo(weighted, stakeInfo.Amount)
+}
+
+// replaceStakeInfo revokes stakeInfo and saves replacement, identified by the hash of sendBlock, in its place.
+// The revoked entry still gets rewards up to the revoke time, so the rewards of the epoch are split correctly between the two.
+func replaceStakeInfo(context vm_context.AccountVmContext, stakeInfo *definition.StakeInfo, replacement *definition.StakeInfo, sendBlock *nom.AccountBlock) {
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	stakeInfo.RevokeTime = momentum.Timestamp.Unix()
+	stakeInfo.Amount = common.Big0
+	common.DealWithErr(stakeInfo.Save(context.Storage()))
+
+	replacement.StartTime = momentum.Timestamp.Unix()
+	replacement.StakeAddress = sendBlock.Address
+	replacement.Id = sendBlock.Hash
+	common.DealWithErr(replacement.Save(context.Storage()))
+}
+
+type ExtendStakeMethod struct {
+	MethodName string
+}
+
+func (p *ExtendStakeMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *ExtendStakeMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.ExtendStakeParam)
+
+	if err := definition.ABIStake.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+	if param.DurationInSec < constants.StakeTimeMinSec || param.DurationInSec > constants.StakeTimeMaxSec || param.DurationInSec%constants.StakeTimeUnitSec != 0 {
+		return constants.ErrInvalidStakingPeriod
+	}
+
+	block.Data, err = definition.ABIStake.PackMethod(p.MethodName, param.Id, param.DurationInSec)
+	return err
+}
+
+// ReceiveBlock locks the entry again for durationInSec, starting now. The new expiration time can't be earlier than
+// the current one. The extended entry replaces the old one and is identified by the hash of the send-block.
+func (p *ExtendStakeMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.ExtendStakeParam)
+	common.DealWithErr(definition.ABIStake.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	stakeInfo, err := getActiveStakeInfo(context, param.Id, sendBlock.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	if momentum.Timestamp.Unix()+param.DurationInSec < stakeInfo.ExpirationTime {
+		return nil, constants.ErrInvalidStakingPeriod
+	}
+
+	extended := &definition.StakeInfo{
+		Amount:         stakeInfo.Amount,
+		WeightedAmount: getWeightedStakeAmount(stakeInfo.Amount, param.DurationInSec),
+		ExpirationTime: momentum.Timestamp.Unix() + param.DurationInSec,
+	}
+	replaceStakeInfo(context, stakeInfo, extended, sendBlock)
+
+	stakeLog.Debug("extended stake entry", "id", stakeInfo.Id, "new-id", extended.Id, "owner", extended.StakeAddress, "weighted-amount", extended.WeightedAmount, "expiration-time", extended.ExpirationTime)
+	return nil, nil
+}
+
+type SplitStakeMethod struct {
+	MethodName string
+}
+
+func (p *SplitStakeMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SplitStakeMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.StakeAmountParam)
+
+	if err := definition.ABIStake.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 || param.Amount.Cmp(constants.StakeMinAmount) == -1 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIStake.PackMethod(p.MethodName, param.Id, param.Amount)
+	return err
+}
+
+// ReceiveBlock moves amount from the entry into a new entry, identified by the hash of the send-block,
+// with the same start and expiration time. Both entries need to keep at least the minimum stake amount.
+func (p *SplitStakeMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.StakeAmountParam)
+	common.DealWithErr(definition.ABIStake.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	stakeInfo, err := getActiveStakeInfo(context, param.Id, sendBlock.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := new(big.Int).Sub(stakeInfo.Amount, param.Amount)
+	if remaining.Cmp(constants.StakeMinAmount) == -1 {
+		return nil, constants.ErrInvalidTokenOrAmount
+	}
+
+	split := &definition.StakeInfo{
+		Amount:         param.Amount,
+		WeightedAmount: getProportionalWeightedAmount(stakeInfo, param.Amount),
+		StartTime:      stakeInfo.StartTime,
+		RevokeTime:     0,
+		ExpirationTime: stakeInfo.ExpirationTime,
+		StakeAddress:   sendBlock.Address,
+		Id:             sendBlock.Hash,
+	}
+	stakeInfo.Amount = remaining
+	stakeInfo.WeightedAmount = new(big.Int).Sub(stakeInfo.WeightedAmount, split.WeightedAmount)
+	common.DealWithErr(stakeInfo.Save(context.Storage()))
+	common.DealWithErr(split.Save(context.Storage()))
+
+	stakeLog.Debug("split stake entry", "id", stakeInfo.Id, "new-id", split.Id, "owner", split.StakeAddress, "remaining", stakeInfo.Amount, "split-amount", split.Amount)
+	return nil, nil
+}
+
+type CancelPartialStakeMethod struct {
+	MethodName string
+}
+
+func (p *CancelPartialStakeMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *CancelPartialStakeMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.StakeAmountParam)
+
+	if err := definition.ABIStake.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 || param.Amount.Sign() != 1 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIStake.PackMethod(p.MethodName, param.Id, param.Amount)
+	return err
+}
+
+// ReceiveBlock returns amount from an expired entry. The remaining amount, which needs to be at least the minimum
+// stake amount, is kept in a new expired entry identified by the hash of the send-block. Use Cancel to return the whole amount.
+func (p *CancelPartialStakeMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.StakeAmountParam)
+	common.DealWithErr(definition.ABIStake.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	stakeInfo, err := getActiveStakeInfo(context, param.Id, sendBlock.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	if stakeInfo.ExpirationTime > momentum.Timestamp.Unix() {
+		return nil, constants.RevokeNotDue
+	}
+
+	remaining := new(big.Int).Sub(stakeInfo.Amount, param.Amount)
+	if remaining.Cmp(constants.StakeMinAmount) == -1 {
+		return nil, constants.ErrInvalidTokenOrAmount
+	}
+
+	kept := &definition.StakeInfo{
+		Amount:         remaining,
+		WeightedAmount: getProportionalWeightedAmount(stakeInfo, remaining),
+		ExpirationTime: momentum.Timestamp.Unix(),
+	}
+	replaceStakeInfo(context, stakeInfo, kept, sendBlock)
+
+	stakeLog.Debug("partially revoked stake entry", "id", stakeInfo.Id, "new-id", kept.Id, "owner", kept.StakeAddress, "revoked-amount", param.Amount, "remaining", kept.Amount)
+
+	return []*nom.AccountBlock{
+		{
+			Address:       types.StakeContract,
+			ToAddress:     sendBlock.Address,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        param.Amount,
+			TokenStandard: types.ZnnTokenStandard,
+			Data:          nil,
+		},
+	}, nil
+}
+
+type SetAutoCompoundMethod struct {
+	MethodName string
+}
+
+func (p *SetAutoCompoundMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SetAutoCompoundMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	enabled := new(bool)
+
+	if err := definition.ABIStake.UnpackMethod(enabled, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIStake.PackMethod(p.MethodName, *enabled)
+	return err
+}
+func (p *SetAutoCompoundMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	enabled := new(bool)
+	common.DealWithErr(definition.ABIStake.UnpackMethod(enabled, p.MethodName, sendBlock.Data))
+	common.DealWithErr(definition.SetStakeAutoCompound(context.Storage(), sendBlock.Address, *enabled))
+
+	stakeLog.Debug("set auto-compound", "owner", sendBlock.Address, "enabled", *enabled)
+	return nil, nil
+}
+
+// CollectStakeRewardMethod replaces CollectRewardMethod for the stake contract.
+// For addresses which enabled auto-compounding, the QSR reward is fused for plasma, with the address as owner and beneficiary,
+// instead of being sent to the address. Only multiples of constants.CostPerFusionUnit are fused, the rest is sent as usual.
+type CollectStakeRewardMethod struct {
+	CollectRewardMethod
+}
+
+func (p *CollectStakeRewardMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	autoCompound, err := definition.IsStakeAutoCompound(context.Storage(), sendBlock.Address)
+	common.DealWithErr(err)
+	if !autoCompound {
+		return p.CollectRewardMethod.ReceiveBlock(context, sendBlock)
+	}
+
+	deposit, err := definition.GetRewardDeposit(context.Storage(), &sendBlock.Address)
+	common.DealWithErr(err)
+
+	fused := new(big.Int).Mod(deposit.Qsr, big.NewInt(constants.CostPerFusionUnit))
+	fused.Sub(deposit.Qsr, fused)
+	if fused.Cmp(constants.FuseMinAmount) == -1 {
+		return p.CollectRewardMethod.ReceiveBlock(context, sendBlock)
+	}
+
+	result := []*nom.AccountBlock{
+		{
+			Address:       types.StakeContract,
+			ToAddress:     types.TokenContract,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        big.NewInt(0),
+			TokenStandard: types.ZnnTokenStandard,
+			Data: definition.ABIToken.PackMethodPanic(
+				definition.MintMethodName,
+				types.QsrTokenStandard,
+				fused,
+				types.PlasmaContract,
+			),
+		},
+		{
+			Address:       types.StakeContract,
+			ToAddress:     types.PlasmaContract,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        big.NewInt(0),
+			TokenStandard: types.ZnnTokenStandard,
+			Data: definition.ABIPlasma.PackMethodPanic(
+				definition.FuseRewardMethodName,
+				sendBlock.Address,
+				fused,
+			),
+		},
+	}
+	stakeLog.Debug("auto-compounding stake reward", "owner", sendBlock.Address, "fused-amount", fused)
+
+	deposit.Qsr.Sub(deposit.Qsr, fused)
+	if deposit.Znn.Sign() == 0 && deposit.Qsr.Sign() == 0 {
+		common.DealWithErr(deposit.Delete(context.Storage()))
+		return result, nil
+	}
+	common.DealWithErr(deposit.Save(context.Storage()))
+
+	remaining, err := p.CollectRewardMethod.ReceiveBlock(context, sendBlock)
+	if err != nil {
+		return nil, err
+	}
+	return append(result, remaining...), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain/nom"
 	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
@@ -153,6 +154,32 @@ func (p *IssueMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBloc
 	}, nil
 }
 
+// consumeMintCap adds amount to the amount minted in the current epoch, if the token policy caps it
+func consumeMintCap(context vm_context.AccountVmContext, ts types.ZenonTokenStandard, amount *big.Int) error {
+	policy, err := definition.GetTokenPolicy(context.Storage(), ts)
+	common.DealWithErr(err)
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+	epoch := context.EpochTicker().ToTick(*momentum.Timestamp)
+
+	mintable := policy.MintableInEpoch(epoch)
+	if mintable == nil {
+		return nil
+	}
+	if mintable.Cmp(amount) < 0 {
+		return constants.ErrTokenMintCapped
+	}
+
+	if policy.MintEpoch != epoch {
+		policy.MintEpoch = epoch
+		policy.MintedInEpoch = big.NewInt(0)
+	}
+	policy.MintedInEpoch.Add(policy.MintedInEpoch, amount)
+	common.DealWithErr(policy.Save(context.Storage()))
+	return nil
+}
+
 type MintMethod struct {
 	MethodName string
 }
@@ -211,6 +238,10 @@ func (p *MintMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock
 		return nil, constants.ErrPermissionDenied
 	}
 
+	if err := consumeMintCap(context, param.TokenStandard, param.Amount); err != nil {
+		return nil, err
+	}
+
 	tokenInfo.TotalSupply.Add(tokenInfo.TotalSupply, param.Amount)
 	common.DealWithErr(tokenInfo.Save(context.Storage()))
 
@@ -349,3 +380,197 @@ func (p *UpdateTokenMethod) ReceiveBlock(context vm_context.AccountVmContext, se
 	common.DealWithErr(tokenInfo.Save(context.Storage()))
 	return nil, nil
 }
+
+// getOwnedTokenInfo returns the token info of ts if address is its owner.
+// The policies of ZNN and QSR can't be changed.
+func getOwnedTokenInfo(context vm_context.AccountVmContext, ts types.ZenonTokenStandard, address types.Address) (*definition.TokenInfo, error) {
+	if ts == types.ZnnTokenStandard || ts == types.QsrTokenStandard {
+		return nil, constants.ErrPermissionDenied
+	}
+	tokenInfo, err := definition.GetTokenInfo(context.Storage(), ts)
+	if err == constants.ErrDataNonExistent {
+		return nil, err
+	}
+	common.DealWithErr(err)
+
+	if tokenInfo.Owner != address {
+		return nil, constants.ErrPermissionDenied
+	}
+	return tokenInfo, nil
+}
+
+type SetTokenPolicyMethod struct {
+	MethodName string
+}
+
+func (p *SetTokenPolicyMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SetTokenPolicyMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.TokenPolicyParam)
+
+	if err := definition.ABIToken.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if param.MintCapPerEpoch.Sign() < 0 || param.MintCapPerEpoch.Cmp(constants.TokenMaxSupplyBig) > 0 {
+		return constants.ErrTokenInvalidAmount
+	}
+	if block.Amount.Sign() > 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIToken.PackMethod(p.MethodName, param.TokenStandard, param.UseAllowlist, param.MintCapPerEpoch)
+	return err
+}
+func (p *SetTokenPolicyMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.TokenPolicyParam)
+	err := definition.ABIToken.UnpackMethod(param, p.MethodName, sendBlock.Data)
+	common.DealWithErr(err)
+
+	if _, err := getOwnedTokenInfo(context, param.TokenStandard, sendBlock.Address); err != nil {
+		return nil, err
+	}
+
+	policy, err := definition.GetTokenPolicy(context.Storage(), param.TokenStandard)
+	common.DealWithErr(err)
+	policy.UseAllowlist = param.UseAllowlist
+	policy.MintCapPerEpoch = param.MintCapPerEpoch
+	common.DealWithErr(policy.Save(context.Storage()))
+
+	tokenLog.Debug("updated token policy", "token-standard", param.TokenStandard, "use-allowlist", policy.UseAllowlist, "mint-cap-per-epoch", policy.MintCapPerEpoch)
+	return nil, nil
+}
+
+type SetAddressFrozenMethod struct {
+	MethodName string
+}
+
+func (p *SetAddressFrozenMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SetAddressFrozenMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.SetAddressFrozenParam)
+
+	if err := definition.ABIToken.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() > 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIToken.PackMethod(p.MethodName, param.TokenStandard, param.Address, param.IsFrozen)
+	return err
+}
+func (p *SetAddressFrozenMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.SetAddressFrozenParam)
+	err := definition.ABIToken.UnpackMethod(param, p.MethodName, sendBlock.Data)
+	common.DealWithErr(err)
+
+	if _, err := getOwnedTokenInfo(context, param.TokenStandard, sendBlock.Address); err != nil {
+		return nil, err
+	}
+
+	policy, err := definition.GetTokenAddressPolicy(context.Storage(), param.TokenStandard, param.Address)
+	common.DealWithErr(err)
+	policy.IsFrozen = param.IsFrozen
+	common.DealWithErr(policy.Save(context.Storage()))
+
+	tokenLog.Debug("updated token address policy", "token-standard", param.TokenStandard, "address", param.Address, "is-frozen", policy.IsFrozen)
+	return nil, nil
+}
+
+type SetAddressAllowedMethod struct {
+	MethodName string
+}
+
+func (p *SetAddressAllowedMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *SetAddressAllowedMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.SetAddressAllowedParam)
+
+	if err := definition.ABIToken.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() > 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIToken.PackMethod(p.MethodName, param.TokenStandard, param.Address, param.IsAllowed)
+	return err
+}
+func (p *SetAddressAllowedMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.SetAddressAllowedParam)
+	err := definition.ABIToken.UnpackMethod(param, p.MethodName, sendBlock.Data)
+	common.DealWithErr(err)
+
+	if _, err := getOwnedTokenInfo(context, param.TokenStandard, sendBlock.Address); err != nil {
+		return nil, err
+	}
+
+	policy, err := definition.GetTokenAddressPolicy(context.Storage(), param.TokenStandard, param.Address)
+	common.DealWithErr(err)
+	policy.IsAllowed = param.IsAllowed
+	common.DealWithErr(policy.Save(context.Storage()))
+
+	tokenLog.Debug("updated token address policy", "token-standard", param.TokenStandard, "address", param.Address, "is-allowed", policy.IsAllowed)
+	return nil, nil
+}
+
+// CheckTransferPolicy checks the policies set by the owner of the token for a transfer of block.TokenStandard from sender.
+// The sender is passed separately since the descendant blocks of embedded contracts don't have their address set yet.
+// Embedded contracts are restricted like any other sender, the owner of the token has to allow them when using an allowlist.
+// The owner of the token and the token contract, which sends issued and minted tokens, are not restricted.
+// Tokens can always be sent back to the token contract, so that they can be burned.
+func CheckTransferPolicy(tokenStorage db.DB, sender types.Address, block *nom.AccountBlock) error {
+	if block.Amount.Sign() == 0 || sender == types.TokenContract || block.ToAddress == types.TokenContract {
+		return nil
+	}
+	if block.TokenStandard == types.ZnnTokenStandard || block.TokenStandard == types.QsrTokenStandard {
+		return nil
+	}
+
+	tokenInfo, err := definition.GetTokenInfo(tokenStorage, block.TokenStandard)
+	if err == constants.ErrDataNonExistent {
+		return nil
+	}
+	common.DealWithErr(err)
+	if tokenInfo.Owner == sender {
+		return nil
+	}
+
+	senderPolicy, err := definition.GetTokenAddressPolicy(tokenStorage, block.TokenStandard, sender)
+	common.DealWithErr(err)
+	if senderPolicy.IsFrozen {
+		return constants.ErrTokenAddressFrozen
+	}
+
+	policy, err := definition.GetTokenPolicy(tokenStorage, block.TokenStandard)
+	common.DealWithErr(err)
+	if policy.UseAllowlist && block.ToAddress != tokenInfo.Owner {
+		receiver, err := definition.GetTokenAddressPolicy(tokenStorage, block.TokenStandard, block.ToAddress)
+		common.DealWithErr(err)
+		if !receiver.IsAllowed {
+			return constants.ErrTokenNotAllowed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package implementation
+
+import (
+	"math/big"
+
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/vm_context"
+)
+
+var (
+	vestingLog = common.EmbeddedLogger.New("contract", "vesting")
+)
+
+func checkScheduleStatic(param *definition.CreateScheduleParam) error {
+	if param.StartTime < 0 || param.CliffDuration < 0 {
+		return constants.ErrInvalidVestingSchedule
+	}
+	if param.VestingDuration <= 0 || param.VestingDuration > constants.VestingDurationMax {
+		return constants.ErrInvalidVestingSchedule
+	}
+	if param.CliffDuration > param.VestingDuration {
+		return constants.ErrInvalidVestingSchedule
+	}
+	if types.IsEmbeddedAddress(param.Beneficiary) {
+		return constants.ErrForbiddenParam
+	}
+	return nil
+}
+
+type CreateScheduleMethod struct {
+	MethodName string
+}
+
+func (p *CreateScheduleMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedSimple, nil
+}
+func (p *CreateScheduleMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	param := new(definition.CreateScheduleParam)
+
+	if err := definition.ABIVesting.UnpackMethod(param, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if err := checkScheduleStatic(param); err != nil {
+		return err
+	}
+
+	if block.Amount.Sign() == 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIVesting.PackMethod(p.MethodName, param.Beneficiary, param.StartTime, param.CliffDuration, param.VestingDuration)
+	return err
+}
+func (p *CreateScheduleMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	param := new(definition.CreateScheduleParam)
+	common.DealWithErr(definition.ABIVesting.UnpackMethod(param, p.MethodName, sendBlock.Data))
+
+	schedule := &definition.VestingSchedule{
+		VestingScheduleKey: definition.VestingScheduleKey{
+			Beneficiary: param.Beneficiary,
+			Id:          sendBlock.Hash,
+		},
+		Creator:         sendBlock.Address,
+		TokenStandard:   sendBlock.TokenStandard,
+		TotalAmount:     sendBlock.Amount,
+		WithdrawnAmount: big.NewInt(0),
+		StartTime:       param.StartTime,
+		CliffDuration:   param.CliffDuration,
+		VestingDuration: param.VestingDuration,
+	}
+	schedule.Save(context.Storage())
+
+	vestingLog.Debug("created vesting schedule", "id", schedule.Id, "creator", schedule.Creator, "beneficiary", schedule.Beneficiary, "zts", schedule.TokenStandard, "amount", schedule.TotalAmount, "start-time", schedule.StartTime, "cliff-duration", schedule.CliffDuration, "vesting-duration", schedule.VestingDuration)
+	return nil, nil
+}
+
+type WithdrawVestingMethod struct {
+	MethodName string
+}
+
+func (p *WithdrawVestingMethod) GetPlasma(plasmaTable *constants.PlasmaTable) (uint64, error) {
+	return plasmaTable.EmbeddedWWithdraw, nil
+}
+func (p *WithdrawVestingMethod) ValidateSendBlock(block *nom.AccountBlock) error {
+	var err error
+	id := new(types.Hash)
+
+	if err := definition.ABIVesting.UnpackMethod(id, p.MethodName, block.Data); err != nil {
+		return constants.ErrUnpackError
+	}
+
+	if block.Amount.Sign() != 0 {
+		return constants.ErrInvalidTokenOrAmount
+	}
+
+	block.Data, err = definition.ABIVesting.PackMethod(p.MethodName, id)
+	return err
+}
+func (p *WithdrawVestingMethod) ReceiveBlock(context vm_context.AccountVmContext, sendBlock *nom.AccountBlock) ([]*nom.AccountBlock, error) {
+	if err := p.ValidateSendBlock(sendBlock); err != nil {
+		return nil, err
+	}
+
+	id := new(types.Hash)
+	common.DealWithErr(definition.ABIVesting.UnpackMethod(id, p.MethodName, sendBlock.Data))
+
+	// schedules are keyed by beneficiary, so only the beneficiary can find its schedules
+	schedule, err := definition.GetVestingSchedule(context.Storage(), sendBlock.Address, *id)
+	if err != nil {
+		return nil, err
+	}
+
+	momentum, err := context.GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	amount := schedule.WithdrawableAmount(momentum.Timestamp.Unix())
+	if amount.Sign() <= 0 {
+		return nil, constants.ErrNothingToWithdraw
+	}
+
+	schedule.WithdrawnAmount.Add(schedule.WithdrawnAmount, amount)
+	if schedule.WithdrawnAmount.Cmp(schedule.TotalAmount) == 0 {
+		schedule.Delete(context.Storage())
+	} else {
+		schedule.Save(context.Storage())
+	}
+
+	vestingLog.Debug("withdrew vested amount", "id", schedule.Id, "beneficiary", schedule.Beneficiary, "zts", schedule.TokenStandard, "amount", amount, "withdrawn-amount", schedule.WithdrawnAmount)
+	return []*nom.AccountBlock{
+		{
+			Address:       types.VestingContract,
+			ToAddress:     schedule.Beneficiary,
+			BlockType:     nom.BlockTypeContractSend,
+			Amount:        amount,
+			TokenStandard: schedule.TokenStandard,
+			Data:          nil,
+		},
+	}, nil
+}
@@ -0,0 +1,231 @@
+package embedded
+
+import (
+	"sync"
+
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	cabi "github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/vm/embedded/implementation"
+	"github.com/zenon-network/go-zenon/vm/vm_context"
+)
+
+// sporkUpgrade adds or replaces methods of embedded contracts once its spork is enforced.
+// The abi of each upgraded contract replaces the previous one and contracts which don't exist yet are added.
+type sporkUpgrade struct {
+	spork     *types.ImplementedSpork
+	contracts map[types.Address]*embeddedImplementation
+}
+
+var (
+	// sporkUpgrades is the registry of the embedded contract changes gated by sporks, applied in order.
+	// Future contract upgrades only need a new entry here and a new spork in types.ImplementedSporks.
+	sporkUpgrades = []*sporkUpgrade{
+		{
+			spork:     types.AcceleratorSpork,
+			contracts: getAcceleratorUpgrade(),
+		},
+		{
+			spork:     types.MultisigSpork,
+			contracts: getMultisigUpgrade(),
+		},
+		{
+			spork:     types.VestingSpork,
+			contracts: getVestingUpgrade(),
+		},
+		{
+			spork:     types.HtlcSpork,
+			contracts: getHtlcUpgrade(),
+		},
+		{
+			spork:     types.TokenPolicySpork,
+			contracts: getTokenPolicyUpgrade(),
+		},
+		{
+			spork:     types.StakeSpork,
+			contracts: getStakeUpgrade(),
+		},
+		{
+			spork:     types.PillarPayoutSpork,
+			contracts: getPillarPayoutUpgrade(),
+		},
+	}
+
+	// originEmbedded has none of the upgrades applied
+	originEmbedded = getOrigin()
+	// latestEmbedded has all the upgrades applied, regardless of the sporks which are enforced
+	latestEmbedded = buildContracts(func(int) bool { return true })
+
+	contractsLock  sync.Mutex
+	contractsCache = make(map[uint64]map[types.Address]*embeddedImplementation)
+)
+
+func getAcceleratorUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.AcceleratorContract: {
+			map[string]Method{
+				cabi.DonateMethodName:        &implementation.DonateMethod{cabi.DonateMethodName},
+				cabi.CreateProjectMethodName: &implementation.CreateProjectMethod{cabi.CreateProjectMethodName},
+				cabi.AddPhaseMethodName:      &implementation.AddPhaseMethod{cabi.AddPhaseMethodName},
+				cabi.UpdateMethodName:        &implementation.UpdateEmbeddedAcceleratorMethod{cabi.UpdateMethodName},
+				cabi.UpdatePhaseMethodName:   &implementation.UpdatePhaseMethod{cabi.UpdatePhaseMethodName},
+				// common
+				cabi.VoteByNameMethodName:        &implementation.VoteByNameMethod{cabi.VoteByNameMethodName},
+				cabi.VoteByProdAddressMethodName: &implementation.VoteByProdAddressMethod{cabi.VoteByProdAddressMethodName},
+			},
+			cabi.ABIAccelerator,
+		},
+		types.PillarContract: {
+			map[string]Method{
+				cabi.CollectRewardMethodName: &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple},
+			},
+			cabi.ABIPillars,
+		},
+		types.SentinelContract: {
+			map[string]Method{
+				cabi.CollectRewardMethodName: &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple},
+			},
+			cabi.ABISentinel,
+		},
+		types.StakeContract: {
+			map[string]Method{
+				cabi.CollectRewardMethodName: &implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple},
+			},
+			cabi.ABIStake,
+		},
+		types.LiquidityContract: {
+			map[string]Method{
+				cabi.FundMethodName:    &implementation.FundMethod{cabi.FundMethodName},
+				cabi.BurnZnnMethodName: &implementation.BurnZnnMethod{cabi.BurnZnnMethodName},
+			},
+			cabi.ABILiquidity,
+		},
+	}
+}
+
+func getMultisigUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.MultisigContract: {
+			map[string]Method{
+				cabi.CreateWalletMethodName:    &implementation.CreateWalletMethod{cabi.CreateWalletMethodName},
+				cabi.DepositMultisigMethodName: &implementation.DepositMultisigMethod{cabi.DepositMultisigMethodName},
+				cabi.ProposeMethodName:         &implementation.ProposeMethod{cabi.ProposeMethodName},
+				cabi.ApproveMethodName:         &implementation.ApproveMethod{cabi.ApproveMethodName},
+			},
+			cabi.ABIMultisig,
+		},
+	}
+}
+
+func getVestingUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.VestingContract: {
+			map[string]Method{
+				cabi.CreateScheduleMethodName:  &implementation.CreateScheduleMethod{cabi.CreateScheduleMethodName},
+				cabi.WithdrawVestingMethodName: &implementation.WithdrawVestingMethod{cabi.WithdrawVestingMethodName},
+			},
+			cabi.ABIVesting,
+		},
+	}
+}
+
+func getHtlcUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.HtlcContract: {
+			map[string]Method{
+				cabi.CreateHtlcMethodName:  &implementation.CreateHtlcMethod{cabi.CreateHtlcMethodName},
+				cabi.ReclaimHtlcMethodName: &implementation.ReclaimHtlcMethod{cabi.ReclaimHtlcMethodName},
+				cabi.UnlockHtlcMethodName:  &implementation.UnlockHtlcMethod{cabi.UnlockHtlcMethodName},
+			},
+			cabi.ABIHtlc,
+		},
+	}
+}
+
+func getTokenPolicyUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.TokenContract: {
+			map[string]Method{
+				cabi.SetTokenPolicyMethodName:    &implementation.SetTokenPolicyMethod{cabi.SetTokenPolicyMethodName},
+				cabi.SetAddressFrozenMethodName:  &implementation.SetAddressFrozenMethod{cabi.SetAddressFrozenMethodName},
+				cabi.SetAddressAllowedMethodName: &implementation.SetAddressAllowedMethod{cabi.SetAddressAllowedMethodName},
+			},
+			cabi.ABIToken,
+		},
+	}
+}
+
+func getStakeUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.StakeContract: {
+			map[string]Method{
+				cabi.ExtendStakeMethodName:        &implementation.ExtendStakeMethod{cabi.ExtendStakeMethodName},
+				cabi.SplitStakeMethodName:         &implementation.SplitStakeMethod{cabi.SplitStakeMethodName},
+				cabi.CancelPartialStakeMethodName: &implementation.CancelPartialStakeMethod{cabi.CancelPartialStakeMethodName},
+				cabi.SetAutoCompoundMethodName:    &implementation.SetAutoCompoundMethod{cabi.SetAutoCompoundMethodName},
+				cabi.CollectRewardMethodName:      &implementation.CollectStakeRewardMethod{implementation.CollectRewardMethod{cabi.CollectRewardMethodName, constants.AlphanetPlasmaTable.EmbeddedSimple}},
+			},
+			cabi.ABIStake,
+		},
+		types.PlasmaContract: {
+			map[string]Method{
+				cabi.FuseRewardMethodName: &implementation.FuseRewardMethod{cabi.FuseRewardMethodName},
+				cabi.DonateMethodName:     &implementation.DonateMethod{cabi.DonateMethodName},
+			},
+			cabi.ABIPlasma,
+		},
+	}
+}
+
+func getPillarPayoutUpgrade() map[types.Address]*embeddedImplementation {
+	return map[types.Address]*embeddedImplementation{
+		types.PillarContract: {
+			map[string]Method{
+				cabi.SetPayoutConfigMethodName: &implementation.SetPayoutConfigMethod{cabi.SetPayoutConfigMethodName},
+			},
+			cabi.ABIPillars,
+		},
+	}
+}
+
+// buildContracts applies on top of the origin contracts the upgrades for which enforced returns true
+func buildContracts(enforced func(index int) bool) map[types.Address]*embeddedImplementation {
+	contracts := getOrigin()
+	for index, upgrade := range sporkUpgrades {
+		if !enforced(index) {
+			continue
+		}
+		for address, upgraded := range upgrade.contracts {
+			contract, ok := contracts[address]
+			if !ok {
+				contract = &embeddedImplementation{m: make(map[string]Method)}
+				contracts[address] = contract
+			}
+			for name, method := range upgraded.m {
+				contract.m[name] = method
+			}
+			contract.abi = upgraded.abi
+		}
+	}
+	return contracts
+}
+
+// getContracts returns the embedded contracts with the upgrades of all the sporks enforced in context
+func getContracts(context vm_context.AccountVmContext) map[types.Address]*embeddedImplementation {
+	enforced := context.EnforcedSporks()
+	mask := uint64(0)
+	for index, upgrade := range sporkUpgrades {
+		if enforced[upgrade.spork.SporkId] {
+			mask |= 1 << index
+		}
+	}
+
+	contractsLock.Lock()
+	defer contractsLock.Unlock()
+	if contracts, ok := contractsCache[mask]; ok {
+		return contracts
+	}
+	contracts := buildContracts(func(index int) bool { return mask&(1<<index) != 0 })
+	contractsCache[mask] = contracts
+	return contracts
+}
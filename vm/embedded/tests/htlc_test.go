@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/crypto"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+var (
+	htlcPreimage = []byte("all your znn are belong to us")
+)
+
+func createHtlc(expirationTime int64, hashType uint8, hashLock []byte) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.HtlcContract,
+		Data: definition.ABIHtlc.PackMethodPanic(definition.CreateHtlcMethodName,
+			g.User2.Address,          // hashLocked
+			expirationTime,           // expirationTime
+			hashType,                 // hashType
+			uint8(len(htlcPreimage)), // keyMaxSize
+			hashLock,                 // hashLock
+		),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}
+}
+
+func unlockHtlc(address types.Address, id types.Hash, preimage []byte) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   address,
+		ToAddress: types.HtlcContract,
+		Data:      definition.ABIHtlc.PackMethodPanic(definition.UnlockHtlcMethodName, id, preimage),
+	}
+}
+
+func reclaimHtlc(address types.Address, id types.Hash) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   address,
+		ToAddress: types.HtlcContract,
+		Data:      definition.ABIHtlc.PackMethodPanic(definition.ReclaimHtlcMethodName, id),
+	}
+}
+
+func frontierTimestamp(t *testing.T, z mock.MockZenon) int64 {
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	return frontier.Timestamp.Unix()
+}
+
+func TestHtlc_InvalidCreate(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+
+	hashLock := crypto.Hash(htlcPreimage)
+	z.InsertSendBlock(createHtlc(frontierTimestamp(t, z)+100, definition.HashTypeSHA3, hashLock), constants.ErrContractDoesntExist, mock.SkipVmChanges)
+	activateSpork(t, z, types.HtlcSpork, "htlc")
+
+	z.InsertSendBlock(createHtlc(frontierTimestamp(t, z)+100, definition.HashTypeNotValid, hashLock), constants.ErrInvalidHashType, mock.SkipVmChanges)
+	z.InsertSendBlock(createHtlc(frontierTimestamp(t, z)+100, definition.HashTypeSHA3, hashLock[:20]), constants.ErrInvalidHashDigest, mock.SkipVmChanges)
+	defer z.CallContract(createHtlc(frontierTimestamp(t, z)-10, definition.HashTypeSHA3, hashLock)).Error(t, constants.ErrInvalidExpirationTime)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 0)
+}
+
+// Test that only the hash-locked address can unlock the htlc and only with the right preimage
+func TestHtlc_Unlock(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.HtlcSpork, "htlc")
+	htlcAPI := embedded.NewHtlcApi(z)
+
+	hashLock := sha256.Sum256(htlcPreimage)
+	defer z.CallContract(createHtlc(frontierTimestamp(t, z)+1000, definition.HashTypeSHA256, hashLock[:])).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 10*g.Zexp)
+
+	htlcList, err := htlcAPI.GetHashLockedBy(g.User2.Address, 0, 10)
+	common.FailIfErr(t, err)
+	common.Json(htlcList.Count, nil).Equals(t, `1`)
+	id := htlcList.List[0].Id
+	common.Json(htlcAPI.GetTimeLockedBy(g.User1.Address, 0, 10)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"id": "`+id.String()+`",
+			"timeLocked": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"hashLocked": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"amount": 1000000000,
+			"expirationTime": 1000001190,
+			"hashType": 1,
+			"keyMaxSize": 29,
+			"hashLock": "9Eir0nEEcmE2J4zS4sgVFszoWRykiPaasI1sD3ZFigk="
+		}
+	]
+}`)
+
+	defer z.CallContract(unlockHtlc(g.User3.Address, id, htlcPreimage)).Error(t, constants.ErrPermissionDenied)
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, []byte("wrong preimage"))).Error(t, constants.ErrInvalidPreimage)
+	// the time-locked address can't reclaim before the expiration
+	defer z.CallContract(reclaimHtlc(g.User1.Address, id)).Error(t, constants.ErrHtlcNotExpired)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 10*g.Zexp)
+
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, htlcPreimage)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 0)
+	common.Json(htlcAPI.GetById(id)).Error(t, constants.ErrDataNonExistent)
+}
+
+// Test that the time-locked address can reclaim the funds once the htlc expires
+func TestHtlc_Reclaim(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.HtlcSpork, "htlc")
+	htlcAPI := embedded.NewHtlcApi(z)
+
+	defer z.CallContract(createHtlc(frontierTimestamp(t, z)+100, definition.HashTypeSHA3, crypto.Hash(htlcPreimage))).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	htlcList, err := htlcAPI.GetTimeLockedBy(g.User1.Address, 0, 10)
+	common.FailIfErr(t, err)
+	id := htlcList.List[0].Id
+
+	z.InsertMomentumsTo(40)
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, htlcPreimage)).Error(t, constants.ErrHtlcExpired)
+	defer z.CallContract(reclaimHtlc(g.User2.Address, id)).Error(t, constants.ErrPermissionDenied)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 10*g.Zexp)
+
+	defer z.CallContract(reclaimHtlc(g.User1.Address, id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, types.ZnnTokenStandard, 0)
+	common.Json(htlcAPI.GetHashLockedBy(g.User2.Address, 0, 10)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// createMultisigWallet creates a wallet with User1, User2 and User3 as co-signers and returns its id
+func createMultisigWallet(t *testing.T, z mock.MockZenon, threshold uint8) types.Hash {
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.MultisigContract,
+		Data: definition.ABIMultisig.PackMethodPanic(definition.CreateWalletMethodName,
+			[]types.Address{g.User1.Address, g.User2.Address, g.User3.Address}, // signers
+			threshold, // threshold
+		),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	wallets, err := embedded.NewMultisigApi(z).GetWalletsBySigner(g.User3.Address, 0, 10)
+	common.FailIfErr(t, err)
+	return wallets.List[0].Id
+}
+
+func depositMultisig(z mock.MockZenon, id types.Hash, amount int64) {
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.MultisigContract,
+		Data:          definition.ABIMultisig.PackMethodPanic(definition.DepositMultisigMethodName, id),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(amount),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+}
+
+func proposeMultisig(proposer types.Address, id types.Hash, amount int64) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   proposer,
+		ToAddress: types.MultisigContract,
+		Data: definition.ABIMultisig.PackMethodPanic(definition.ProposeMethodName,
+			id,                     // id
+			g.User4.Address,        // toAddress
+			types.ZnnTokenStandard, // tokenStandard
+			big.NewInt(amount),     // amount
+		),
+	}
+}
+
+func approveMultisig(signer types.Address, id types.Hash) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   signer,
+		ToAddress: types.MultisigContract,
+		Data:      definition.ABIMultisig.PackMethodPanic(definition.ApproveMethodName, id),
+	}
+}
+
+// Test that the multisig contract is only available once the spork is enforced
+func TestMultisig_Spork(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+
+	createWallet := func() *nom.AccountBlock {
+		return &nom.AccountBlock{
+			Address:   g.User1.Address,
+			ToAddress: types.MultisigContract,
+			Data: definition.ABIMultisig.PackMethodPanic(definition.CreateWalletMethodName,
+				[]types.Address{g.User1.Address, g.User2.Address}, // signers
+				uint8(2), // threshold
+			),
+		}
+	}
+	z.InsertSendBlock(createWallet(), constants.ErrContractDoesntExist, mock.SkipVmChanges)
+
+	activateSpork(t, z, types.MultisigSpork, "multisig")
+	z.InsertSendBlock(createWallet(), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	wallets, err := embedded.NewMultisigApi(z).GetWalletsBySigner(g.User2.Address, 0, 10)
+	common.FailIfErr(t, err)
+	common.Json(wallets.Count, nil).Equals(t, `1`)
+}
+
+func TestMultisig_CreateWalletInvalid(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.MultisigSpork, "multisig")
+
+	createWallet := func(signers []types.Address, threshold uint8) *nom.AccountBlock {
+		return &nom.AccountBlock{
+			Address:   g.User1.Address,
+			ToAddress: types.MultisigContract,
+			Data:      definition.ABIMultisig.PackMethodPanic(definition.CreateWalletMethodName, signers, threshold),
+		}
+	}
+
+	// no signers
+	z.InsertSendBlock(createWallet([]types.Address{}, 1), constants.ErrMultisigInvalidSigners, mock.SkipVmChanges)
+	// threshold bigger than the number of signers
+	z.InsertSendBlock(createWallet([]types.Address{g.User1.Address, g.User2.Address}, 3), constants.ErrMultisigInvalidSigners, mock.SkipVmChanges)
+	// zero threshold
+	z.InsertSendBlock(createWallet([]types.Address{g.User1.Address, g.User2.Address}, 0), constants.ErrMultisigInvalidSigners, mock.SkipVmChanges)
+	// duplicated signer
+	z.InsertSendBlock(createWallet([]types.Address{g.User1.Address, g.User1.Address}, 1), constants.ErrMultisigInvalidSigners, mock.SkipVmChanges)
+	// embedded signer
+	z.InsertSendBlock(createWallet([]types.Address{g.User1.Address, types.PillarContract}, 1), constants.ErrMultisigInvalidSigners, mock.SkipVmChanges)
+}
+
+// Test that a proposal is only executed after enough co-signers approve it
+func TestMultisig_ProposeAndApprove(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.MultisigSpork, "multisig")
+	multisigAPI := embedded.NewMultisigApi(z)
+
+	walletId := createMultisigWallet(t, z, 2)
+	depositMultisig(z, walletId, 100*g.Zexp)
+	z.ExpectBalance(types.MultisigContract, types.ZnnTokenStandard, 100*g.Zexp)
+	z.ExpectBalance(g.User1.Address, types.ZnnTokenStandard, 11900*g.Zexp)
+
+	// only signers can propose
+	defer z.CallContract(proposeMultisig(g.User4.Address, walletId, 40*g.Zexp)).Error(t, constants.ErrPermissionDenied)
+	z.InsertNewMomentum()
+
+	defer z.CallContract(proposeMultisig(g.User2.Address, walletId, 40*g.Zexp)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	pending, err := multisigAPI.GetPendingProposals(walletId, 0, 10)
+	common.FailIfErr(t, err)
+	common.Json(pending.Count, nil).Equals(t, `1`)
+	proposal := pending.List[0]
+	common.Json(proposal.Approvals, nil).Equals(t, `
+[
+	"z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+]`)
+
+	// the proposer already approved, non-signers can't approve
+	defer z.CallContract(approveMultisig(g.User2.Address, proposal.Id)).Error(t, constants.ErrMultisigAlreadyApproved)
+	defer z.CallContract(approveMultisig(g.User4.Address, proposal.Id)).Error(t, constants.ErrPermissionDenied)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.MultisigContract, types.ZnnTokenStandard, 100*g.Zexp)
+
+	// the second approval executes the proposal
+	defer z.CallContract(approveMultisig(g.User3.Address, proposal.Id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.MultisigContract, types.ZnnTokenStandard, 60*g.Zexp)
+
+	executed, err := multisigAPI.GetProposalById(proposal.Id)
+	common.FailIfErr(t, err)
+	common.Json(executed.Status, nil).Equals(t, `1`)
+	common.Json(multisigAPI.GetPendingProposals(walletId, 0, 10)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+	wallet, err := multisigAPI.GetWalletById(walletId)
+	common.FailIfErr(t, err)
+	common.Json(wallet.Balances, nil).Equals(t, `
+[
+	{
+		"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+		"amount": 6000000000
+	}
+]`)
+
+	// executed proposals can't be approved
+	defer z.CallContract(approveMultisig(g.User1.Address, proposal.Id)).Error(t, constants.ErrMultisigNotPending)
+	z.InsertNewMomentum()
+}
+
+// Test that a proposal for more than the wallet owns is not executed
+func TestMultisig_InsufficientBalance(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.MultisigSpork, "multisig")
+	multisigAPI := embedded.NewMultisigApi(z)
+
+	walletId := createMultisigWallet(t, z, 2)
+	otherWalletId := createMultisigWallet(t, z, 1)
+	depositMultisig(z, walletId, 10*g.Zexp)
+	depositMultisig(z, otherWalletId, 100*g.Zexp)
+
+	defer z.CallContract(proposeMultisig(g.User1.Address, walletId, 40*g.Zexp)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	pending, err := multisigAPI.GetPendingProposals(walletId, 0, 10)
+	common.FailIfErr(t, err)
+	common.Json(pending.Count, nil).Equals(t, `1`)
+
+	// the funds of the other wallet can't be used
+	defer z.CallContract(approveMultisig(g.User2.Address, pending.List[0].Id)).Error(t, constants.ErrInsufficientBalance)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.MultisigContract, types.ZnnTokenStandard, 110*g.Zexp)
+
+	// the proposal is executed once enough funds are deposited
+	depositMultisig(z, walletId, 30*g.Zexp)
+	defer z.CallContract(approveMultisig(g.User2.Address, pending.List[0].Id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.MultisigContract, types.ZnnTokenStandard, 100*g.Zexp)
+}
@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func setPayoutConfig(address types.Address, name string, blockRewardPercentage uint8) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   address,
+		ToAddress: types.PillarContract,
+		Data:      definition.ABIPillars.PackMethodPanic(definition.SetPayoutConfigMethodName, name, blockRewardPercentage),
+	}
+}
+
+func listOfPayout() interface{} {
+	return ListOf(func() interface{} {
+		return new(struct {
+			Address       types.Address            `json:"address"`
+			TokenStandard types.ZenonTokenStandard `json:"tokenStandard"`
+			Amount        *big.Int                 `json:"amount"`
+		})
+	})
+}
+
+// Test that only the owner of an active pillar can set the payout config and only after the spork is enforced
+func TestPillarPayout_Permissions(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	pillarAPI := embedded.NewPillarApi(z, true)
+
+	z.InsertSendBlock(setPayoutConfig(g.Pillar1.Address, g.Pillar1Name, 50), constants.ErrContractMethodNotFound, mock.SkipVmChanges)
+	activateSpork(t, z, types.PillarPayoutSpork, "pillar payout")
+
+	z.InsertSendBlock(setPayoutConfig(g.Pillar1.Address, g.Pillar1Name, 101), constants.ErrForbiddenParam, mock.SkipVmChanges)
+	defer z.CallContract(setPayoutConfig(g.User1.Address, g.Pillar1Name, 50)).Error(t, constants.ErrPermissionDenied)
+	defer z.CallContract(setPayoutConfig(g.Pillar1.Address, "TEST-pillar-missing", 50)).Error(t, constants.ErrDataNonExistent)
+	defer z.CallContract(setPayoutConfig(g.Pillar2.Address, g.Pillar2Name, 30)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(pillarAPI.GetPayoutConfig(g.Pillar1Name, nil)).Equals(t, `
+{
+	"name": "TEST-pillar-1",
+	"blockRewardPercentage": 0,
+	"nextBlockRewardPercentage": 0,
+	"nextEpoch": 0
+}`)
+	// the share is committed for the next epoch
+	common.Json(pillarAPI.GetPayoutConfig(g.Pillar2Name, nil)).Equals(t, `
+{
+	"name": "TEST-pillar-cool",
+	"blockRewardPercentage": 0,
+	"nextBlockRewardPercentage": 30,
+	"nextEpoch": 1
+}`)
+
+	// and can't be changed until it's used
+	defer z.CallContract(setPayoutConfig(g.Pillar2.Address, g.Pillar2Name, 0)).Error(t, constants.ErrPayoutConfigLocked)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(pillarAPI.GetPayoutConfig(g.Pillar2Name, nil)).Equals(t, `
+{
+	"name": "TEST-pillar-cool",
+	"blockRewardPercentage": 0,
+	"nextBlockRewardPercentage": 30,
+	"nextEpoch": 1
+}`)
+}
+
+// Test that the payout share is used starting with the next epoch and is sent to the delegators by the epoch update
+func TestPillarPayout_Distribution(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	pillarAPI := embedded.NewPillarApi(z, true)
+	ledgerApi := api.NewLedgerApi(z)
+	activateSpork(t, z, types.PillarPayoutSpork, "pillar payout")
+
+	defer z.CallContract(setPayoutConfig(g.Pillar1.Address, g.Pillar1Name, 40)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	// the epoch in which the share was set is rewarded without it
+	z.InsertMomentumsTo(60*6 + 2)
+	common.Json(pillarAPI.GetUncollectedReward(g.Pillar1.Address, nil)).Equals(t, `
+{
+	"address": "z1qqq43dyrswfehx9w9td43exflqzcxrt7g6alah",
+	"znnAmount": 10487866627,
+	"qsrAmount": 0
+}`)
+	common.Json(ledgerApi.GetUnreceivedBlocksByAddress(g.User1.Address, 0, 10)).SubJson(listOfPayout()).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+
+	z.InsertMomentumsTo(60*12 + 2)
+	common.Json(pillarAPI.GetPayoutConfig(g.Pillar1Name, nil)).Equals(t, `
+{
+	"name": "TEST-pillar-1",
+	"blockRewardPercentage": 40,
+	"nextBlockRewardPercentage": 0,
+	"nextEpoch": 0
+}`)
+	// the payout is deducted from the reward of the pillar
+	common.Json(pillarAPI.GetUncollectedReward(g.Pillar1.Address, nil)).Equals(t, `
+{
+	"address": "z1qqq43dyrswfehx9w9td43exflqzcxrt7g6alah",
+	"znnAmount": 17063866603,
+	"qsrAmount": 0
+}`)
+	// and sent to the delegators pro rata, without collecting it
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(ledgerApi.GetUnreceivedBlocksByAddress(g.User1.Address, 0, 10)).SubJson(listOfPayout()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"address": "z1qxemdeddedxt0kenxxxxxxxxxxxxxxxxh9amk0",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"amount": 2285714276
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetUnreceivedBlocksByAddress(g.User2.Address, 0, 10)).SubJson(listOfPayout()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"address": "z1qxemdeddedxt0kenxxxxxxxxxxxxxxxxh9amk0",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"amount": 1523809517
+		}
+	]
+}`)
+	// once the share is used it can be changed again
+	defer z.CallContract(setPayoutConfig(g.Pillar1.Address, g.Pillar1Name, 0)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(pillarAPI.GetPayoutConfig(g.Pillar1Name, nil)).Equals(t, `
+{
+	"name": "TEST-pillar-1",
+	"blockRewardPercentage": 40,
+	"nextBlockRewardPercentage": 0,
+	"nextEpoch": 3
+}`)
+
+	_, context, err := api.GetFrontierContext(z.Chain(), types.PillarContract)
+	common.FailIfErr(t, err)
+	payouts, err := definition.GetPillarPayoutList(context.Storage(), 10)
+	common.FailIfErr(t, err)
+	if len(payouts) != 0 {
+		t.Fatalf("invalid number of pending payouts; have %v, want 0", len(payouts))
+	}
+}
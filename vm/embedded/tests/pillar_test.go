@@ -249,7 +249,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	}).Error(t, nil)
 	// Add send-blocks
 	z.InsertNewMomentum()
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `15000000000000`)
 	z.ExpectBalance(g.Pillar4.Address, types.QsrTokenStandard, 200000*g.Zexp-15000000000000)
 
 	defer z.CallContract(&nom.AccountBlock{
@@ -261,7 +261,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.Pillar4.Address)
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `0`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `0`)
 	z.ExpectBalance(g.Pillar4.Address, types.QsrTokenStandard, 200000*g.Zexp)
 
 	// withdraw again, should receive error
@@ -283,7 +283,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 	}).Error(t, nil)
 	// Add send-blocks
 	z.InsertNewMomentum()
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `15000000000000`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `15000000000000`)
 
 	defer z.CallContract(&nom.AccountBlock{
 		Address:   g.Pillar4.Address,
@@ -291,7 +291,7 @@ func TestPillar_DepositQsr(t *testing.T) {
 		Data:      definition.ABIPillars.PackMethodPanic(definition.WithdrawQsrMethodName),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(30)
-	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address)).Equals(t, `0`)
+	common.Json(pillarApi.GetDepositedQsr(g.Pillar4.Address, nil)).Equals(t, `0`)
 }
 
 // Register a pillar depositing weird amounts of QSR
@@ -436,7 +436,7 @@ t=2001-09-09T01:47:50+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	}).Error(t, nil)
 	z.InsertNewMomentum()
 	z.InsertMomentumsTo(65)
-	common.Json(pillarApi.GetAll(0, 10)).SubJson(ListOfName()).Equals(t, `
+	common.Json(pillarApi.GetAll(0, 10, nil)).SubJson(ListOfName()).Equals(t, `
 {
 	"count": 6,
 	"list": [
@@ -491,7 +491,7 @@ t=2001-09-09T02:20:00+0000 lvl=info msg="Updating pillar producer address" modul
 		C int           `json:"giveDelegateRewardPercentage"`
 	}{}
 
-	common.Json(pillarApi.GetByName(g.Pillar1Name)).SubJson(interest).Equals(t, `
+	common.Json(pillarApi.GetByName(g.Pillar1Name, nil)).SubJson(interest).Equals(t, `
 {
 	"producerAddress": "z1qqq43dyrswfehx9w9td43exflqzcxrt7g6alah",
 	"giveMomentumRewardPercentage": 0,
@@ -503,7 +503,7 @@ t=2001-09-09T02:20:00+0000 lvl=info msg="Updating pillar producer address" modul
 		Data:      definition.ABIPillars.PackMethodPanic(definition.UpdatePillarMethodName, g.Pillar1Name, g.Pillar4.Address, g.Pillar5.Address, uint8(20), uint8(50)),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(200)
-	common.Json(pillarApi.GetByName(g.Pillar1Name)).SubJson(interest).Equals(t, `
+	common.Json(pillarApi.GetByName(g.Pillar1Name, nil)).SubJson(interest).Equals(t, `
 {
 	"producerAddress": "z1qplpsv3wcm64js30jlumxlatgxxkqr6hgv30fg",
 	"giveMomentumRewardPercentage": 20,
@@ -515,7 +515,7 @@ t=2001-09-09T02:20:00+0000 lvl=info msg="Updating pillar producer address" modul
 		Data:      definition.ABIPillars.PackMethodPanic(definition.UpdatePillarMethodName, g.Pillar1Name, g.Pillar1.Address, g.Pillar5.Address, uint8(20), uint8(50)),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(300)
-	common.Json(pillarApi.GetByName(g.Pillar1Name)).SubJson(interest).Equals(t, `
+	common.Json(pillarApi.GetByName(g.Pillar1Name, nil)).SubJson(interest).Equals(t, `
 {
 	"producerAddress": "z1qqq43dyrswfehx9w9td43exflqzcxrt7g6alah",
 	"giveMomentumRewardPercentage": 20,
@@ -561,7 +561,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	z.InsertMomentumsTo(10)
 
-	common.Json(pillarApi.GetAll(0, 100)).SubJson(ListOfName()).Equals(t, `
+	common.Json(pillarApi.GetAll(0, 100, nil)).SubJson(ListOfName()).Equals(t, `
 {
 	"count": 4,
 	"list": [
@@ -591,7 +591,7 @@ t=2001-09-09T01:47:10+0000 lvl=dbug msg="burned ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.Pillar4.Address)
 
-	common.Json(pillarApi.GetAll(0, 100)).SubJson(ListOfName()).Equals(t, `
+	common.Json(pillarApi.GetAll(0, 100, nil)).SubJson(ListOfName()).Equals(t, `
 {
 	"count": 3,
 	"list": [
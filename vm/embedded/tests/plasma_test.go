@@ -53,7 +53,7 @@ t=2001-09-09T01:47:00+0000 lvl=dbug msg="fused new entry" module=embedded contra
 		TokenStandard: types.QsrTokenStandard,
 		Amount:        big.NewInt(10 * g.Zexp),
 	}).Error(t, nil)
-	common.Json(plasmaApi.Get(g.User1.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User1.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10447500,
 	"maxPlasma": 10500000,
@@ -61,19 +61,19 @@ t=2001-09-09T01:47:00+0000 lvl=dbug msg="fused new entry" module=embedded contra
 }`) // User1 consumed plasma by sending blocks
 
 	z.InsertNewMomentum() // include send block
-	common.Json(plasmaApi.Get(g.User1.Address)).Equals(t, `{
+	common.Json(plasmaApi.Get(g.User1.Address, nil)).Equals(t, `{
 	"currentPlasma": 10500000,
 	"maxPlasma": 10500000,
 	"qsrAmount": 1000000000000
 }`) // User 1 refreshed to full plasma
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `{
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `{
 	"currentPlasma": 0,
 	"maxPlasma": 0,
 	"qsrAmount": 0
 }`) // User 6 didn't gain plasma (yet)
 
 	z.InsertNewMomentum() // include contract receive block
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `{
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `{
 	"currentPlasma": 21000,
 	"maxPlasma": 21000,
 	"qsrAmount": 1000000000
@@ -140,7 +140,7 @@ func TestPlasma_combined(t *testing.T) {
 	}, constants.ErrNotEnoughPlasma, mock.NoVmChanges)
 
 	// get pow-hash to generate nonce from it
-	last, err := ledgerApi.GetFrontierAccountBlock(g.User6.Address)
+	last, err := ledgerApi.GetFrontierAccountBlock(g.User6.Address, nil)
 	common.FailIfErr(t, err)
 	common.Expect(t, pow.GetAccountBlockHash(&nom.AccountBlock{
 		Address:      g.User6.Address,
@@ -157,21 +157,21 @@ func TestPlasma_combined(t *testing.T) {
 		Difficulty:    41500 * constants.PoWDifficultyPerPlasma,
 		Nonce:         parseNonce("135759ef94039b2e"),
 	}, nil, mock.SkipVmChanges)
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10000,
 	"maxPlasma": 21000,
 	"qsrAmount": 1000000000
 }`) // User 6 used all plasma
 	z.InsertNewMomentum() // include send block
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 21000,
 	"maxPlasma": 21000,
 	"qsrAmount": 1000000000
 }`) // User 6 refreshed to full 21K plasma
 	z.InsertNewMomentum() // include contract receive block
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 42000,
 	"maxPlasma": 42000,
@@ -233,7 +233,7 @@ func TestPlasma_rpc(t *testing.T) {
 	"basePlasma": 52500,
 	"requiredDifficulty": 47250000
 }`)
-	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"qsrAmount": 2001000000000,
 	"count": 3,
@@ -258,7 +258,7 @@ func TestPlasma_rpc(t *testing.T) {
 		}
 	]
 }`)
-	common.Json(plasmaApi.GetEntriesByAddress(g.User6.Address, 0, 10)).Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User6.Address, 0, 10, nil)).Equals(t, `
 {
 	"qsrAmount": 0,
 	"count": 0,
@@ -278,7 +278,7 @@ func TestPlasma_RevokeGenesisPlasma(t *testing.T) {
 t=2001-09-09T01:46:50+0000 lvl=dbug msg="canceled fusion entry" module=embedded contract=plasma fusionInfo="&{Owner:z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz Id:117613e734b6cb0fd7b7583f5b0e863a3f0c856cd32fa36f1b60b464d068c5a6 Amount:+1000000000000 ExpirationHeight:0 Beneficiary:z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz}" beneficiary-remaining="&{Beneficiary:z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz Amount:+0}"
 `)
 
-	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"qsrAmount": 2000000000000,
 	"count": 2,
@@ -297,7 +297,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 		}
 	]
 }`)
-	common.Json(plasmaApi.Get(g.User1.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User1.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10500000,
 	"maxPlasma": 10500000,
@@ -365,7 +365,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 	"count": 1,
 	"more": false
 }`)
-	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"qsrAmount": 1000000000000,
 	"count": 1,
@@ -378,7 +378,7 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 		}
 	]
 }`)
-	common.Json(plasmaApi.Get(g.User1.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User1.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 0,
 	"maxPlasma": 0,
@@ -399,7 +399,7 @@ t=2001-09-09T01:52:10+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 `)
 	constants.FuseExpiration = 30
 
-	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10)).Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
 {
 	"qsrAmount": 2000000000000,
 	"count": 2,
@@ -418,7 +418,7 @@ t=2001-09-09T01:52:10+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 		}
 	]
 }`)
-	common.Json(plasmaApi.Get(g.User1.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User1.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10500000,
 	"maxPlasma": 10500000,
@@ -432,7 +432,7 @@ t=2001-09-09T01:52:10+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 		Amount:        big.NewInt(10 * g.Zexp),
 	}).Error(t, nil)
 	z.InsertMomentumsTo(33)
-	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10)).HideHashes().Equals(t, `
+	common.Json(plasmaApi.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).HideHashes().Equals(t, `
 {
 	"qsrAmount": 2001000000000,
 	"count": 3,
@@ -484,7 +484,7 @@ t=2001-09-09T01:47:30+0000 lvl=dbug msg="fused new entry" module=embedded contra
 t=2001-09-09T01:51:40+0000 lvl=dbug msg="canceled fusion entry" module=embedded contract=plasma fusionInfo="&{Owner:z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz Id:XXXHASHXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX Amount:+350000000000 ExpirationHeight:12 Beneficiary:z1qqdt06lnwz57x38rwlyutcx5wgrtl0ynkfe3kv}" beneficiary-remaining="&{Beneficiary:z1qqdt06lnwz57x38rwlyutcx5wgrtl0ynkfe3kv Amount:+450000000000}"
 `)
 
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 0,
 	"maxPlasma": 0,
@@ -500,7 +500,7 @@ t=2001-09-09T01:51:40+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 7350000,
 	"maxPlasma": 7350000,
@@ -517,7 +517,7 @@ t=2001-09-09T01:51:40+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10500000,
 	"maxPlasma": 10500000,
@@ -534,7 +534,7 @@ t=2001-09-09T01:51:40+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 10500000,
 	"maxPlasma": 10500000,
@@ -552,7 +552,7 @@ t=2001-09-09T01:51:40+0000 lvl=dbug msg="canceled fusion entry" module=embedded
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 
-	common.Json(plasmaApi.Get(g.User6.Address)).Equals(t, `
+	common.Json(plasmaApi.Get(g.User6.Address, nil)).Equals(t, `
 {
 	"currentPlasma": 9450000,
 	"maxPlasma": 9450000,
@@ -619,3 +619,66 @@ t=2001-09-09T01:46:50+0000 lvl=dbug msg="fused new entry" module=embedded contra
 	}).Error(t, constants.ErrDataNonExistent)
 	z.InsertNewMomentum()
 }
+
+// - test plasma.EstimatePlasma rpc takes into account the unconfirmed blocks of the account
+// - test plasma.EstimatePlasma rpc returns the required PoW for accounts without enough plasma
+func TestPlasma_EstimatePlasma(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	plasmaApi := embedded.NewPlasmaApi(z)
+	defer z.StopPanic()
+
+	send := func(address types.Address) *api.AccountBlock {
+		return &api.AccountBlock{
+			AccountBlock: nom.AccountBlock{
+				BlockType:     nom.BlockTypeUserSend,
+				Address:       address,
+				ToAddress:     g.User2.Address,
+				TokenStandard: types.ZnnTokenStandard,
+				Amount:        big.NewInt(1 * g.Zexp),
+			},
+		}
+	}
+
+	common.Json(plasmaApi.EstimatePlasma(send(g.User1.Address))).Equals(t, `
+{
+	"basePlasma": 21000,
+	"availablePlasma": 10500000,
+	"unconfirmedBlocks": 0,
+	"unconfirmedPlasma": 0,
+	"fusedBlocks": 500,
+	"requiredDifficulty": 0
+}`)
+	for i := 0; i < 3; i += 1 {
+		block := send(g.User1.Address).AccountBlock
+		z.InsertSendBlock(&block, nil, mock.SkipVmChanges)
+	}
+	common.Json(plasmaApi.EstimatePlasma(send(g.User1.Address))).Equals(t, `
+{
+	"basePlasma": 21000,
+	"availablePlasma": 10437000,
+	"unconfirmedBlocks": 3,
+	"unconfirmedPlasma": 63000,
+	"fusedBlocks": 497,
+	"requiredDifficulty": 0
+}`)
+	z.InsertNewMomentum()
+	common.Json(plasmaApi.EstimatePlasma(send(g.User1.Address))).Equals(t, `
+{
+	"basePlasma": 21000,
+	"availablePlasma": 10500000,
+	"unconfirmedBlocks": 0,
+	"unconfirmedPlasma": 0,
+	"fusedBlocks": 500,
+	"requiredDifficulty": 0
+}`)
+	common.Json(plasmaApi.EstimatePlasma(send(g.User6.Address))).Equals(t, `
+{
+	"basePlasma": 21000,
+	"availablePlasma": 0,
+	"unconfirmedBlocks": 0,
+	"unconfirmedPlasma": 0,
+	"fusedBlocks": 0,
+	"requiredDifficulty": 31500000
+}`)
+	common.Json(plasmaApi.EstimatePlasma(nil)).Error(t, api.ErrParamIsNull)
+}
@@ -0,0 +1,344 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
+	rpc "github.com/zenon-network/go-zenon/rpc/server"
+	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+// Test that successful embedded calls emit events, which can be queried by the hash of both blocks of the call
+func TestRPCEvents_ByBlockHash(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+
+	stake := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	// fails since the stake entry doesn't exist
+	cancel := z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.StakeContract,
+		Data:      definition.ABIStake.PackMethodPanic(definition.CancelStakeMethodName, types.HexToHashPanic("0123456789012345678901234567890123456789012345678901234567890123")),
+	}, nil, mock.SkipVmChanges)
+	send := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	events, err := ledgerApi.GetEventsByBlockHash(stake.Hash)
+	common.FailIfErr(t, err)
+	common.Json(events, err).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"name": "StakeCreated",
+			"contract": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"methodName": "Stake",
+			"params": {
+				"durationInSec": 3600
+			},
+			"caller": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"amount": 1000000000,
+			"transfers": [],
+			"sendBlockHash": "60be4b855f8b1871ccfbe13b348039c4106a15a902b2e983dc289c809691f545",
+			"blockHash": "c31f62285fd8737a17aa65ed8c7c52db2571b5e0dd4be128fa1b0a53f40b0195"
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetEventsByBlockHash(events.List[0].BlockHash)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"name": "StakeCreated",
+			"contract": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"methodName": "Stake",
+			"params": {
+				"durationInSec": 3600
+			},
+			"caller": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"amount": 1000000000,
+			"transfers": [],
+			"sendBlockHash": "60be4b855f8b1871ccfbe13b348039c4106a15a902b2e983dc289c809691f545",
+			"blockHash": "c31f62285fd8737a17aa65ed8c7c52db2571b5e0dd4be128fa1b0a53f40b0195"
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetEventsByBlockHash(cancel.Hash)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+	common.Json(ledgerApi.GetEventsByBlockHash(send.Hash)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
+
+// Test that events can be filtered by contract, name and caller
+func TestRPCEvents_ByFilter(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+	issueTokenSetup(t, z)
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.MintMethodName, customZts, big.NewInt(20), g.User2.Address),
+	}).Error(t, nil)
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User2.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	tokenContract := types.TokenContract
+	common.Json(ledgerApi.GetEventsByFilter(1, 10, &api.EventFilter{
+		Contract: &tokenContract,
+	})).SubJson(new(eventNames)).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"name": "TokenIssued"
+		},
+		{
+			"name": "TokenMinted"
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetEventsByFilter(1, 10, &api.EventFilter{
+		Names: []string{"TokenMinted", "StakeCreated"},
+	})).SubJson(new(eventNames)).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"name": "TokenMinted"
+		},
+		{
+			"name": "StakeCreated"
+		}
+	]
+}`)
+	user2 := g.User2.Address
+	common.Json(ledgerApi.GetEventsByFilter(1, 10, &api.EventFilter{
+		Caller: &user2,
+	})).SubJson(new(eventNames)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"name": "StakeCreated"
+		}
+	]
+}`)
+	// the issue is confirmed before momentum 5
+	common.Json(ledgerApi.GetEventsByFilter(5, 10, nil)).SubJson(new(eventNames)).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"name": "TokenMinted"
+		},
+		{
+			"name": "StakeCreated"
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetEventsByFilter(0, 10, nil)).Error(t, api.ErrHeightParamIsZero)
+}
+
+// Test that events are removed together with the momentum which confirmed their block
+func TestRPCEvents_Rollback(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+
+	stake := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	z.InsertNewMomentum()
+
+	events, err := ledgerApi.GetEventsByBlockHash(stake.Hash)
+	common.FailIfErr(t, err)
+	common.Json(events, err).SubJson(new(eventNames)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"name": "StakeCreated"
+		}
+	]
+}`)
+
+	insert := z.Chain().AcquireInsert("test rollback")
+	common.FailIfErr(t, z.Chain().RollbackTo(insert, momentum.Identifier()))
+	insert.Unlock()
+
+	common.Json(ledgerApi.GetEventsByBlockHash(stake.Hash)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+	common.Json(ledgerApi.GetEventsByBlockHash(events.List[0].BlockHash)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
+
+// Test that successful calls of methods which don't have an event, like the QSR deposits, don't emit events
+func TestRPCEvents_NoEvent(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+
+	deposit := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.SentinelContract,
+		Data:          definition.ABISentinel.PackMethodPanic(definition.DepositQsrMethodName),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	receive, err := z.Chain().GetFrontierMomentumStore().GetBlockWhichReceives(deposit.Hash)
+	common.FailIfErr(t, err)
+	if status := vm.StatusToString(receive.Data); status != "success" {
+		t.Fatalf("invalid deposit status; have %v, want success", status)
+	}
+	common.Json(ledgerApi.GetEventsByBlockHash(deposit.Hash)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
+
+// Test that the events and eventsByContract subscriptions stream the events of the inserted momentums
+func TestRPCEvents_Subscriptions(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	issueTokenSetup(t, z)
+
+	server := subscribe.GetSubscribeServer(z.Chain())
+	common.FailIfErr(t, server.Init())
+	common.FailIfErr(t, server.Start())
+	defer server.Stop()
+	rpcServer := rpc.NewServer()
+	defer rpcServer.Stop()
+	common.FailIfErr(t, rpcServer.RegisterName("ledger", subscribe.GetSubscribeApi()))
+	client := rpc.DialInProc(rpcServer)
+	defer client.Close()
+
+	all := make(chan json.RawMessage, 10)
+	byContract := make(chan json.RawMessage, 10)
+	momentums := make(chan json.RawMessage, 10)
+	for _, sub := range []struct {
+		ch   chan json.RawMessage
+		args []interface{}
+	}{
+		{all, []interface{}{"events"}},
+		{byContract, []interface{}{"eventsByContract", types.StakeContract}},
+		// subscriptions are installed in order, so the momentums notification confirms the others are installed
+		{momentums, []interface{}{"momentums"}},
+	} {
+		subscription, err := client.Subscribe(context.Background(), "ledger", sub.ch, sub.args...)
+		common.FailIfErr(t, err)
+		defer subscription.Unsubscribe()
+	}
+	z.InsertNewMomentum()
+	receiveNotification(t, momentums)
+
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.MintMethodName, customZts, big.NewInt(20), g.User2.Address),
+	}, nil, mock.SkipVmChanges)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User2.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(receiveNotification(t, all), nil).SubJson(new(eventNamesList)).Equals(t, `
+[
+	{
+		"name": "TokenMinted"
+	},
+	{
+		"name": "StakeCreated"
+	}
+]`)
+	common.Json(receiveNotification(t, byContract), nil).SubJson(new(eventNamesList)).Equals(t, `
+[
+	{
+		"name": "StakeCreated"
+	}
+]`)
+}
+
+func receiveNotification(t *testing.T, ch chan json.RawMessage) json.RawMessage {
+	select {
+	case notification := <-ch:
+		return notification
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout while waiting for notification")
+		return nil
+	}
+}
+
+type eventNamesList []struct {
+	Name string `json:"name"`
+}
+
+type eventNames struct {
+	Count int `json:"count"`
+	List  []struct {
+		Name string `json:"name"`
+	} `json:"list"`
+}
@@ -0,0 +1,437 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/indexer"
+	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func listOfIndexedBlock() interface{} {
+	return ListOf(func() interface{} {
+		return new(struct {
+			BlockType uint64        `json:"blockType"`
+			Height    uint64        `json:"height"`
+			Address   types.Address `json:"address"`
+			ToAddress types.Address `json:"toAddress"`
+		})
+	})
+}
+
+func TestRPCIndexer_Filters(t *testing.T) {
+	z := mock.NewMockZenonWithIndexer(t)
+	indexerApi := api.NewIndexerApi(z)
+	defer z.StopPanic()
+
+	simpleSendSetup(t, z)
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, nil)).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 5,
+	"list": [
+		{
+			"blockType": 5,
+			"height": 1,
+			"address": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 3,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62"
+		},
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		},
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User2.Address, 0, 10, nil)).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 3,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		},
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		Counterparty: &g.User2.Address,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		MethodName: definition.StakeMethodName,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"blockType": 5,
+			"height": 1,
+			"address": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 3,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		BlockTypes: []uint64{nom.BlockTypeGenesisReceive},
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		TokenStandard: &types.ZnnTokenStandard,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 4,
+	"list": [
+		{
+			"blockType": 5,
+			"height": 1,
+			"address": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 3,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62"
+		},
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		}
+	]
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		TokenStandard: &types.QsrTokenStandard,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &indexer.Filter{
+		Counterparty: &g.User2.Address,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		},
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		}
+	]
+}`)
+
+	// only the blocks of the page are returned, more reports the blocks after it
+	blocks, err := indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, 1, nil)
+	common.Json(blocks, err).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"blockType": 5,
+			"height": 1,
+			"address": "z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+	if !blocks.More {
+		t.Fatalf("invalid more for the first page; have false, want true")
+	}
+	blocks, err = indexerApi.GetAccountBlocksByPage(g.User1.Address, 4, 1, nil)
+	common.Json(blocks, err).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+	if blocks.More {
+		t.Fatalf("invalid more for the last page; have true, want false")
+	}
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User1.Address, 0, api.RpcMaxPageSize+1, nil)).Error(t, api.ErrPageSizeParamTooBig)
+}
+
+func TestRPCIndexer_Rollback(t *testing.T) {
+	z := mock.NewMockZenonWithIndexer(t)
+	indexerApi := api.NewIndexerApi(z)
+	defer z.StopPanic()
+
+	simpleSendSetup(t, z)
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetMomentumByHeight(2)
+	common.FailIfErr(t, err)
+
+	// only the blocks confirmed after the send momentum
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User2.Address, 0, 10, &indexer.Filter{
+		FromTimestamp: momentum.Timestamp.Unix() + 1,
+	})).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"blockType": 3,
+			"height": 2,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+
+	insert := z.Chain().AcquireInsert("test rollback")
+	common.FailIfErr(t, z.Chain().RollbackTo(insert, momentum.Identifier()))
+	insert.Unlock()
+
+	common.Json(indexerApi.GetFrontierMomentum()).SubJson(&Height{}).Equals(t, `
+{
+	"height": 2
+}`)
+	common.Json(indexerApi.GetAccountBlocksByPage(g.User2.Address, 0, 10, nil)).SubJson(listOfIndexedBlock()).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"blockType": 2,
+			"height": 2,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"toAddress": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx"
+		},
+		{
+			"blockType": 1,
+			"height": 1,
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"toAddress": "z1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqsggv2f"
+		}
+	]
+}`)
+}
+
+func TestRPCIndexer_CatchUp(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+
+	// more momentums than a catch-up batch, so momentums are inserted between batches
+	z.InsertMomentumsTo(1500)
+
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	common.FailIfErr(t, err)
+	defer db.Close()
+	idx := indexer.NewIndexer(z.Chain(), db)
+	common.FailIfErr(t, idx.Init())
+	common.FailIfErr(t, idx.Start())
+	defer idx.Stop()
+
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	waitIndexerFrontier(t, z, idx)
+
+	// once caught up, the indexer follows the chain
+	z.InsertNewMomentum()
+	frontier, err := idx.Frontier()
+	common.FailIfErr(t, err)
+	if *frontier != z.Chain().GetFrontierMomentumStore().Identifier() {
+		t.Fatalf("indexer frontier %v doesn't follow the chain", frontier)
+	}
+}
+
+// Test that the indexer retries a failed catch-up, instead of never following the chain
+func TestRPCIndexer_CatchUpRetry(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	z.InsertMomentumsTo(10)
+
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	common.FailIfErr(t, err)
+	defer db.Close()
+	// the indexer frontier points to a momentum which was never indexed, so removing it fails
+	frontierKey := []byte{0}
+	common.FailIfErr(t, db.Put(frontierKey, (&types.HashHeight{Hash: types.HexToHashPanic("0123456789012345678901234567890123456789012345678901234567890123"), Height: 1000}).Serialize(), nil))
+
+	failures := make(chan struct{}, 1)
+	handler := common.IndexerLogger.GetHandler()
+	defer common.IndexerLogger.SetHandler(handler)
+	common.IndexerLogger.SetHandler(log15.FuncHandler(func(r *log15.Record) error {
+		if r.Msg == "failed to catch up with the chain" {
+			select {
+			case failures <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	}))
+
+	idx := indexer.NewIndexer(z.Chain(), db)
+	common.FailIfErr(t, idx.Init())
+	common.FailIfErr(t, idx.Start())
+	defer idx.Stop()
+
+	select {
+	case <-failures:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("catch-up didn't fail")
+	}
+	common.FailIfErr(t, db.Delete(frontierKey, nil))
+	waitIndexerFrontier(t, z, idx)
+
+	z.InsertNewMomentum()
+	frontier, err := idx.Frontier()
+	common.FailIfErr(t, err)
+	if *frontier != z.Chain().GetFrontierMomentumStore().Identifier() {
+		t.Fatalf("indexer frontier %v doesn't follow the chain", frontier)
+	}
+}
+
+// Test that an indexer which is out of sync with the inserted momentum catches up in the background
+func TestRPCIndexer_OutOfSync(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	z.InsertMomentumsTo(10)
+
+	db, err := leveldb.Open(storage.NewMemStorage(), nil)
+	common.FailIfErr(t, err)
+	defer db.Close()
+	idx := indexer.NewIndexer(z.Chain(), db)
+	common.FailIfErr(t, idx.Init())
+	common.FailIfErr(t, idx.Start())
+	defer idx.Stop()
+	waitIndexerFrontier(t, z, idx)
+
+	// the indexer frontier no longer matches the chain, so the next momentum can't be indexed
+	frontierKey := []byte{0}
+	frontier := z.Chain().GetFrontierMomentumStore().Identifier()
+	common.FailIfErr(t, db.Put(frontierKey, (&types.HashHeight{Hash: types.HexToHashPanic("0123456789012345678901234567890123456789012345678901234567890123"), Height: frontier.Height}).Serialize(), nil))
+	z.InsertNewMomentum()
+	waitIndexerFrontier(t, z, idx)
+
+	z.InsertNewMomentum()
+	indexed, err := idx.Frontier()
+	common.FailIfErr(t, err)
+	if *indexed != z.Chain().GetFrontierMomentumStore().Identifier() {
+		t.Fatalf("indexer frontier %v doesn't follow the chain", indexed)
+	}
+	entries, _, err := idx.GetEntries(g.User1.Address, &indexer.Filter{BlockTypes: []uint64{nom.BlockTypeGenesisReceive}}, 0, 10)
+	common.FailIfErr(t, err)
+	if len(entries) != 1 {
+		t.Fatalf("invalid number of genesis entries; have %v, want 1", len(entries))
+	}
+}
+
+// waitIndexerFrontier waits until idx indexed the frontier momentum of the chain
+func waitIndexerFrontier(t *testing.T, z mock.MockZenon, idx indexer.Indexer) {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		frontier, err := idx.Frontier()
+		common.FailIfErr(t, err)
+		if frontier != nil && *frontier == z.Chain().GetFrontierMomentumStore().Identifier() {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("indexer didn't catch up, frontier %v", frontier)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
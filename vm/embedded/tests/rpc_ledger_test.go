@@ -2,15 +2,19 @@ package tests
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
 	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/rpc/api"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 	"github.com/zenon-network/go-zenon/zenon/mock"
 )
 
@@ -21,7 +25,7 @@ func TestRPCLedger_PublishRawTX_internal(t *testing.T) {
 
 	simpleSendSetup(t, z)
 
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 2, 1)).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 2, 1, nil)).Equals(t, `
 {
 	"list": [
 		{
@@ -109,7 +113,7 @@ func TestRPCLedger_PublishRawTX_internal(t *testing.T) {
 	"count": 2,
 	"more": false
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User2.Address, 2, 1)).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User2.Address, 2, 1, nil)).Equals(t, `
 {
 	"list": [
 		{
@@ -231,16 +235,150 @@ func TestRPCLedger_PublishRawTransaction(t *testing.T) {
 	common.FailIfErr(t, ledgerApi.PublishRawTransaction(a))
 }
 
+// signedSends returns a chain of signed send-blocks from User1 to User2 on top of the frontier of User1
+func signedSends(z mock.MockZenon, amounts ...int64) []*api.AccountBlock {
+	frontier := z.Chain().GetFrontierAccountStore(g.User1.Address).Identifier()
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.DealWithErr(err)
+
+	blocks := make([]*api.AccountBlock, 0, len(amounts))
+	for _, amount := range amounts {
+		block := &nom.AccountBlock{
+			Version:              1,
+			ChainIdentifier:      z.Chain().ChainIdentifier(),
+			BlockType:            nom.BlockTypeUserSend,
+			PreviousHash:         frontier.Hash,
+			Height:               frontier.Height + 1,
+			MomentumAcknowledged: momentum.Identifier(),
+			Address:              g.User1.Address,
+			ToAddress:            g.User2.Address,
+			Amount:               big.NewInt(amount),
+			TokenStandard:        types.ZnnTokenStandard,
+			FusedPlasma:          constants.AccountBlockBasePlasma,
+		}
+		block.Hash = block.ComputeHash()
+		signature, _, publicKey, err := g.User1.Signer(block.Hash.Bytes())
+		common.DealWithErr(err)
+		block.Signature = signature
+		block.PublicKey = publicKey
+
+		blocks = append(blocks, &api.AccountBlock{AccountBlock: *block})
+		frontier = block.Identifier()
+	}
+	return blocks
+}
+
+// Test that a batch is published only if all blocks are valid
+func TestRPCLedger_PublishRawTransactions(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	ledgerApi := api.NewLedgerApi(z)
+	z.InsertNewMomentum()
+
+	// the last block spends more than the balance of User1
+	blocks := signedSends(z, 1*g.Zexp, 2*g.Zexp, 100000*g.Zexp)
+	common.Json(ledgerApi.PublishRawTransactions(blocks)).Equals(t, `
+{
+	"published": false,
+	"list": [
+		{
+			"hash": "2146a93d2f7af0832f683c00fdb8dc3638d686b72829b4ba91c066fc5aa5d294",
+			"error": ""
+		},
+		{
+			"hash": "fa97e85d8c5d47a5221629e4937fb88d09fb70768ffa8e2db59a5528ab0de59a",
+			"error": ""
+		},
+		{
+			"hash": "f6db8a73f7b355a4e9ef1f89febb62431905ed167b2a14779b2de707009f4127",
+			"error": "insufficient balance for transfer"
+		}
+	]
+}`)
+	common.Expect(t, len(z.Chain().GetUncommittedAccountBlocksByAddress(g.User1.Address)), 0)
+
+	// blocks after the failed one are not verified
+	blocks = signedSends(z, 1*g.Zexp, 2*g.Zexp, 3*g.Zexp)
+	blocks[1].Amount = big.NewInt(5 * g.Zexp)
+	common.Json(ledgerApi.PublishRawTransactions(blocks)).Equals(t, `
+{
+	"published": false,
+	"list": [
+		{
+			"hash": "2146a93d2f7af0832f683c00fdb8dc3638d686b72829b4ba91c066fc5aa5d294",
+			"error": ""
+		},
+		{
+			"hash": "fa97e85d8c5d47a5221629e4937fb88d09fb70768ffa8e2db59a5528ab0de59a",
+			"error": "account-block hash is different than the one computed"
+		},
+		{
+			"hash": "92b933cff3b6e245da7a6860d8bf8c5d0538eb62a66b3284f9302f5b329f4365",
+			"error": "block not verified since a previous block of the batch failed"
+		}
+	]
+}`)
+	common.Expect(t, len(z.Chain().GetUncommittedAccountBlocksByAddress(g.User1.Address)), 0)
+
+	blocks = signedSends(z, 1*g.Zexp, 2*g.Zexp, 3*g.Zexp)
+	common.Json(ledgerApi.PublishRawTransactions(blocks)).Equals(t, `
+{
+	"published": true,
+	"list": [
+		{
+			"hash": "2146a93d2f7af0832f683c00fdb8dc3638d686b72829b4ba91c066fc5aa5d294",
+			"error": ""
+		},
+		{
+			"hash": "fa97e85d8c5d47a5221629e4937fb88d09fb70768ffa8e2db59a5528ab0de59a",
+			"error": ""
+		},
+		{
+			"hash": "92b933cff3b6e245da7a6860d8bf8c5d0538eb62a66b3284f9302f5b329f4365",
+			"error": ""
+		}
+	]
+}`)
+	common.Expect(t, len(z.Chain().GetUncommittedAccountBlocksByAddress(g.User1.Address)), 3)
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User2.Address)
+	z.ExpectBalance(g.User2.Address, types.ZnnTokenStandard, 8006*g.Zexp)
+
+	// a batch which forks unconfirmed blocks is rejected
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	uncommitted := z.Chain().GetUncommittedAccountBlocksByAddress(g.User1.Address)
+	common.Expect(t, len(uncommitted), 1)
+	blocks = signedSends(z, 1*g.Zexp)
+	blocks[0].PreviousHash = uncommitted[0].PreviousHash
+	blocks[0].Height = uncommitted[0].Height
+	common.Json(ledgerApi.PublishRawTransactions(blocks)).Equals(t, `
+{
+	"published": false,
+	"list": [
+		{
+			"hash": "4ca55c50bb4f8f63d0b3ac4503b5a7f17dd31e8edba144d3f8329f781a5c6dee",
+			"error": "the block is not on top of the account frontier {611e948b269741fc7c85c39077c1b4b6b05291fd0145f03c5a753e55bc5e1151 5}"
+		}
+	]
+}`)
+	common.Json(ledgerApi.PublishRawTransactions(nil)).Error(t, api.ErrParamIsNull)
+}
+
 func ExpectGetFrontierAccountBlock(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
-	common.Json(ledgerApi.GetFrontierAccountBlock(g.User1.Address)).SubJson(&Height{}).Equals(t, `
+	common.Json(ledgerApi.GetFrontierAccountBlock(g.User1.Address, nil)).SubJson(&Height{}).Equals(t, `
 {
 	"height": 11
 }`)
 }
 func ExpectGetAccountBlocksByHeight(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 3, 2)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 3, 2, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -252,7 +390,7 @@ func ExpectGetAccountBlocksByHeight(t *testing.T, z mock.MockZenon) {
 		}
 	]
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 1, 5)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 1, 5, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -273,12 +411,12 @@ func ExpectGetAccountBlocksByHeight(t *testing.T, z mock.MockZenon) {
 		}
 	]
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 20, 5)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 20, 5, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": []
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 10, 5)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 10, 5, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -294,7 +432,7 @@ func ExpectGetAccountBlocksByHeight(t *testing.T, z mock.MockZenon) {
 func ExpectGetAccountBlockByHash(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
 
-	blocks, err := ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 1, 10)
+	blocks, err := ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 1, 10, nil)
 	common.FailIfErr(t, err)
 	common.Json(ledgerApi.GetAccountBlockByHash(blocks.List[0].Hash)).SubJson(&Height{}).Equals(t, `
 {
@@ -309,7 +447,7 @@ func ExpectGetAccountBlockByHash(t *testing.T, z mock.MockZenon) {
 func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
 
-	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 0, 2)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 0, 2, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -321,7 +459,7 @@ func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 		}
 	]
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 2, 2)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 2, 2, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -333,7 +471,7 @@ func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 		}
 	]
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 1, 8)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 1, 8, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": [
@@ -348,7 +486,7 @@ func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 		}
 	]
 }`)
-	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 2, 8)).SubJson(ListOfHeight()).Equals(t, `
+	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 2, 8, nil)).SubJson(ListOfHeight()).Equals(t, `
 {
 	"count": 11,
 	"list": []
@@ -357,7 +495,7 @@ func ExpectGetAccountBlocksByPage(t *testing.T, z mock.MockZenon) {
 func ExpectGetAccountInfoByAddress(t *testing.T, z mock.MockZenon) {
 	ledgerApi := api.NewLedgerApi(z)
 
-	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address)).Equals(t, `
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"accountHeight": 11,
@@ -457,6 +595,363 @@ func ExpectGetUnreceivedBlocksByAddress(t *testing.T, z mock.MockZenon) {
 }`)
 }
 
+func TestRPCLedger_SimulateTransaction(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	z.InsertNewMomentum()
+
+	// simple stake, nothing is inserted
+	common.Json(ledgerApi.SimulateTransaction(&api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, constants.StakeTimeMinSec),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}})).Equals(t, `
+{
+	"balanceChanges": {
+		"z1qxemdeddedxstakexxxxxxxxxxxxxxxxjv8v62": {
+			"zts1znnxxxxxxxxxxxxx9z4ulx": 1000000000
+		},
+		"z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz": {
+			"zts1znnxxxxxxxxxxxxx9z4ulx": -1000000000
+		}
+	},
+	"descendantBlocks": [],
+	"basePlasma": 52500,
+	"usedPlasma": 52500,
+	"returnedError": null
+}`)
+	z.ExpectBalance(g.User1.Address, types.ZnnTokenStandard, 12000*g.Zexp)
+
+	// embedded errors are reported
+	common.Json(ledgerApi.SimulateTransaction(&api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType: nom.BlockTypeUserSend,
+		Address:   g.User1.Address,
+		ToAddress: types.StakeContract,
+		Data:      definition.ABIStake.PackMethodPanic(definition.CancelStakeMethodName, types.HexToHashPanic("0123456789012345678901234567890123456789012345678901234567890123")),
+	}})).Equals(t, `
+{
+	"balanceChanges": {},
+	"descendantBlocks": [],
+	"basePlasma": 73500,
+	"usedPlasma": 73500,
+	"returnedError": "data non existent"
+}`)
+
+	// invalid send-blocks are rejected
+	common.Json(ledgerApi.SimulateTransaction(&api.AccountBlock{AccountBlock: nom.AccountBlock{
+		BlockType:     nom.BlockTypeUserSend,
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, constants.StakeTimeMinSec),
+		TokenStandard: types.QsrTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}})).Error(t, constants.ErrInvalidTokenOrAmount)
+	common.Json(ledgerApi.SimulateTransaction(&api.AccountBlock{AccountBlock: nom.AccountBlock{
+		ChainIdentifier: z.Chain().ChainIdentifier() + 1,
+		BlockType:       nom.BlockTypeUserSend,
+		Address:         g.User1.Address,
+		ToAddress:       g.User2.Address,
+		TokenStandard:   types.ZnnTokenStandard,
+		Amount:          big.NewInt(10 * g.Zexp),
+	}})).Error(t, fmt.Errorf("the block has a different network Id (%d) from the node (%d)", z.Chain().ChainIdentifier()+1, z.Chain().ChainIdentifier()))
+	common.Json(ledgerApi.SimulateTransaction(nil)).Error(t, api.ErrParamIsNull)
+}
+
+func TestRPCLedger_HistoricalQueries(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	simpleSendSetup(t, z)
+
+	type balance struct {
+		AccountHeight  uint64 `json:"accountHeight"`
+		BalanceInfoMap map[types.ZenonTokenStandard]*struct {
+			Balance *big.Int `json:"balance"`
+		} `json:"balanceInfoMap"`
+	}
+
+	// before the send
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &types.HashHeight{Height: 1})).SubJson(new(balance)).Equals(t, `
+{
+	"accountHeight": 1,
+	"balanceInfoMap": {
+		"zts1qsrxxxxxxxxxxxxxmrhjll": {
+			"balance": 12000000000000
+		},
+		"zts1znnxxxxxxxxxxxxx9z4ulx": {
+			"balance": 1200000000000
+		}
+	}
+}`)
+	common.Json(ledgerApi.GetFrontierAccountBlock(g.User1.Address, &types.HashHeight{Height: 1})).SubJson(&Height{}).Equals(t, `
+{
+	"height": 1
+}`)
+	momentum, err := z.Chain().GetFrontierMomentumStore().GetMomentumByHeight(2)
+	common.FailIfErr(t, err)
+	common.Json(ledgerApi.GetAccountBlocksByPage(g.User1.Address, 0, 10, &types.HashHeight{Hash: momentum.Hash})).SubJson(ListOfHeight()).Equals(t, `
+{
+	"count": 2,
+	"list": [
+		{
+			"height": 2
+		},
+		{
+			"height": 1
+		}
+	]
+}`)
+	// frontier
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, nil)).SubJson(new(balance)).Equals(t, `
+{
+	"accountHeight": 2,
+	"balanceInfoMap": {
+		"zts1qsrxxxxxxxxxxxxxmrhjll": {
+			"balance": 12000000000000
+		},
+		"zts1znnxxxxxxxxxxxxx9z4ulx": {
+			"balance": 1190000000000
+		}
+	}
+}`)
+
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &types.HashHeight{})).Error(t, api.ErrMomentumParamIsZero)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &types.HashHeight{Height: 100})).Error(t, api.ErrMomentumNotFound)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &types.HashHeight{Hash: momentum.Hash, Height: 3})).Error(t, api.ErrMomentumNotFound)
+}
+
+func TestRPCLedger_PrunedQueries(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	// received send-block
+	simpleSendSetup(t, z)
+	blocks, err := ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 2, 1, nil)
+	common.FailIfErr(t, err)
+	received := blocks.List[0]
+	// unreceived send-block
+	unreceived := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	oldMomentum, err := z.Chain().GetFrontierMomentumStore().GetMomentumByHeight(3)
+	common.FailIfErr(t, err)
+
+	// consensus needs the momentums of the last periods
+	z.Chain().SetPruning(100)
+	z.InsertMomentumsTo(150)
+
+	// momentums
+	common.Json(ledgerApi.GetMomentumsByHeight(3, 1)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetMomentumByHash(oldMomentum.Hash)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetMomentumsByHeight(1, 1)).SubJson(ListOfHeight()).Equals(t, `
+{
+	"count": 150,
+	"list": [
+		{
+			"height": 1
+		}
+	]
+}`)
+	common.Json(ledgerApi.GetMomentumsByHeight(51, 1)).SubJson(ListOfHeight()).Equals(t, `
+{
+	"count": 150,
+	"list": [
+		{
+			"height": 51
+		}
+	]
+}`)
+	momentums, err := ledgerApi.GetMomentumsByHeight(1, 1)
+	common.FailIfErr(t, err)
+	genesis := momentums.List[0]
+	common.Json(ledgerApi.GetMomentumBeforeTime(genesis.Timestamp.Add(time.Second*10*5+time.Second).Unix())).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetMomentumBeforeTime(genesis.Timestamp.Add(time.Second*10*125+time.Second).Unix())).SubJson(&Height{}).Equals(t, `
+{
+	"height": 126
+}`)
+
+	// account-blocks
+	common.Json(ledgerApi.GetAccountBlockByHash(received.Hash)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User1.Address, 1, 3, nil)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetAccountBlockByHash(unreceived.Hash)).SubJson(&Height{}).Equals(t, `
+{
+	"height": 3
+}`)
+	common.Json(ledgerApi.GetFrontierAccountBlock(g.User2.Address, nil)).SubJson(&Height{}).Equals(t, `
+{
+	"height": 2
+}`)
+	common.Json(ledgerApi.GetAccountInfoByAddress(g.User1.Address, &types.HashHeight{Height: 3})).Error(t, store.ErrPruned)
+
+	// the unreceived send-block can still be received
+	z.InsertReceiveBlock(unreceived.Header(), nil, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.ExpectBalance(g.User2.Address, types.ZnnTokenStandard, 8110*g.Zexp)
+
+	// the kept account-blocks are pruned once they are neither frontiers nor unreceived
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertMomentumsTo(260)
+	common.Json(ledgerApi.GetAccountBlockByHash(unreceived.Hash)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetAccountBlocksByHeight(g.User2.Address, 2, 1, nil)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetFrontierAccountBlock(g.User2.Address, nil)).SubJson(&Height{}).Equals(t, `
+{
+	"height": 3
+}`)
+}
+
+// Test that enabling pruning on an existing chain doesn't prune the whole backlog on the next insert
+func TestRPCLedger_PrunedBacklog(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	z.InsertMomentumsTo(150)
+	z.Chain().SetPruning(100)
+	expectPrunedHeight := func(expected uint64) {
+		prunedHeight, err := z.Chain().GetFrontierMomentumStore().PrunedHeight()
+		common.FailIfErr(t, err)
+		if prunedHeight != expected {
+			t.Fatalf("pruned height mismatch: have %v, want %v", prunedHeight, expected)
+		}
+	}
+
+	// each insert prunes at most one momentum, the background pruning deals with the rest
+	z.InsertNewMomentum()
+	expectPrunedHeight(2)
+	z.InsertNewMomentum()
+	expectPrunedHeight(3)
+	common.Json(ledgerApi.GetMomentumsByHeight(3, 1)).Error(t, store.ErrPruned)
+	common.Json(ledgerApi.GetMomentumsByHeight(4, 1)).SubJson(ListOfHeight()).Equals(t, `
+{
+	"count": 152,
+	"list": [
+		{
+			"height": 4
+		}
+	]
+}`)
+}
+
+func TestRPCLedger_DecodedAccountBlocks(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	ledgerApi := api.NewLedgerApi(z)
+	defer z.StopPanic()
+
+	type decoded struct {
+		DecodedData        *api.DecodedData `json:"decodedData"`
+		PairedAccountBlock *struct {
+			DecodedData *api.DecodedData `json:"decodedData"`
+		} `json:"pairedAccountBlock"`
+	}
+
+	stake := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, int64(constants.StakeTimeMinSec)),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	cancel := z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.StakeContract,
+		Data:      definition.ABIStake.PackMethodPanic(definition.CancelStakeMethodName, types.HexToHashPanic("0123456789012345678901234567890123456789012345678901234567890123")),
+	}, nil, mock.SkipVmChanges)
+	send := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     g.User2.Address,
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(ledgerApi.GetDecodedAccountBlockByHash(stake.Hash)).SubJson(new(decoded)).Equals(t, `
+{
+	"decodedData": {
+		"methodName": "Stake",
+		"params": {
+			"durationInSec": 3600
+		},
+		"status": ""
+	},
+	"pairedAccountBlock": {
+		"decodedData": {
+			"methodName": "Stake",
+			"params": null,
+			"status": "success"
+		}
+	}
+}`)
+	common.Json(ledgerApi.GetDecodedAccountBlockByHash(cancel.Hash)).SubJson(new(decoded)).Equals(t, `
+{
+	"decodedData": {
+		"methodName": "Cancel",
+		"params": {
+			"id": "0123456789012345678901234567890123456789012345678901234567890123"
+		},
+		"status": ""
+	},
+	"pairedAccountBlock": {
+		"decodedData": {
+			"methodName": "Cancel",
+			"params": null,
+			"status": "fail"
+		}
+	}
+}`)
+	common.Json(ledgerApi.GetDecodedAccountBlockByHash(send.Hash)).SubJson(new(decoded)).Equals(t, `
+{
+	"decodedData": null,
+	"pairedAccountBlock": null
+}`)
+
+	// embedded receive-block
+	receive, err := z.Chain().GetFrontierMomentumStore().GetBlockWhichReceives(cancel.Hash)
+	common.FailIfErr(t, err)
+	common.Json(ledgerApi.GetDecodedAccountBlockByHash(receive.Hash)).SubJson(new(decoded)).Equals(t, `
+{
+	"decodedData": {
+		"methodName": "Cancel",
+		"params": null,
+		"status": "fail"
+	},
+	"pairedAccountBlock": {
+		"decodedData": {
+			"methodName": "Cancel",
+			"params": {
+				"id": "0123456789012345678901234567890123456789012345678901234567890123"
+			},
+			"status": ""
+		}
+	}
+}`)
+	// regular queries don't decode
+	common.Json(ledgerApi.GetAccountBlockByHash(stake.Hash)).SubJson(new(decoded)).Equals(t, `
+{
+	"decodedData": null,
+	"pairedAccountBlock": {
+		"decodedData": null
+	}
+}`)
+}
+
 func TestRPCLedger_UnconfirmedAccountBlocks(t *testing.T) {
 	z := mock.NewMockZenon(t)
 	ledgerApi := api.NewLedgerApi(z)
@@ -740,5 +1235,5 @@ func TestRPCLedger_Errors(t *testing.T) {
 
 	common.Json(ledgerApi.GetDetailedMomentumsByHeight(0, 3)).Error(t, api.ErrHeightParamIsZero)
 	common.Json(ledgerApi.GetDetailedMomentumsByHeight(1, 1234)).Error(t, api.ErrCountParamTooBig)
-	common.Json(ledgerApi.GetAccountBlocksByPage(types.ZeroAddress, 0, 1234)).Error(t, api.ErrPageSizeParamTooBig)
+	common.Json(ledgerApi.GetAccountBlocksByPage(types.ZeroAddress, 0, 1234, nil)).Error(t, api.ErrPageSizeParamTooBig)
 }
@@ -19,7 +19,7 @@ import (
 
 func depositQsr(z mock.MockZenon, t *testing.T, address types.Address, amount *big.Int) {
 	sentinelApi := embedded.NewSentinelApi(z)
-	initialQsr, err := sentinelApi.GetDepositedQsr(address)
+	initialQsr, err := sentinelApi.GetDepositedQsr(address, nil)
 	common.DealWithErr(err)
 
 	// Deposit QSR
@@ -32,13 +32,13 @@ func depositQsr(z mock.MockZenon, t *testing.T, address types.Address, amount *b
 	}, nil, mock.SkipVmChanges)
 	z.InsertNewMomentum()
 
-	finalQsr, err := sentinelApi.GetDepositedQsr(address)
+	finalQsr, err := sentinelApi.GetDepositedQsr(address, nil)
 	common.ExpectString(t, fmt.Sprintf("%v", new(big.Int).Add(initialQsr, amount)), fmt.Sprintf("%v", finalQsr))
 }
 
 func withdrawQsr(z mock.MockZenon, t *testing.T, address types.Address) {
 	sentinelApi := embedded.NewSentinelApi(z)
-	initialQsr, err := sentinelApi.GetDepositedQsr(address)
+	initialQsr, err := sentinelApi.GetDepositedQsr(address, nil)
 	common.DealWithErr(err)
 	if initialQsr.Cmp(big.NewInt(0)) == 0 {
 		// Try to withdraw QSR
@@ -57,7 +57,7 @@ func withdrawQsr(z mock.MockZenon, t *testing.T, address types.Address) {
 		}).Error(t, nil)
 		z.InsertNewMomentum()
 	}
-	common.Json(sentinelApi.GetDepositedQsr(address)).Equals(t, `0`)
+	common.Json(sentinelApi.GetDepositedQsr(address, nil)).Equals(t, `0`)
 }
 
 func registerSentinel(z mock.MockZenon, t *testing.T, address types.Address) {
@@ -73,7 +73,7 @@ func registerSentinel(z mock.MockZenon, t *testing.T, address types.Address) {
 	}).Error(t, nil)
 	z.InsertNewMomentum()
 
-	common.Json(sentinelApi.GetDepositedQsr(address)).Equals(t, `0`)
+	common.Json(sentinelApi.GetDepositedQsr(address, nil)).Equals(t, `0`)
 	sentinel, err := sentinelApi.GetByOwner(address)
 	common.DealWithErr(err)
 	common.ExpectTrue(t, sentinel.Active)
@@ -189,7 +189,7 @@ t=2001-09-09T01:47:00+0000 lvl=dbug msg="successfully register" module=embedded
 	}).Error(t, nil)
 	z.InsertNewMomentum()
 
-	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address)).Equals(t, `1`)
+	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address, nil)).Equals(t, `1`)
 	common.Json(sentinelApi.GetByOwner(g.User1.Address)).Equals(t, `
 {
 	"owner": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
@@ -237,7 +237,7 @@ t=2001-09-09T01:47:20+0000 lvl=dbug msg="invalid register - existing address" mo
 	z.ExpectBalance(g.User1.Address, types.QsrTokenStandard, 20000*g.Zexp)
 	z.ExpectBalance(g.User1.Address, types.ZnnTokenStandard, 7000*g.Zexp)
 
-	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address)).Equals(t, `5000000000000`)
+	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address, nil)).Equals(t, `5000000000000`)
 	common.Json(sentinelApi.GetByOwner(g.User1.Address)).Equals(t, `
 {
 	"owner": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
@@ -445,7 +445,7 @@ t=2001-09-09T02:06:40+0000 lvl=dbug msg="successfully revoke" module=embedded co
 
 	registerSentinel(z, t, g.User1.Address)
 
-	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address)).Equals(t, `0`)
+	common.Json(sentinelApi.GetDepositedQsr(g.User1.Address, nil)).Equals(t, `0`)
 	common.Json(sentinelApi.GetByOwner(g.User1.Address)).Equals(t, `
 {
 	"owner": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
@@ -515,14 +515,14 @@ t=2001-09-09T02:46:50+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	registerSentinel(z, t, g.User1.Address)
 	z.InsertMomentumsTo(50)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
 	"qsrAmount": 0
 }`)
 	z.InsertMomentumsTo(60*6 + 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -588,7 +588,7 @@ t=2001-09-09T03:46:50+0000 lvl=dbug msg="invalid update - rewards not due yet" m
 
 	registerSentinel(z, t, g.User1.Address)
 	z.InsertMomentumsTo(60 * 5)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -597,33 +597,33 @@ t=2001-09-09T03:46:50+0000 lvl=dbug msg="invalid update - rewards not due yet" m
 	z.InsertMomentumsTo(60 * 6)
 	registerSentinel(z, t, g.User2.Address)
 	z.InsertMomentumsTo(60*6 + 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
 	"qsrAmount": 500000000000
 }`)
-	common.Json(sentinelApi.GetUncollectedReward(g.User2.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User2.Address, nil)).Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"znnAmount": 0,
 	"qsrAmount": 0
 }`)
 	z.InsertMomentumsTo(60 * 6 * 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
 	"qsrAmount": 500000000000
 }`)
 	z.InsertMomentumsTo(60*6*2 + 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 280800000000,
 	"qsrAmount": 750000000000
 }`)
-	common.Json(sentinelApi.GetUncollectedReward(g.User2.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User2.Address, nil)).Equals(t, `
 {
 	"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
 	"znnAmount": 93600000000,
@@ -683,7 +683,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 	sentinelApi := embedded.NewSentinelApi(z)
 	registerSentinel(z, t, g.User1.Address)
 	z.InsertMomentumsTo(uint64(60*6 + 2 + constants.SentinelLockTimeWindow))
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -697,7 +697,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	z.InsertMomentumsTo(60*6*3 + 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -742,7 +742,7 @@ t=2001-09-09T02:47:10+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	ledgerApi := api.NewLedgerApi(z)
 	registerSentinel(z, t, g.User1.Address)
 	z.InsertMomentumsTo(60*6 + 2)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -858,7 +858,7 @@ t=2001-09-09T02:47:10+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	"more": false
 }`)
 	autoreceive(t, z, g.User1.Address)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -929,7 +929,7 @@ t=2001-09-09T02:54:10+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 
 	registerSentinel(z, t, g.User1.Address)
 	z.InsertMomentumsTo(uint64(60*6 + constants.SentinelLockTimeWindow + 2))
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -943,7 +943,7 @@ t=2001-09-09T02:54:10+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.User1.Address)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 187200000000,
@@ -958,7 +958,7 @@ t=2001-09-09T02:54:10+0000 lvl=dbug msg="minted ZTS" module=embedded contract=to
 	z.InsertNewMomentum()
 	z.InsertNewMomentum()
 	autoreceive(t, z, g.User1.Address)
-	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address)).HideHashes().Equals(t, `
+	common.Json(sentinelApi.GetUncollectedReward(g.User1.Address, nil)).HideHashes().Equals(t, `
 {
 	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
 	"znnAmount": 0,
@@ -1087,7 +1087,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 	z.InsertMomentumsTo(50)
 	registerSentinel(z, t, g.User2.Address)
 	z.InsertMomentumsTo(60*6 + 50)
-	common.Json(sentinelApi.GetFrontierRewardByPage(g.User1.Address, 0, 5)).Equals(t, `
+	common.Json(sentinelApi.GetFrontierRewardByPage(g.User1.Address, 0, 5, nil)).Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -1098,7 +1098,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 		}
 	]
 }`)
-	common.Json(sentinelApi.GetFrontierRewardByPage(g.User2.Address, 0, 5)).Equals(t, `
+	common.Json(sentinelApi.GetFrontierRewardByPage(g.User2.Address, 0, 5, nil)).Equals(t, `
 {
 	"count": 1,
 	"list": [
@@ -1110,7 +1110,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 	]
 }`)
 	z.InsertMomentumsTo(60*6*2 + 50)
-	common.Json(sentinelApi.GetFrontierRewardByPage(g.User1.Address, 0, 5)).Equals(t, `
+	common.Json(sentinelApi.GetFrontierRewardByPage(g.User1.Address, 0, 5, nil)).Equals(t, `
 {
 	"count": 2,
 	"list": [
@@ -1126,7 +1126,7 @@ t=2001-09-09T03:47:10+0000 lvl=info msg="received donation" module=embedded cont
 		}
 	]
 }`)
-	common.Json(sentinelApi.GetFrontierRewardByPage(g.User2.Address, 0, 5)).Equals(t, `
+	common.Json(sentinelApi.GetFrontierRewardByPage(g.User2.Address, 0, 5, nil)).Equals(t, `
 {
 	"count": 2,
 	"list": [
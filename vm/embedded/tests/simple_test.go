@@ -125,8 +125,8 @@ func TestSimple_ContractCall(t *testing.T) {
 	z.InsertNewMomentum() // cemented send blocks
 	z.InsertNewMomentum() // cemented pillar receive-blocks
 
-	common.Json(pillarApi.GetDepositedQsr(g.User1.Address)).Equals(t, `150000000000`)
-	common.Json(pillarApi.GetDelegatedPillar(g.User1.Address)).Equals(t, `
+	common.Json(pillarApi.GetDepositedQsr(g.User1.Address, nil)).Equals(t, `150000000000`)
+	common.Json(pillarApi.GetDelegatedPillar(g.User1.Address, nil)).Equals(t, `
 {
 	"name": "TEST-pillar-1",
 	"status": 1,
@@ -134,7 +134,7 @@ func TestSimple_ContractCall(t *testing.T) {
 }`)
 
 	z.InsertMomentumsTo(60)
-	common.Json(pillarApi.GetAll(0, 10)).SubJson(ListOf(func() interface{} {
+	common.Json(pillarApi.GetAll(0, 10, nil)).SubJson(ListOf(func() interface{} {
 		return new(struct {
 			Weight *big.Int `json:"weight"`
 		})
@@ -262,7 +262,7 @@ func TestSimple_CanCallConsensusCache(t *testing.T) {
 	defer z.StopPanic()
 	pillarApi := embedded.NewPillarApi(z, true)
 
-	common.Json(pillarApi.GetAll(0, 10)).Error(t, nil)
+	common.Json(pillarApi.GetAll(0, 10, nil)).Error(t, nil)
 }
 
 // - test that it's not possible to have 2 transaction which don't have the momentum-ack in decreasing order
@@ -324,7 +324,7 @@ func TestSimple_BatchedBlocks(t *testing.T) {
 	z.InsertNewMomentum()
 
 	// initial statement, account-block has height 1 with 10 unreceived blocks
-	frontierAccBlock, err := ledgerApi.GetFrontierAccountBlock(g.User2.Address)
+	frontierAccBlock, err := ledgerApi.GetFrontierAccountBlock(g.User2.Address, nil)
 	common.FailIfErr(t, err)
 	common.Expect(t, frontierAccBlock.Height, 1)
 	unreceived, err := ledgerApi.GetUnreceivedBlocksByAddress(g.User2.Address, 0, 10)
@@ -339,7 +339,7 @@ func TestSimple_BatchedBlocks(t *testing.T) {
 	z.InsertNewMomentum()
 
 	// final statement, account-block has height 11 with 0 unreceived blocks
-	frontierAccBlock, err = ledgerApi.GetFrontierAccountBlock(g.User2.Address)
+	frontierAccBlock, err = ledgerApi.GetFrontierAccountBlock(g.User2.Address, nil)
 	common.FailIfErr(t, err)
 	common.Expect(t, frontierAccBlock.Height, 11)
 	unreceived, err = ledgerApi.GetUnreceivedBlocksByAddress(g.User2.Address, 0, 10)
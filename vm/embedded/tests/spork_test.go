@@ -1,18 +1,64 @@
 package tests
 
 import (
+	"errors"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
 	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/verifier"
 	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/vm/embedded/definition"
 	"github.com/zenon-network/go-zenon/zenon/mock"
 )
 
+// activateSpork creates and activates a spork named after feature, which enforces the changes gated by spork.
+// The id of spork is replaced by the id of the created spork until the test finishes.
+func activateSpork(t *testing.T, z mock.MockZenon, spork *types.ImplementedSpork, feature string) {
+	created := z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.Spork.Address,
+		ToAddress: types.SporkContract,
+		Data: definition.ABISpork.PackMethodPanic(definition.SporkCreateMethodName,
+			"spork-"+strings.ReplaceAll(feature, " ", "-"), // name
+			"activate spork for "+feature,                  // description
+		),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	id := created.Hash
+
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:   g.Spork.Address,
+		ToAddress: types.SporkContract,
+		Data: definition.ABISpork.PackMethodPanic(definition.SporkActivateMethodName,
+			id, // id
+		),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	// the spork is enforced a few momentums after the activation is received, which matters when activating multiple sporks
+	enforced := frontier.Height + constants.SporkMinHeightDelay + 2
+	if enforced < 20 {
+		enforced = 20
+	}
+
+	previous := spork.SporkId
+	spork.SporkId = id
+	types.ImplementedSporksMap[id] = true
+	t.Cleanup(func() {
+		spork.SporkId = previous
+		delete(types.ImplementedSporksMap, id)
+	})
+	z.InsertMomentumsTo(enforced)
+}
+
 // Test create spork
 func TestSpork_CreateSpork(t *testing.T) {
 	z := mock.NewMockZenon(t)
@@ -163,3 +209,117 @@ t=2001-09-09T01:47:00+0000 lvl=dbug msg=activated module=embedded contract=spork
 	types.ImplementedSporksMap[types.HexToHashPanic("eedcf4003fedfa69a0494e8b09c156f70c3e790af563642d0222514c3078966f")] = true
 	z.InsertMomentumsTo(20)
 }
+
+// Test that the embedded methods gated by a spork can only be called once the spork is enforced
+func TestSpork_EmbeddedUpgrade(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+
+	createProject := func() *nom.AccountBlock {
+		return &nom.AccountBlock{
+			Address:       g.User1.Address,
+			ToAddress:     types.AcceleratorContract,
+			TokenStandard: types.ZnnTokenStandard,
+			Amount:        constants.ProjectCreationAmount,
+			Data: definition.ABIAccelerator.PackMethodPanic(definition.CreateProjectMethodName,
+				"Test Project 1",   //param.Name
+				"TEST DESCRIPTION", //param.Description
+				"test.com",         //param.Url
+				big.NewInt(100),    //param.ZnnFundsNeeded
+				big.NewInt(1000),   //param.QsrFundsNeeded
+			),
+		}
+	}
+
+	// Donate is available before the spork, CreateProject is not
+	z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.AcceleratorContract,
+		Data:          definition.ABICommon.PackMethodPanic(definition.DonateMethodName),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        common.Big100,
+	}, nil, mock.SkipVmChanges)
+	z.InsertSendBlock(createProject(), constants.ErrContractMethodNotFound, mock.SkipVmChanges)
+
+	activateAccelerator(z)
+	z.InsertSendBlock(createProject(), nil, mock.SkipVmChanges)
+	z.InsertMomentumsTo(25)
+
+	projectList, err := embedded.NewAcceleratorApi(z).GetAll(0, 10)
+	common.FailIfErr(t, err)
+	common.Json(projectList.Count, nil).Equals(t, `1`)
+}
+
+// Test that a plasma table gated by a spork replaces the plasma costs only once the spork is enforced
+func TestSpork_PlasmaTableUpgrade(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	plasmaApi := embedded.NewPlasmaApi(z)
+
+	spork := types.NewImplementedSpork("8b1c7a3e0f9d4e2b6a5c3d1e0f7b9a8c6d4e2f1a0b9c8d7e6f5a4b3c2d1e0f9a")
+	table := constants.AlphanetPlasmaTable
+	table.EmbeddedSimple = 2 * constants.EmbeddedSimplePlasma
+	previous := constants.PlasmaTableUpgrades
+	constants.PlasmaTableUpgrades = append(constants.PlasmaTableUpgrades, &constants.PlasmaTableUpgrade{Spork: spork, Table: &table})
+	t.Cleanup(func() { constants.PlasmaTableUpgrades = previous })
+
+	fuse := func() *nom.AccountBlock {
+		return &nom.AccountBlock{
+			Address:       g.User1.Address,
+			ToAddress:     types.PlasmaContract,
+			Data:          definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User1.Address),
+			TokenStandard: types.QsrTokenStandard,
+			Amount:        big.NewInt(10 * g.Zexp),
+		}
+	}
+	expectBasePlasma := func(expected uint64) {
+		required, err := plasmaApi.GetRequiredPoWForAccountBlock(embedded.GetRequiredParam{
+			BlockType: nom.BlockTypeUserSend,
+			SelfAddr:  g.User1.Address,
+			ToAddr:    &types.PlasmaContract,
+			Data:      definition.ABIPlasma.PackMethodPanic(definition.FuseMethodName, g.User1.Address),
+		})
+		common.FailIfErr(t, err)
+		if required.BasePlasma != expected {
+			t.Fatalf("estimated base plasma mismatch: have %v, want %v", required.BasePlasma, expected)
+		}
+		if block := z.InsertSendBlock(fuse(), nil, mock.SkipVmChanges); block.BasePlasma != expected {
+			t.Fatalf("base plasma mismatch: have %v, want %v", block.BasePlasma, expected)
+		}
+		z.InsertNewMomentum()
+	}
+
+	expectBasePlasma(constants.EmbeddedSimplePlasma)
+	activateSpork(t, z, spork, "plasma table")
+	expectBasePlasma(2 * constants.EmbeddedSimplePlasma)
+}
+
+// Test that a verifier rule gated by a spork only rejects blocks which acknowledge a momentum where the spork is enforced
+func TestSpork_VerifierRule(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+
+	spork := types.NewImplementedSpork("2f4e6a8c0b1d3e5f7a9c1e3b5d7f9a0c2e4b6d8f0a1c3e5b7d9f1a2c4e6b8d0f")
+	errRule := errors.New("sends to User2 are not allowed")
+	previous := verifier.AccountBlockRules
+	verifier.AccountBlockRules = append(verifier.AccountBlockRules, &verifier.AccountBlockRule{
+		Spork: spork,
+		Verify: func(block *nom.AccountBlock, momentumStore store.Momentum) error {
+			if block.IsSendBlock() && block.ToAddress == g.User2.Address {
+				return errRule
+			}
+			return nil
+		},
+	})
+	t.Cleanup(func() { verifier.AccountBlockRules = previous })
+	abv := verifier.NewAccountBlockVerifier(z.Chain(), z.Consensus())
+
+	before := signedSends(z, 1)[0]
+	common.ExpectError(t, abv.AccountBlock(&before.AccountBlock), nil)
+
+	activateSpork(t, z, spork, "verifier rule")
+	// blocks are verified against the momentum they acknowledge, so the rule doesn't apply to older blocks
+	common.ExpectError(t, abv.AccountBlock(&before.AccountBlock), nil)
+	after := signedSends(z, 1)[0]
+	common.ExpectError(t, abv.AccountBlock(&after.AccountBlock), errRule)
+}
@@ -0,0 +1,227 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func stakeCall(data []byte) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.StakeContract,
+		Data:      data,
+	}
+}
+
+// Test that entries can be extended, split and partially cancelled
+func TestStakeManagement_ExtendSplitCancel(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	stakeAPI := embedded.NewStakeApi(z)
+
+	stake := z.InsertSendBlock(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, 3*constants.StakeTimeUnitSec),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}, nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertSendBlock(stakeCall(definition.ABIStake.PackMethodPanic(definition.ExtendStakeMethodName, stake.Hash, 4*constants.StakeTimeUnitSec)), constants.ErrContractMethodNotFound, mock.SkipVmChanges)
+	activateSpork(t, z, types.StakeSpork, "stake")
+
+	// the expiration time can't be earlier than the current one
+	defer z.CallContract(stakeCall(definition.ABIStake.PackMethodPanic(definition.ExtendStakeMethodName, stake.Hash, constants.StakeTimeUnitSec))).Error(t, constants.ErrInvalidStakingPeriod)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	extend := z.InsertSendBlock(stakeCall(definition.ABIStake.PackMethodPanic(definition.ExtendStakeMethodName, stake.Hash, 4*constants.StakeTimeUnitSec)), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(stakeAPI.GetEntryById(g.User1.Address, stake.Hash, nil)).Error(t, constants.ErrDataNonExistent)
+	common.Json(stakeAPI.GetEntryById(g.User1.Address, extend.Hash, nil)).Equals(t, `
+{
+	"amount": 1000000000,
+	"weightedAmount": 1300000000,
+	"startTimestamp": 1000000220,
+	"expirationTimestamp": 1000014620,
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"id": "6826188bfbfe73bc25ccb2bb8f46d66156f69283e548efeeda27443ac9047637"
+}`)
+
+	// both entries need to keep at least the minimum amount
+	defer z.CallContract(stakeCall(definition.ABIStake.PackMethodPanic(definition.SplitStakeMethodName, extend.Hash, big.NewInt(95*g.Zexp/10)))).Error(t, constants.ErrInvalidTokenOrAmount)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	split := z.InsertSendBlock(stakeCall(definition.ABIStake.PackMethodPanic(definition.SplitStakeMethodName, extend.Hash, big.NewInt(3*g.Zexp))), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(stakeAPI.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
+{
+	"totalAmount": 1000000000,
+	"totalWeightedAmount": 1300000000,
+	"count": 2,
+	"list": [
+		{
+			"amount": 700000000,
+			"weightedAmount": 910000000,
+			"startTimestamp": 1000000220,
+			"expirationTimestamp": 1000014620,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"id": "6826188bfbfe73bc25ccb2bb8f46d66156f69283e548efeeda27443ac9047637"
+		},
+		{
+			"amount": 300000000,
+			"weightedAmount": 390000000,
+			"startTimestamp": 1000000220,
+			"expirationTimestamp": 1000014620,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"id": "bbbc5e38bc5c7b3b16b44528d615104c51b73e52e0f99118a316cd9f273c6e4c"
+		}
+	]
+}`)
+
+	defer z.CallContract(stakeCall(definition.ABIStake.PackMethodPanic(definition.CancelPartialStakeMethodName, split.Hash, big.NewInt(2*g.Zexp)))).Error(t, constants.RevokeNotDue)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(stakeAPI.GetExpiredEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
+{
+	"totalAmount": 0,
+	"totalWeightedAmount": 0,
+	"count": 0,
+	"list": []
+}`)
+
+	z.InsertMomentumsTo(4*360 + 60)
+	common.Json(stakeAPI.GetExpiredEntriesByAddress(g.User1.Address, 0, 10, nil)).Equals(t, `
+{
+	"totalAmount": 1000000000,
+	"totalWeightedAmount": 1300000000,
+	"count": 2,
+	"list": [
+		{
+			"amount": 700000000,
+			"weightedAmount": 910000000,
+			"startTimestamp": 1000000220,
+			"expirationTimestamp": 1000014620,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"id": "6826188bfbfe73bc25ccb2bb8f46d66156f69283e548efeeda27443ac9047637"
+		},
+		{
+			"amount": 300000000,
+			"weightedAmount": 390000000,
+			"startTimestamp": 1000000220,
+			"expirationTimestamp": 1000014620,
+			"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"id": "bbbc5e38bc5c7b3b16b44528d615104c51b73e52e0f99118a316cd9f273c6e4c"
+		}
+	]
+}`)
+	balance := z.Chain().GetFrontierMomentumStore().GetAccountStore(g.User1.Address)
+	before, err := balance.GetBalance(types.ZnnTokenStandard)
+	common.FailIfErr(t, err)
+
+	// the remaining amount needs to be at least the minimum amount
+	defer z.CallContract(stakeCall(definition.ABIStake.PackMethodPanic(definition.CancelPartialStakeMethodName, split.Hash, big.NewInt(25*g.Zexp/10)))).Error(t, constants.ErrInvalidTokenOrAmount)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	cancel := z.InsertSendBlock(stakeCall(definition.ABIStake.PackMethodPanic(definition.CancelPartialStakeMethodName, split.Hash, big.NewInt(2*g.Zexp))), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User1.Address)
+	z.ExpectBalance(g.User1.Address, types.ZnnTokenStandard, before.Int64()+2*g.Zexp)
+	kept, err := stakeAPI.GetEntryById(g.User1.Address, cancel.Hash, nil)
+	common.FailIfErr(t, err)
+	common.Expect(t, kept.Id, cancel.Hash)
+	common.Json(kept, err).SubJson(&struct {
+		Amount              *big.Int `json:"amount"`
+		WeightedAmount      *big.Int `json:"weightedAmount"`
+		StartTimestamp      int64    `json:"startTimestamp"`
+		ExpirationTimestamp int64    `json:"expirationTimestamp"`
+	}{}).Equals(t, `
+{
+	"amount": 100000000,
+	"weightedAmount": 130000000,
+	"startTimestamp": 1000015020,
+	"expirationTimestamp": 1000015020
+}`)
+	common.Json(stakeAPI.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).SubJson(&struct {
+		TotalAmount *big.Int `json:"totalAmount"`
+		Count       int      `json:"count"`
+	}{}).Equals(t, `
+{
+	"totalAmount": 800000000,
+	"count": 2
+}`)
+}
+
+// Test that the QSR rewards of addresses which enabled auto-compounding are fused for plasma
+func TestStakeManagement_AutoCompound(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	stakeAPI := embedded.NewStakeApi(z)
+	plasmaAPI := embedded.NewPlasmaApi(z)
+	activateSpork(t, z, types.StakeSpork, "stake")
+
+	defer z.CallContract(&nom.AccountBlock{
+		Address:       g.User1.Address,
+		ToAddress:     types.StakeContract,
+		Data:          definition.ABIStake.PackMethodPanic(definition.StakeMethodName, constants.StakeTimeMinSec),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(10 * g.Zexp),
+	}).Error(t, nil)
+	defer z.CallContract(stakeCall(definition.ABIStake.PackMethodPanic(definition.SetAutoCompoundMethodName, true))).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(stakeAPI.IsAutoCompound(g.User1.Address, nil)).Equals(t, `true`)
+
+	z.InsertMomentumsTo(400)
+	reward, err := stakeAPI.GetUncollectedReward(g.User1.Address, nil)
+	common.FailIfErr(t, err)
+	common.Json(reward, err).Equals(t, `
+{
+	"address": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+	"znnAmount": 0,
+	"qsrAmount": 1000000000000
+}`)
+	plasmaBefore, err := plasmaAPI.Get(g.User1.Address, nil)
+	common.FailIfErr(t, err)
+
+	defer z.CallContract(stakeCall(definition.ABICommon.PackMethodPanic(definition.CollectRewardMethodName))).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(plasmaAPI.GetEntriesByAddress(g.User1.Address, 0, 10, nil)).SubJson(&struct {
+		QsrAmount *big.Int `json:"qsrAmount"`
+		Count     int      `json:"count"`
+	}{}).Equals(t, `
+{
+	"qsrAmount": 3000000000000,
+	"count": 3
+}`)
+	plasmaAfter, err := plasmaAPI.Get(g.User1.Address, nil)
+	common.FailIfErr(t, err)
+	common.Expect(t, plasmaAfter.QsrAmount.Cmp(plasmaBefore.QsrAmount), 1)
+	z.ExpectBalance(types.PlasmaContract, types.QsrTokenStandard, 150000*g.Zexp)
+
+	// only the stake contract can fuse rewards
+	defer z.CallContract(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.PlasmaContract,
+		Data:      definition.ABIPlasma.PackMethodPanic(definition.FuseRewardMethodName, g.User1.Address, big.NewInt(10*g.Zexp)),
+	}).Error(t, constants.ErrPermissionDenied)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+}
@@ -0,0 +1,256 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func setTokenPolicy(address types.Address, useAllowlist bool, mintCapPerEpoch int64) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.SetTokenPolicyMethodName, customZts, useAllowlist, big.NewInt(mintCapPerEpoch)),
+	}
+}
+
+func setAddressFrozen(address types.Address, isFrozen bool) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.SetAddressFrozenMethodName, customZts, address, isFrozen),
+	}
+}
+
+func setAddressAllowed(address types.Address, isAllowed bool) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.SetAddressAllowedMethodName, customZts, address, isAllowed),
+	}
+}
+
+func sendCustomZts(from, to types.Address, amount int64) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:       from,
+		ToAddress:     to,
+		TokenStandard: customZts,
+		Amount:        big.NewInt(amount),
+	}
+}
+
+// Test that only the owner can set the policies and only after the spork is enforced
+func TestTokenPolicy_Permissions(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	issueTokenSetup(t, z)
+
+	z.InsertSendBlock(setTokenPolicy(g.User1.Address, true, 0), constants.ErrContractMethodNotFound, mock.SkipVmChanges)
+	activateSpork(t, z, types.TokenPolicySpork, "token policy")
+
+	defer z.CallContract(setTokenPolicy(g.User2.Address, true, 0)).Error(t, constants.ErrPermissionDenied)
+	defer z.CallContract(&nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.TokenContract,
+		Data:      definition.ABIToken.PackMethodPanic(definition.SetAddressFrozenMethodName, types.ZnnTokenStandard, g.User2.Address, true),
+	}).Error(t, constants.ErrPermissionDenied)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	tokenAPI := embedded.NewTokenApi(z)
+	policy, err := tokenAPI.GetPolicy(customZts, nil)
+	common.FailIfErr(t, err)
+	common.Expect(t, policy.UseAllowlist, false)
+	common.Json(tokenAPI.GetAddressPolicies(types.ZnnTokenStandard, 0, 10, nil)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
+
+// Test that frozen addresses can't send the token and that the allowlist restricts the receivers
+func TestTokenPolicy_Transfers(t *testing.T) {
+	z := mock.NewMockZenon(t)
+	defer z.StopPanic()
+	tokenAPI := embedded.NewTokenApi(z)
+	issueTokenSetup(t, z)
+	autoreceive(t, z, g.User1.Address)
+	activateSpork(t, z, types.TokenPolicySpork, "token policy")
+
+	z.InsertSendBlock(sendCustomZts(g.User1.Address, g.User2.Address, 30), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User2.Address)
+	z.ExpectBalance(g.User2.Address, customZts, 30)
+
+	defer z.CallContract(setAddressFrozen(g.User2.Address, true)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(tokenAPI.GetAddressPolicies(customZts, 0, 10, nil)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"tokenStandard": "zts103tsa5yqngu9cfpj2m0z9u",
+			"address": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"isFrozen": true,
+			"isAllowed": false
+		}
+	]
+}`)
+
+	// a frozen address can't send the token, but can still receive it
+	z.InsertSendBlock(sendCustomZts(g.User2.Address, g.User3.Address, 10), constants.ErrTokenAddressFrozen, mock.SkipVmChanges)
+	z.InsertSendBlock(sendCustomZts(g.User1.Address, g.User2.Address, 10), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User2.Address)
+	z.ExpectBalance(g.User2.Address, customZts, 40)
+
+	defer z.CallContract(setAddressFrozen(g.User2.Address, false)).Error(t, nil)
+	defer z.CallContract(setAddressAllowed(g.User3.Address, true)).Error(t, nil)
+	defer z.CallContract(setTokenPolicy(g.User1.Address, true, 0)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(tokenAPI.GetAddressPolicies(customZts, 0, 10, nil)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"tokenStandard": "zts103tsa5yqngu9cfpj2m0z9u",
+			"address": "z1qrs2lpccnsneglhnnfwvlsj0qncnxjnwlfmjac",
+			"isFrozen": false,
+			"isAllowed": true
+		}
+	]
+}`)
+
+	// with the allowlist only allowed addresses and the owner can receive the token
+	z.InsertSendBlock(sendCustomZts(g.User2.Address, g.User4.Address, 10), constants.ErrTokenNotAllowed, mock.SkipVmChanges)
+	z.InsertSendBlock(sendCustomZts(g.User2.Address, g.User3.Address, 10), nil, mock.SkipVmChanges)
+	z.InsertSendBlock(sendCustomZts(g.User2.Address, g.User1.Address, 10), nil, mock.SkipVmChanges)
+	// the owner is not restricted
+	z.InsertSendBlock(sendCustomZts(g.User1.Address, g.User4.Address, 10), nil, mock.SkipVmChanges)
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User3.Address)
+	autoreceive(t, z, g.User4.Address)
+	z.ExpectBalance(g.User2.Address, customZts, 20)
+	z.ExpectBalance(g.User3.Address, customZts, 10)
+	z.ExpectBalance(g.User4.Address, customZts, 10)
+}
+
+// Test that the amount minted per epoch is capped and that the cap resets in the next epoch
+func TestTokenPolicy_MintCap(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	tokenAPI := embedded.NewTokenApi(z)
+	issueTokenSetup(t, z)
+	activateSpork(t, z, types.TokenPolicySpork, "token policy")
+
+	mint := func(amount int64) *nom.AccountBlock {
+		return &nom.AccountBlock{
+			Address:   g.User1.Address,
+			ToAddress: types.TokenContract,
+			Data:      definition.ABIToken.PackMethodPanic(definition.MintMethodName, customZts, big.NewInt(amount), g.User1.Address),
+		}
+	}
+
+	defer z.CallContract(setTokenPolicy(g.User1.Address, false, 50)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	defer z.CallContract(mint(30)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	defer z.CallContract(mint(30)).Error(t, constants.ErrTokenMintCapped)
+	defer z.CallContract(mint(20)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(tokenAPI.GetPolicy(customZts, nil)).Equals(t, `
+{
+	"tokenStandard": "zts103tsa5yqngu9cfpj2m0z9u",
+	"useAllowlist": false,
+	"mintCapPerEpoch": 50,
+	"mintEpoch": 0,
+	"mintedInEpoch": 50
+}`)
+
+	z.InsertMomentumsTo(370)
+	defer z.CallContract(mint(30)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	common.Json(tokenAPI.GetPolicy(customZts, nil)).Equals(t, `
+{
+	"tokenStandard": "zts103tsa5yqngu9cfpj2m0z9u",
+	"useAllowlist": false,
+	"mintCapPerEpoch": 50,
+	"mintEpoch": 1,
+	"mintedInEpoch": 30
+}`)
+	common.Json(tokenAPI.GetByZts(customZts, nil)).SubJson(&struct {
+		TotalSupply *big.Int `json:"totalSupply"`
+	}{}).Equals(t, `
+{
+	"totalSupply": 180
+}`)
+}
+
+// Test that the policies also restrict the tokens sent by embedded contracts, like the unlock of a htlc
+func TestTokenPolicy_ContractSends(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	htlcAPI := embedded.NewHtlcApi(z)
+	issueTokenSetup(t, z)
+	autoreceive(t, z, g.User1.Address)
+	activateSpork(t, z, types.HtlcSpork, "htlc")
+	activateSpork(t, z, types.TokenPolicySpork, "token policy")
+
+	hashLock := sha256.Sum256(htlcPreimage)
+	create := createHtlc(frontierTimestamp(t, z)+1000, definition.HashTypeSHA256, hashLock[:])
+	create.TokenStandard = customZts
+	create.Amount = big.NewInt(20)
+	defer z.CallContract(create).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, customZts, 20)
+	htlcList, err := htlcAPI.GetHashLockedBy(g.User2.Address, 0, 10)
+	common.FailIfErr(t, err)
+	id := htlcList.List[0].Id
+
+	// the contract can't send the token once frozen
+	defer z.CallContract(setAddressFrozen(types.HtlcContract, true)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, htlcPreimage)).Error(t, constants.ErrTokenAddressFrozen)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, customZts, 20)
+
+	// with the allowlist the contract can only send the token to allowed addresses
+	defer z.CallContract(setAddressFrozen(types.HtlcContract, false)).Error(t, nil)
+	defer z.CallContract(setTokenPolicy(g.User1.Address, true, 0)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, htlcPreimage)).Error(t, constants.ErrTokenNotAllowed)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.HtlcContract, customZts, 20)
+
+	defer z.CallContract(setAddressAllowed(g.User2.Address, true)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	defer z.CallContract(unlockHtlc(g.User2.Address, id, htlcPreimage)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	autoreceive(t, z, g.User2.Address)
+	z.ExpectBalance(types.HtlcContract, customZts, 0)
+	z.ExpectBalance(g.User2.Address, customZts, 20)
+}
@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	g "github.com/zenon-network/go-zenon/chain/genesis/mock"
+	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/common"
+	"github.com/zenon-network/go-zenon/common/types"
+	"github.com/zenon-network/go-zenon/rpc/api/embedded"
+	"github.com/zenon-network/go-zenon/vm/constants"
+	"github.com/zenon-network/go-zenon/vm/embedded/definition"
+	"github.com/zenon-network/go-zenon/zenon/mock"
+)
+
+func createSchedule(startTime, cliffDuration, vestingDuration int64, amount int64) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   g.User1.Address,
+		ToAddress: types.VestingContract,
+		Data: definition.ABIVesting.PackMethodPanic(definition.CreateScheduleMethodName,
+			g.User2.Address, // beneficiary
+			startTime,       // startTime
+			cliffDuration,   // cliffDuration
+			vestingDuration, // vestingDuration
+		),
+		TokenStandard: types.ZnnTokenStandard,
+		Amount:        big.NewInt(amount),
+	}
+}
+
+func withdrawVesting(address types.Address, id types.Hash) *nom.AccountBlock {
+	return &nom.AccountBlock{
+		Address:   address,
+		ToAddress: types.VestingContract,
+		Data:      definition.ABIVesting.PackMethodPanic(definition.WithdrawVestingMethodName, id),
+	}
+}
+
+func TestVesting_InvalidSchedule(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+
+	// the contract is only available once the spork is enforced
+	z.InsertSendBlock(createSchedule(0, 0, 100, 10*g.Zexp), constants.ErrContractDoesntExist, mock.SkipVmChanges)
+	activateSpork(t, z, types.VestingSpork, "vesting")
+
+	// cliff longer than the vesting period
+	z.InsertSendBlock(createSchedule(0, 200, 100, 10*g.Zexp), constants.ErrInvalidVestingSchedule, mock.SkipVmChanges)
+	// no vesting period
+	z.InsertSendBlock(createSchedule(0, 0, 0, 10*g.Zexp), constants.ErrInvalidVestingSchedule, mock.SkipVmChanges)
+	// vesting period too long
+	z.InsertSendBlock(createSchedule(0, 0, constants.VestingDurationMax+1, 10*g.Zexp), constants.ErrInvalidVestingSchedule, mock.SkipVmChanges)
+	// no funds
+	z.InsertSendBlock(createSchedule(0, 0, 100, 0), constants.ErrInvalidTokenOrAmount, mock.SkipVmChanges)
+}
+
+// Test that the funds unlock linearly after the cliff
+func TestVesting_LinearWithdraw(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.VestingSpork, "vesting")
+	vestingAPI := embedded.NewVestingApi(z)
+
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+	startTime := frontier.Timestamp.Unix() + 100
+
+	// cliff after 200 seconds, fully vested after 1000 seconds
+	defer z.CallContract(createSchedule(startTime, 200, 1000, 100*g.Zexp)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.VestingContract, types.ZnnTokenStandard, 100*g.Zexp)
+
+	schedules, err := vestingAPI.GetSchedulesByBeneficiary(g.User2.Address, 0, 10)
+	common.FailIfErr(t, err)
+	common.Json(schedules.Count, nil).Equals(t, `1`)
+	id := schedules.List[0].Id
+
+	// nothing can be withdrawn before the cliff
+	defer z.CallContract(withdrawVesting(g.User2.Address, id)).Error(t, constants.ErrNothingToWithdraw)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	// only the beneficiary can withdraw
+	z.InsertMomentumsTo(60)
+	defer z.CallContract(withdrawVesting(g.User1.Address, id)).Error(t, constants.ErrDataNonExistent)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	common.Json(vestingAPI.GetSchedulesByBeneficiary(g.User2.Address, 0, 10)).Equals(t, `
+{
+	"count": 1,
+	"list": [
+		{
+			"beneficiary": "z1qr4pexnnfaexqqz8nscjjcsajy5hdqfkgadvwx",
+			"id": "5dc38b7a0267732144c4fccf738ef7044538b2d0bf20b86f4bb8c3cda8fc93a2",
+			"creator": "z1qzal6c5s9rjnnxd2z7dvdhjxpmmj4fmw56a0mz",
+			"tokenStandard": "zts1znnxxxxxxxxxxxxx9z4ulx",
+			"totalAmount": 10000000000,
+			"withdrawnAmount": 0,
+			"startTime": 1000000290,
+			"cliffDuration": 200,
+			"vestingDuration": 1000,
+			"vestedAmount": 3200000000,
+			"withdrawableAmount": 3200000000
+		}
+	]
+}`)
+
+	// the withdrawal is computed when the contract receives the call
+	defer z.CallContract(withdrawVesting(g.User2.Address, id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.VestingContract, types.ZnnTokenStandard, 67*g.Zexp)
+
+	// everything is withdrawn after the vesting period
+	z.InsertMomentumsTo(150)
+	defer z.CallContract(withdrawVesting(g.User2.Address, id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.VestingContract, types.ZnnTokenStandard, 0)
+	common.Json(vestingAPI.GetSchedulesByBeneficiary(g.User2.Address, 0, 10)).Equals(t, `
+{
+	"count": 0,
+	"list": []
+}`)
+}
+
+// Test that a time-locked transfer unlocks everything at once
+func TestVesting_TimeLock(t *testing.T) {
+	z := mock.NewMockZenonWithCustomEpochDuration(t, time.Hour)
+	defer z.StopPanic()
+	activateSpork(t, z, types.VestingSpork, "vesting")
+	vestingAPI := embedded.NewVestingApi(z)
+
+	frontier, err := z.Chain().GetFrontierMomentumStore().GetFrontierMomentum()
+	common.FailIfErr(t, err)
+
+	defer z.CallContract(createSchedule(frontier.Timestamp.Unix(), 300, 300, 50*g.Zexp)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+
+	schedules, err := vestingAPI.GetSchedulesByBeneficiary(g.User2.Address, 0, 10)
+	common.FailIfErr(t, err)
+	id := schedules.List[0].Id
+
+	z.InsertMomentumsTo(45)
+	defer z.CallContract(withdrawVesting(g.User2.Address, id)).Error(t, constants.ErrNothingToWithdraw)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.VestingContract, types.ZnnTokenStandard, 50*g.Zexp)
+
+	z.InsertMomentumsTo(60)
+	defer z.CallContract(withdrawVesting(g.User2.Address, id)).Error(t, nil)
+	z.InsertNewMomentum()
+	z.InsertNewMomentum()
+	z.ExpectBalance(types.VestingContract, types.ZnnTokenStandard, 0)
+}
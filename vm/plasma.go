@@ -98,7 +98,22 @@ func GetBasePlasmaForAccountBlock(context vm_context.AccountVmContext, block *no
 		} else if err != nil {
 			return 0, err
 		} else {
-			return method.GetPlasma(&constants.AlphanetPlasmaTable)
+			return method.GetPlasma(GetPlasmaTable(context))
 		}
 	}
 }
+
+// GetPlasmaTable returns the plasma table enforced at the frontier momentum of context
+func GetPlasmaTable(context vm_context.AccountVmContext) *constants.PlasmaTable {
+	table := &constants.AlphanetPlasmaTable
+	if len(constants.PlasmaTableUpgrades) == 0 {
+		return table
+	}
+	enforced := context.EnforcedSporks()
+	for _, upgrade := range constants.PlasmaTableUpgrades {
+		if enforced[upgrade.Spork.SporkId] {
+			table = upgrade.Table
+		}
+	}
+	return table
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/nom"
+	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common"
 	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
@@ -35,6 +36,16 @@ type ContractExecution struct {
 	ReturnedError error
 }
 
+// BlockSimulation is the result of applying an account-block on top of the frontier stores.
+// None of the changes are inserted in the chain.
+type BlockSimulation struct {
+	Block          *nom.AccountBlock
+	BalanceChanges map[types.Address]map[types.ZenonTokenStandard]*big.Int
+	// ContractReceive is the auto-generated receive block in case the simulated block calls an embedded contract
+	ContractReceive *nom.AccountBlock
+	ReturnedError   error
+}
+
 func NewSupervisor(chain chain.Chain, consensus consensus.Consensus) *Supervisor {
 	return &Supervisor{
 		log:       common.SupervisorLogger,
@@ -226,6 +237,104 @@ func (s *Supervisor) applyBlock(block *nom.AccountBlock, signFunc SignFunc) (tra
 	return transaction, nil
 }
 
+// SimulateBlock applies the template on top of the frontier stores and discards all changes afterwards.
+// The template doesn't need to be signed. In case the block calls an embedded contract,
+// the embedded receive-block is also generated, as it would be when the send-block gets confirmed.
+func (s *Supervisor) SimulateBlock(template *nom.AccountBlock) (result *BlockSimulation, internalErr error) {
+	defer func() {
+		if err := recover(); err != nil {
+			s.log.Error("vm panic when simulating block", "reason", err, "stack", string(debug.Stack()))
+
+			result = nil
+			internalErr = constants.ErrVmRunPanic
+		}
+	}()
+
+	if template.BlockType != nom.BlockTypeUserSend && template.BlockType != nom.BlockTypeUserReceive {
+		return nil, errors.Errorf("can only simulate user blocks")
+	}
+	if err := s.setAll(template); err != nil {
+		return nil, err
+	}
+	context := s.newBlockContext(template)
+	if err := s.setBlockPlasma(context, template); err != nil {
+		return nil, err
+	}
+	if template.Hash.IsZero() {
+		template.Hash = template.ComputeHash()
+	}
+	if err := s.verifier.AccountBlock(template); err != nil {
+		return nil, err
+	}
+
+	before := s.chain.GetAccountStore(template.Address, template.Previous())
+	if err := NewVM(context).applyBlock(template); err != nil {
+		return nil, err
+	}
+	result = &BlockSimulation{
+		Block:          template,
+		BalanceChanges: make(map[types.Address]map[types.ZenonTokenStandard]*big.Int),
+	}
+	if err := addBalanceChanges(result.BalanceChanges, before, context); err != nil {
+		return nil, err
+	}
+
+	if template.BlockType != nom.BlockTypeUserSend || !types.IsEmbeddedAddress(template.ToAddress) {
+		return result, nil
+	}
+
+	momentumStore := s.chain.GetFrontierMomentumStore()
+	before = s.chain.GetFrontierAccountStore(template.ToAddress)
+	contractContext := vm_context.NewAccountContext(
+		momentumStore,
+		s.chain.GetFrontierAccountStore(template.ToAddress),
+		s.consensus.FixedPillarReader(momentumStore.Identifier()),
+	)
+	block, methodErr, err := NewVM(contractContext).receiveEmbedded(template)
+	if err != nil {
+		return nil, err
+	}
+	if err := addBalanceChanges(result.BalanceChanges, before, contractContext); err != nil {
+		return nil, err
+	}
+	result.ContractReceive = block
+	result.ReturnedError = methodErr
+	return result, nil
+}
+
+// addBalanceChanges adds the difference between the balances of before and after in changes, skipping unchanged tokens
+func addBalanceChanges(changes map[types.Address]map[types.ZenonTokenStandard]*big.Int, before, after store.Account) error {
+	beforeMap, err := before.GetBalanceMap()
+	if err != nil {
+		return err
+	}
+	afterMap, err := after.GetBalanceMap()
+	if err != nil {
+		return err
+	}
+
+	diff := make(map[types.ZenonTokenStandard]*big.Int)
+	for zts, balance := range afterMap {
+		diff[zts] = new(big.Int).Set(balance)
+	}
+	for zts, balance := range beforeMap {
+		if _, ok := diff[zts]; !ok {
+			diff[zts] = big.NewInt(0)
+		}
+		diff[zts].Sub(diff[zts], balance)
+	}
+	for zts, delta := range diff {
+		if delta.Sign() == 0 {
+			continue
+		}
+		if changes[*after.Address()] == nil {
+			changes[*after.Address()] = make(map[types.ZenonTokenStandard]*big.Int)
+		}
+		changes[*after.Address()][zts] = delta
+	}
+	return nil
+}
+
 func (s *Supervisor) setAll(template *nom.AccountBlock) error {
 	if err := s.setBlockMomentum(template); err != nil {
 		return err
@@ -12,6 +12,7 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/vm/constants"
 	"github.com/zenon-network/go-zenon/vm/embedded"
+	"github.com/zenon-network/go-zenon/vm/embedded/implementation"
 	"github.com/zenon-network/go-zenon/vm/vm_context"
 )
 
@@ -34,6 +35,21 @@ func errToStatus(err error) uint64 {
 	}
 }
 
+// StatusToString decodes the execution status which errToStatus stored in the data of an embedded receive-block
+func StatusToString(data []byte) string {
+	if len(data) != 8 {
+		return "invalid"
+	}
+	switch common.BytesToUint64(data) {
+	case resultSuccess:
+		return "success"
+	case resultFail:
+		return "fail"
+	default:
+		return "invalid"
+	}
+}
+
 type VM struct {
 	context vm_context.AccountVmContext
 }
@@ -95,7 +111,7 @@ func (vm *VM) applyBlock(block *nom.AccountBlock) error {
 	// In case vm will update some fields of block, make a copy of block.
 	switch block.BlockType {
 	case nom.BlockTypeUserSend, nom.BlockTypeContractSend:
-		return vm.applySend(block)
+		return vm.applySend(block, vm.context.IsSporkEnforced(types.TokenPolicySpork))
 	case nom.BlockTypeUserReceive:
 		return vm.applyReceive(block)
 	case nom.BlockTypeContractReceive:
@@ -115,7 +131,11 @@ func (vm *VM) applyBlock(block *nom.AccountBlock) error {
 		panic("unknown block type")
 	}
 }
-func (vm *VM) applySend(block *nom.AccountBlock) error {
+
+// applySend applies the send block on top of vm.context, the account which sends it.
+// checkPolicy is whether the transfer policies set by the owner of the token apply, so that callers
+// applying multiple blocks check the spork only once.
+func (vm *VM) applySend(block *nom.AccountBlock, checkPolicy bool) error {
 	// check can make transaction
 	if method, err := embedded.GetEmbeddedMethod(vm.context, block.ToAddress, block.Data); err != constants.ErrNotContractAddress {
 		if err != nil {
@@ -129,6 +149,14 @@ func (vm *VM) applySend(block *nom.AccountBlock) error {
 		}
 	}
 
+	// check the transfer policies set by the owner of the token
+	if checkPolicy {
+		tokenStorage := vm.context.MomentumStore().GetAccountStore(types.TokenContract).Storage()
+		if err := implementation.CheckTransferPolicy(tokenStorage, *vm.context.Address(), block); err != nil {
+			return err
+		}
+	}
+
 	// affect balance
 	if !enoughFunds(vm.context, block) {
 		return constants.ErrInsufficientBalance
@@ -164,12 +192,18 @@ func (vm *VM) generateEmbeddedReceive(fromBlockHash types.Hash) (*nom.AccountBlo
 	if err != nil {
 		return nil, nil, err
 	}
+	return vm.receiveEmbedded(sendBlock)
+}
+
+// receiveEmbedded applies sendBlock on top of the embedded contract and generates the receive nom.AccountBlock
+// The sendBlock is not required to be inserted in the chain, which allows simulating contract calls
+func (vm *VM) receiveEmbedded(sendBlock *nom.AccountBlock) (*nom.AccountBlock, error, error) {
 	method, err := embedded.GetEmbeddedMethod(vm.context, sendBlock.ToAddress, sendBlock.Data)
 
 	// can happen when a method is deleted in a spork (height 100) and someone calls it before the spork (height 95)
 	// and the autoReceive uses momentum height 105 for various reasons
 	if err == constants.ErrContractMethodNotFound {
-		return vm.rollbackEmbedded(fromBlockHash, err)
+		return vm.rollbackEmbedded(sendBlock, err)
 	}
 
 	vm.context.Save()
@@ -178,24 +212,22 @@ func (vm *VM) generateEmbeddedReceive(fromBlockHash types.Hash) (*nom.AccountBlo
 	// call code
 	descendantBlocks, err := method.ReceiveBlock(vm.context, sendBlock)
 	if err != nil {
-		return vm.rollbackEmbedded(fromBlockHash, err)
+		return vm.rollbackEmbedded(sendBlock, err)
 	}
-	// apply send-descendant-blocks
+	// apply send-descendant-blocks, the contract is restricted by the transfer policies like any other sender
+	checkPolicy := vm.context.IsSporkEnforced(types.TokenPolicySpork)
 	for _, dblock := range descendantBlocks {
-		err := vm.applySend(dblock)
+		err := vm.applySend(dblock, checkPolicy)
 		if err != nil {
-			return vm.rollbackEmbedded(fromBlockHash, err)
+			return vm.rollbackEmbedded(sendBlock, err)
 		}
 	}
 
 	// everything went right, no rollback required
 	vm.context.Done()
-	return vm.finalizeEmbedded(fromBlockHash, descendantBlocks, nil)
+	return vm.finalizeEmbedded(sendBlock.Hash, descendantBlocks, nil)
 }
-func (vm *VM) rollbackEmbedded(fromBlockHash types.Hash, methodErr error) (*nom.AccountBlock, error, error) {
-	sendBlock, err := vm.context.MomentumStore().GetAccountBlockByHash(fromBlockHash)
-	common.DealWithErr(err) // impossible to not find send-block at rollback
-
+func (vm *VM) rollbackEmbedded(sendBlock *nom.AccountBlock, methodErr error) (*nom.AccountBlock, error, error) {
 	vm.context.Reset()
 	// If sendBlock contains amount, add current amount to embedded to be able to refund it
 	// This operation was rollbacked with vm.context.Reset()
@@ -212,7 +244,8 @@ func (vm *VM) rollbackEmbedded(fromBlockHash types.Hash, methodErr error) (*nom.
 			TokenStandard: sendBlock.TokenStandard,
 		}
 
-		err := vm.applySend(dBlock)
+		// refunds only give the tokens back to their sender, so the transfer policies don't apply
+		err := vm.applySend(dBlock, false)
 		if err != nil {
 			log.Error("Unable to apply descendant blocks for refund", "reason", err, "send-block-hash", sendBlock.Hash)
 			return nil, nil, err
@@ -221,7 +254,7 @@ func (vm *VM) rollbackEmbedded(fromBlockHash types.Hash, methodErr error) (*nom.
 		descendantBlocks = append(descendantBlocks, dBlock)
 	}
 
-	return vm.finalizeEmbedded(fromBlockHash, descendantBlocks, methodErr)
+	return vm.finalizeEmbedded(sendBlock.Hash, descendantBlocks, methodErr)
 }
 func (vm *VM) finalizeEmbedded(fromBlockHash types.Hash, descendantBlocks []*nom.AccountBlock, executionError error) (*nom.AccountBlock, error, error) {
 	var err error
@@ -13,6 +13,8 @@ type accountVmContext struct {
 	api.PillarReader
 	store.Account
 	momentumStore store.Momentum
+
+	enforcedSporks map[types.Hash]bool
 }
 
 func (ctx *accountVmContext) MomentumStore() store.Momentum {
@@ -32,5 +32,8 @@ type AccountVmContext interface {
 
 	// ====== Spork ======
 
-	IsAcceleratorSporkEnforced() bool
+	// IsSporkEnforced returns true if the changes gated by spork are enforced at the frontier momentum
+	IsSporkEnforced(spork *types.ImplementedSpork) bool
+	// EnforcedSporks returns the ids of the sporks enforced at the frontier momentum
+	EnforcedSporks() map[types.Hash]bool
 }
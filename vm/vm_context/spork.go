@@ -5,8 +5,17 @@ import (
 	"github.com/zenon-network/go-zenon/common/types"
 )
 
-func (ctx *accountVmContext) IsAcceleratorSporkEnforced() bool {
-	active, err := ctx.momentumStore.IsSporkActive(types.AcceleratorSpork)
-	common.DealWithErr(err)
-	return active
+func (ctx *accountVmContext) IsSporkEnforced(spork *types.ImplementedSpork) bool {
+	return ctx.EnforcedSporks()[spork.SporkId]
+}
+
+// EnforcedSporks loads the defined sporks only on the first call,
+// the momentum store of a context doesn't change so neither do the enforced sporks.
+func (ctx *accountVmContext) EnforcedSporks() map[types.Hash]bool {
+	if ctx.enforcedSporks == nil {
+		enforced, err := ctx.momentumStore.GetEnforcedSporks()
+		common.DealWithErr(err)
+		ctx.enforcedSporks = enforced
+	}
+	return ctx.enforcedSporks
 }
@@ -0,0 +1,40 @@
+package vm_context
+
+import (
+	"testing"
+
+	"github.com/zenon-network/go-zenon/chain/store"
+	"github.com/zenon-network/go-zenon/common/types"
+)
+
+// countingMomentumStore counts how many times the enforced sporks are loaded
+type countingMomentumStore struct {
+	store.Momentum
+	enforced map[types.Hash]bool
+	loads    int
+}
+
+func (ms *countingMomentumStore) GetEnforcedSporks() (map[types.Hash]bool, error) {
+	ms.loads += 1
+	return ms.enforced, nil
+}
+
+func TestAccountVmContext_EnforcedSporks(t *testing.T) {
+	momentumStore := &countingMomentumStore{enforced: map[types.Hash]bool{types.TokenPolicySpork.SporkId: true}}
+	context := NewAccountContext(momentumStore, nil, nil)
+
+	for i := 0; i < 3; i += 1 {
+		if !context.IsSporkEnforced(types.TokenPolicySpork) {
+			t.Fatalf("token policy spork not enforced")
+		}
+		if context.IsSporkEnforced(types.StakeSpork) {
+			t.Fatalf("stake spork enforced")
+		}
+		if enforced := context.EnforcedSporks(); len(enforced) != 1 {
+			t.Fatalf("enforced sporks mismatch: have %v, want 1", len(enforced))
+		}
+	}
+	if momentumStore.loads != 1 {
+		t.Fatalf("enforced sporks loaded %v times, want once", momentumStore.loads)
+	}
+}
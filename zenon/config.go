@@ -2,11 +2,13 @@ package zenon
 
 import (
 	"path"
+	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
 
 	"github.com/zenon-network/go-zenon/chain/store"
 	"github.com/zenon-network/go-zenon/common/db"
+	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/wallet"
 )
 
@@ -15,7 +17,11 @@ type Config struct {
 	MinConnectedPeers int
 	DataDir           string
 	ProducingKeyPair  *wallet.KeyPair
+	ProducerLease     pillar.Lease  // enables the hot-standby mode of the producer if set
+	LeaseDuration     time.Duration // duration of ProducerLease
 	GenesisConfig     store.Genesis
+	EnableIndexer     bool
+	PruneEpochs       uint64
 }
 
 func (c *Config) NewDBManager(inside string) db.Manager {
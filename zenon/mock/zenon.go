@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/chain/genesis"
@@ -18,6 +21,7 @@ import (
 	"github.com/zenon-network/go-zenon/common/db"
 	"github.com/zenon-network/go-zenon/common/types"
 	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/protocol"
 	"github.com/zenon-network/go-zenon/verifier"
@@ -102,9 +106,13 @@ func getSignFunc(address types.Address) vm.SignFunc {
 type mockClock struct {
 	chain    chain.Chain
 	lastTime *time.Time
+	// the clock is also used by the goroutines of the node, such as the subscribe server
+	changes sync.Mutex
 }
 
 func (clock *mockClock) Now() time.Time {
+	clock.changes.Lock()
+	defer clock.changes.Unlock()
 	store := clock.chain.GetFrontierMomentumStore()
 	// DB didn't stop. Setting new frontier time.
 	if store != nil {
@@ -126,6 +134,8 @@ type mockZenon struct {
 	chain      chain.Chain
 	consensus  consensus.Consensus
 	supervisor *vm.Supervisor
+	indexer    indexer.Indexer
+	indexerDb  *leveldb.DB
 
 	loggers              []log15.Logger
 	handlers             []log15.Handler
@@ -166,6 +176,9 @@ func (zenon *mockZenon) CreateAccountBlock(accountBlockTransaction *nom.AccountB
 		zenon.log.Error("failed to insert own account-block.", "reason", err)
 	}
 }
+func (zenon *mockZenon) BroadcastAccountBlock(block *nom.AccountBlock) {
+	zenon.log.Info("broadcasted block", "identifier", block.Header())
+}
 
 func (zenon *mockZenon) InsertNewMomentum() {
 	store := zenon.chain.GetFrontierMomentumStore()
@@ -294,6 +307,9 @@ func (zenon *mockZenon) ExpectBalance(address types.Address, standard types.Zeno
 func (zenon *mockZenon) Init() error {
 	common.DealWithErr(zenon.chain.Init())
 	common.DealWithErr(zenon.consensus.Init())
+	if zenon.indexer != nil {
+		common.DealWithErr(zenon.indexer.Init())
+	}
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Init())
 	}
@@ -302,6 +318,10 @@ func (zenon *mockZenon) Init() error {
 func (zenon *mockZenon) Start() error {
 	common.DealWithErr(zenon.chain.Start())
 	common.DealWithErr(zenon.consensus.Start())
+	if zenon.indexer != nil {
+		common.DealWithErr(zenon.indexer.Start())
+		zenon.waitIndexer()
+	}
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Start())
 	}
@@ -311,10 +331,15 @@ func (zenon *mockZenon) Stop() error {
 	for _, pillarE := range zenon.pillars {
 		common.DealWithErr(pillarE.Stop())
 	}
+	if zenon.indexer != nil {
+		common.DealWithErr(zenon.indexer.Stop())
+		common.DealWithErr(zenon.indexerDb.Close())
+	}
 	common.DealWithErr(zenon.consensus.Stop())
 	common.DealWithErr(zenon.chain.Stop())
 
 	zenon.chain = nil
+	zenon.indexer = nil
 	zenon.consensus = nil
 	zenon.pillars = nil
 
@@ -351,15 +376,35 @@ func (zenon *mockZenon) Config() *zenon.Config {
 func (zenon *mockZenon) Broadcaster() protocol.Broadcaster {
 	return zenon
 }
+func (zenon *mockZenon) Indexer() indexer.Indexer {
+	return zenon.indexer
+}
+
+// waitIndexer blocks until the indexer caught up with the chain, so all the momentums inserted afterwards are indexed
+func (zenon *mockZenon) waitIndexer() {
+	for {
+		frontier, err := zenon.indexer.Frontier()
+		common.DealWithErr(err)
+		if frontier != nil && *frontier == zenon.chain.GetFrontierMomentumStore().Identifier() {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
 
 func NewMockZenon(t common.T) MockZenon {
-	return newMockZenon(t, consensus.EpochDuration)
+	return newMockZenon(t, consensus.EpochDuration, false)
 }
 func NewMockZenonWithCustomEpochDuration(t common.T, epochDuration time.Duration) MockZenon {
-	return newMockZenon(t, epochDuration)
+	return newMockZenon(t, epochDuration, false)
+}
+
+// NewMockZenonWithIndexer creates a mock zenon which also runs the indexer, similar to EnableIndexer
+func NewMockZenonWithIndexer(t common.T) MockZenon {
+	return newMockZenon(t, consensus.EpochDuration, true)
 }
 
-func newMockZenon(t common.T, customEpochDuration time.Duration) MockZenon {
+func newMockZenon(t common.T, customEpochDuration time.Duration, enableIndexer bool) MockZenon {
 	// silence loggers
 	common.ChainLogger.SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.StderrHandler))
 	common.ConsensusLogger.SetHandler(log15.LvlFilterHandler(log15.LvlError, log15.StderrHandler))
@@ -380,6 +425,13 @@ func newMockZenon(t common.T, customEpochDuration time.Duration) MockZenon {
 		initialEpochDuration: consensus.EpochDuration,
 	}
 
+	if enableIndexer {
+		indexerDb, err := leveldb.Open(storage.NewMemStorage(), nil)
+		common.DealWithErr(err)
+		zenon.indexer = indexer.NewIndexer(ch, indexerDb)
+		zenon.indexerDb = indexerDb
+	}
+
 	for i := range AllLoggers {
 		zenon.loggers[i] = AllLoggers[i]
 		zenon.handlers[i] = AllLoggers[i].GetHandler()
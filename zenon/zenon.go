@@ -1,15 +1,26 @@
 package zenon
 
 import (
+	"time"
+
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 
 	"github.com/zenon-network/go-zenon/chain"
 	"github.com/zenon-network/go-zenon/consensus"
+	"github.com/zenon-network/go-zenon/indexer"
 	"github.com/zenon-network/go-zenon/pillar"
 	"github.com/zenon-network/go-zenon/protocol"
 	"github.com/zenon-network/go-zenon/rpc/api/subscribe"
 	"github.com/zenon-network/go-zenon/verifier"
 	"github.com/zenon-network/go-zenon/vm"
+	"github.com/zenon-network/go-zenon/vm/constants"
+)
+
+const (
+	// MinPruneEpochs is the minimum number of epochs kept by a pruned node,
+	// since consensus and the reward updates of the embedded contracts read the momentums of the previous epochs
+	MinPruneEpochs = 2
 )
 
 type zenon struct {
@@ -24,6 +35,8 @@ type zenon struct {
 	evPrinter   EventPrinter
 	broadcaster protocol.Broadcaster
 	levelDb     *leveldb.DB
+	indexer     indexer.Indexer
+	indexerDb   *leveldb.DB
 }
 
 func NewZenon(cfg *Config) (Zenon, error) {
@@ -31,7 +44,18 @@ func NewZenon(cfg *Config) (Zenon, error) {
 		config: cfg,
 	}
 
+	if cfg.PruneEpochs != 0 {
+		if cfg.PruneEpochs < MinPruneEpochs {
+			return nil, errors.Errorf("a pruned node needs to keep at least %v epochs", MinPruneEpochs)
+		}
+		// the indexer needs to replay the whole chain when catching up
+		if cfg.EnableIndexer {
+			return nil, errors.Errorf("the indexer can't be enabled on a pruned node")
+		}
+	}
+
 	z.chain = chain.NewChain(cfg.NewDBManager("nom"), cfg.GenesisConfig)
+	z.chain.SetPruning(cfg.PruneEpochs * uint64(consensus.EpochDuration/time.Second) / uint64(constants.ConsensusConfig.BlockTime))
 	db, levelDb := cfg.NewLevelDB("consensus")
 	z.consensus = consensus.NewConsensus(db, z.chain, false)
 	z.verifier = verifier.NewVerifier(z.chain, z.consensus)
@@ -45,9 +69,17 @@ func NewZenon(cfg *Config) (Zenon, error) {
 	z.subscribe = subscribe.GetSubscribeServer(z.chain)
 	z.pillar = pillar.NewPillar(z.chain, z.consensus, z.broadcaster)
 
+	if cfg.EnableIndexer {
+		_, z.indexerDb = cfg.NewLevelDB("indexer")
+		z.indexer = indexer.NewIndexer(z.chain, z.indexerDb)
+	}
+
 	if cfg.ProducingKeyPair != nil {
 		z.pillar.SetCoinBase(cfg.ProducingKeyPair)
 	}
+	if cfg.ProducerLease != nil {
+		z.pillar.SetLease(cfg.ProducerLease, cfg.LeaseDuration)
+	}
 
 	return z, nil
 }
@@ -65,6 +97,11 @@ func (z *zenon) Init() error {
 	if err := z.subscribe.Init(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Init(); err != nil {
+			return err
+		}
+	}
 	//z.protocol.Init()
 	if err := z.pillar.Init(); err != nil {
 		return err
@@ -85,6 +122,11 @@ func (z *zenon) Start() error {
 	if err := z.subscribe.Start(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Start(); err != nil {
+			return err
+		}
+	}
 	if err := z.pillar.Start(); err != nil {
 		return err
 	}
@@ -97,6 +139,11 @@ func (z *zenon) Stop() error {
 	if err := z.pillar.Stop(); err != nil {
 		return err
 	}
+	if z.indexer != nil {
+		if err := z.indexer.Stop(); err != nil {
+			return err
+		}
+	}
 	if err := z.subscribe.Stop(); err != nil {
 		return err
 	}
@@ -112,6 +159,11 @@ func (z *zenon) Stop() error {
 	if err := z.levelDb.Close(); err != nil {
 		return err
 	}
+	if z.indexerDb != nil {
+		if err := z.indexerDb.Close(); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -137,3 +189,6 @@ func (z *zenon) Config() *Config {
 func (z *zenon) Broadcaster() protocol.Broadcaster {
 	return z.broadcaster
 }
+func (z *zenon) Indexer() indexer.Indexer {
+	return z.indexer
+}